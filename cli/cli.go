@@ -1,7 +1,11 @@
 package cli
 
 import (
+	"context"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/bloxapp/ssv-dkg/cli/initiator"
 	"github.com/bloxapp/ssv-dkg/cli/operator"
@@ -11,6 +15,11 @@ import (
 
 func init() {
 	RootCmd.AddCommand(initiator.StartDKG)
+	RootCmd.AddCommand(initiator.StartReshare)
+	RootCmd.AddCommand(initiator.Resume)
+	RootCmd.AddCommand(initiator.Ping)
+	RootCmd.AddCommand(initiator.VerifyTranscript)
+	RootCmd.AddCommand(initiator.Verify)
 	RootCmd.AddCommand(operator.StartDKGOperator)
 }
 
@@ -22,12 +31,14 @@ var RootCmd = &cobra.Command{
 	},
 }
 
-// Execute executes the root command
+// Execute executes the root command, an interrupt cancels the context of the command
 func Execute(appName, version string) {
 	RootCmd.Short = appName
 	RootCmd.Version = version
 
-	if err := RootCmd.Execute(); err != nil {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := RootCmd.ExecuteContext(ctx); err != nil {
 		log.Fatal("failed to execute root command", zap.Error(err))
 	}
 }
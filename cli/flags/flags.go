@@ -27,15 +27,44 @@ const (
 	password                 = "password"
 	outputPath               = "outputPath"
 	storeShare               = "storeShare"
+	sharesPath               = "sharesPath"
+	policyPath               = "policyPath"
+	validators               = "validators"
+	batchSize                = "batchSize"
+	depositAmount            = "depositAmount"
+	compounding              = "compounding"
+	tolerateOffline          = "tolerateOffline"
 	logLevel                 = "logLevel"
 	logFormat                = "logFormat"
 	logLevelFormat           = "logLevelFormat"
 	logFilePath              = "logFilePath"
+	oldOperatorIDs           = "oldOperatorIDs"
+	newOperatorIDs           = "newOperatorIDs"
+	validatorPK              = "validatorPK"
+	oldThreshold             = "oldThreshold"
+	minOperators             = "minOperators"
+	maxOperators             = "maxOperators"
+	phaser                   = "phaser"
+	phaseTimeout             = "phaseTimeout"
+	requestTimeout           = "requestTimeout"
+	requestsPath             = "requestsPath"
+	tlsCert                  = "tlsCert"
+	tlsKey                   = "tlsKey"
+	tlsPinned                = "tlsPinned"
+	tlsCA                    = "tlsCA"
+	tlsClientCA              = "tlsClientCA"
+	metricsPushURL           = "metricsPushURL"
+	transcriptPath           = "transcriptPath"
+	keysharesPath            = "keysharesPath"
+	depositDataPath          = "depositDataPath"
+	proofsPath               = "proofsPath"
+	statePath                = "statePath"
+	abort                    = "abort"
 )
 
 // ThresholdFlag adds threshold flag to the command
 func ThresholdFlag(c *cobra.Command) {
-	AddPersistentIntFlag(c, threshold, 0, "Threshold for distributed signature", false)
+	AddPersistentIntFlag(c, threshold, 0, "Threshold for distributed signature, at least a majority of the operators (default: 3f+1 of the operators)", false)
 }
 
 // GetThresholdFlagValue gets threshold flag from the command
@@ -43,6 +72,16 @@ func GetThresholdFlagValue(c *cobra.Command) (uint64, error) {
 	return c.Flags().GetUint64(threshold)
 }
 
+// MinOperatorsFlag adds min amount of operators of a ceremony flag to the command
+func MinOperatorsFlag(c *cobra.Command) {
+	AddPersistentIntFlag(c, minOperators, 4, "Min amount of operators of a ceremony", false)
+}
+
+// MaxOperatorsFlag adds max amount of operators of a ceremony flag to the command
+func MaxOperatorsFlag(c *cobra.Command) {
+	AddPersistentIntFlag(c, maxOperators, 13, "Max amount of operators of a ceremony", false)
+}
+
 // WithdrawAddressFlag  adds withdraw address flag to the command
 func WithdrawAddressFlag(c *cobra.Command) {
 	AddPersistentStringFlag(c, withdrawAddress, "", "Withdrawal address", false)
@@ -63,6 +102,26 @@ func GetoperatorIDsFlagValue(c *cobra.Command) ([]string, error) {
 	return c.Flags().GetStringSlice(operatorIDs)
 }
 
+// OldOperatorIDsFlag adds IDs of the operators holding the current shares flag to the command
+func OldOperatorIDsFlag(c *cobra.Command) {
+	AddPersistentStringSliceFlag(c, oldOperatorIDs, []string{}, "Operator IDs holding the current validator key shares", false)
+}
+
+// NewOperatorIDsFlag adds IDs of the operators receiving new shares flag to the command
+func NewOperatorIDsFlag(c *cobra.Command) {
+	AddPersistentStringSliceFlag(c, newOperatorIDs, []string{}, "Operator IDs to receive the new validator key shares", false)
+}
+
+// ValidatorPKFlag adds validator public key flag to the command
+func ValidatorPKFlag(c *cobra.Command) {
+	AddPersistentStringFlag(c, validatorPK, "", "Public key of the validator to reshare", false)
+}
+
+// OldThresholdFlag adds threshold of the current shares flag to the command
+func OldThresholdFlag(c *cobra.Command) {
+	AddPersistentIntFlag(c, oldThreshold, 0, "Threshold of the current validator key shares", false)
+}
+
 // OperatorsInfoFlag  adds path to operators' ifo file flag to the command
 func OperatorsInfoFlag(c *cobra.Command) {
 	AddPersistentStringFlag(c, operatorsInfo, "", "Raw JSON string operators' public keys, IDs and IPs file e.g. `{ 1: { publicKey: XXX, id: 1, ip: 10.0.0.1:3033 }`", false)
@@ -103,9 +162,64 @@ func GetNonceFlagValue(c *cobra.Command) (uint64, error) {
 	return c.Flags().GetUint64(nonce)
 }
 
+// ValidatorsFlag adds number of validators to create flag to the command
+func ValidatorsFlag(c *cobra.Command) {
+	AddPersistentIntFlag(c, validators, 1, "Number of validators to create, owner nonce is increased for every validator", false)
+}
+
+// BatchSizeFlag adds number of ceremonies running in parallel in a batch flag to the command
+func BatchSizeFlag(c *cobra.Command) {
+	AddPersistentIntFlag(c, batchSize, 5, "Number of ceremonies running in parallel in a batch when creating multiple validators, batches run one after another", false)
+}
+
+// DepositAmountFlag adds deposit amount in Gwei flag to the command
+func DepositAmountFlag(c *cobra.Command) {
+	AddPersistentIntFlag(c, depositAmount, 32000000000, "Deposit amount in Gwei, up to 32 ETH or up to 2048 ETH with compounding withdrawal credentials", false)
+}
+
+// CompoundingFlag adds compounding (0x02) withdrawal credentials flag to the command
+func CompoundingFlag(c *cobra.Command) {
+	AddPersistentBoolFlag(c, compounding, false, "Use compounding (0x02) withdrawal credentials instead of 0x01", false)
+}
+
+// TolerateOfflineFlag adds the flag to continue a ceremony without offline operators to the command
+func TolerateOfflineFlag(c *cobra.Command) {
+	AddPersistentBoolFlag(c, tolerateOffline, false, "Continue without operators which are offline at the start of the ceremony if the threshold can be met, key shares miss their shares", false)
+}
+
+// TranscriptPathFlag adds the path to a ceremony transcript flag to the command
+func TranscriptPathFlag(c *cobra.Command) {
+	AddPersistentStringFlag(c, transcriptPath, "", "Path to a ceremony transcript file", true)
+}
+
+// KeysharesPathFlag adds the path to a keyshares file flag to the command
+func KeysharesPathFlag(c *cobra.Command) {
+	AddPersistentStringFlag(c, keysharesPath, "", "Path to a keyshares file to check", false)
+}
+
+// DepositDataPathFlag adds the path to a deposit data file flag to the command
+func DepositDataPathFlag(c *cobra.Command) {
+	AddPersistentStringFlag(c, depositDataPath, "", "Path to a deposit data file", true)
+}
+
+// ProofsPathFlag adds the path to an operator proofs file flag to the command
+func ProofsPathFlag(c *cobra.Command) {
+	AddPersistentStringFlag(c, proofsPath, "", "Path to a file with proofs of the operators that they created the shares", false)
+}
+
+// StatePathFlag adds the path to a ceremony state file flag to the command
+func StatePathFlag(c *cobra.Command) {
+	AddPersistentStringFlag(c, statePath, "", "Path to the state file of an interrupted ceremony", true)
+}
+
+// AbortFlag adds the flag to abort a ceremony instead of resuming it to the command
+func AbortFlag(c *cobra.Command) {
+	AddPersistentBoolFlag(c, abort, false, "Abort the ceremony at the operators and remove its state instead of resuming it", false)
+}
+
 // NetworkFlag  adds the fork version of the network flag to the command
 func NetworkFlag(c *cobra.Command) {
-	AddPersistentStringFlag(c, network, "mainnet", "Network name: mainnet, prater, or now_test_network", false)
+	AddPersistentStringFlag(c, network, "mainnet", "Network name: mainnet, prater, holesky, now_test_network or a custom network from the config file", false)
 }
 
 // OperatorPrivateKeyFlag  adds private key flag to the command
@@ -297,7 +411,67 @@ func GetResultPathFlag(c *cobra.Command) (string, error) {
 }
 
 func StoreShareFlag(c *cobra.Command) {
-	AddPersistentBoolFlag(c, storeShare, false, "Store BLS share as json", false)
+	AddPersistentBoolFlag(c, storeShare, false, "Store BLS share encrypted with the operator password", false)
+}
+
+// SharesPathFlag adds path to the directory of stored shares flag to the command
+func SharesPathFlag(c *cobra.Command) {
+	AddPersistentStringFlag(c, sharesPath, "./shares", "Path to the directory of stored shares", false)
+}
+
+// PolicyPathFlag adds path to the operator policy file flag to the command
+func PolicyPathFlag(c *cobra.Command) {
+	AddPersistentStringFlag(c, policyPath, "", "Path to the policy file limiting initiators, owners, withdrawal addresses, networks and co-operators of ceremonies", false)
+}
+
+// PhaserFlag adds DKG phaser flag to the command
+func PhaserFlag(c *cobra.Command) {
+	AddPersistentStringFlag(c, phaser, "event", "DKG phaser: event moves to the next phase as soon as the messages of all operators arrive, time waits the phase timeout in every phase", false)
+}
+
+// PhaseTimeoutFlag adds max duration of a DKG phase flag to the command
+func PhaseTimeoutFlag(c *cobra.Command) {
+	AddPersistentStringFlag(c, phaseTimeout, "5s", "Max duration of a DKG phase, e.g. 5s or 1m", false)
+}
+
+// RequestTimeoutFlag adds max duration of a request to an operator flag to the command
+func RequestTimeoutFlag(c *cobra.Command) {
+	AddPersistentStringFlag(c, requestTimeout, "30s", "Max duration of a request to an operator, e.g. 30s or 1m", false)
+}
+
+// RequestsPathFlag adds path to the record of used request IDs flag to the command
+func RequestsPathFlag(c *cobra.Command) {
+	AddPersistentStringFlag(c, requestsPath, "./requests.json", "Path to the record of request IDs of init messages, replayed init messages are rejected", false)
+}
+
+// TLSCertFlag adds path to a TLS certificate flag to the command
+func TLSCertFlag(c *cobra.Command) {
+	AddPersistentStringFlag(c, tlsCert, "", "Path to a PEM encoded TLS certificate: the certificate served by the operator, or the client certificate of the initiator for operators requiring mTLS", false)
+}
+
+// TLSKeyFlag adds path to the key of a TLS certificate flag to the command
+func TLSKeyFlag(c *cobra.Command) {
+	AddPersistentStringFlag(c, tlsKey, "", "Path to the PEM encoded key of the TLS certificate", false)
+}
+
+// TLSPinnedFlag adds TLS certificates pinned to operator keys flag to the command
+func TLSPinnedFlag(c *cobra.Command) {
+	AddPersistentBoolFlag(c, tlsPinned, false, "Use TLS certificates pinned to operator RSA keys: the operator serves a certificate issued by its key, the initiator verifies operator certificates with their keys of operators info", false)
+}
+
+// TLSCAFlag adds path to certificate authorities of operator certificates flag to the command
+func TLSCAFlag(c *cobra.Command) {
+	AddPersistentStringFlag(c, tlsCA, "", "Path to PEM encoded certificate authorities of operator TLS certificates, system authorities are used if not set", false)
+}
+
+// TLSClientCAFlag adds path to trusted initiator client certificates flag to the command
+func TLSClientCAFlag(c *cobra.Command) {
+	AddPersistentStringFlag(c, tlsClientCA, "", "Path to PEM encoded initiator client certificates or their authorities, initiators without a trusted client certificate are rejected (mTLS) if set", false)
+}
+
+// MetricsPushURLFlag adds Prometheus Pushgateway URL flag to the command
+func MetricsPushURLFlag(c *cobra.Command) {
+	AddPersistentStringFlag(c, metricsPushURL, "", "Prometheus Pushgateway URL to push latency and errors of requests to operators to, metrics aren't pushed if not set", false)
 }
 
 func GetStoreShareFlag(c *cobra.Command) (bool, error) {
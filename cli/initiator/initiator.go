@@ -4,6 +4,7 @@ import (
 	"crypto/rsa"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
@@ -11,9 +12,11 @@ import (
 	"github.com/bloxapp/ssv-dkg/cli/flags"
 	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
 	"github.com/bloxapp/ssv-dkg/pkgs/initiator"
+	"github.com/bloxapp/ssv-dkg/pkgs/network"
+	"github.com/bloxapp/ssv-dkg/pkgs/threshold"
 	"github.com/bloxapp/ssv-dkg/pkgs/utils"
 
-	"github.com/bloxapp/ssv/logging"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/bloxapp/ssv/utils/rsaencryption"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -31,63 +34,35 @@ func init() {
 	flags.OperatorIDsFlag(StartDKG)
 	flags.OwnerAddressFlag(StartDKG)
 	flags.NonceFlag(StartDKG)
+	flags.ValidatorsFlag(StartDKG)
+	flags.BatchSizeFlag(StartDKG)
+	flags.RequestTimeoutFlag(StartDKG)
+	flags.ThresholdFlag(StartDKG)
+	flags.MinOperatorsFlag(StartDKG)
+	flags.MaxOperatorsFlag(StartDKG)
+	flags.DepositAmountFlag(StartDKG)
+	flags.CompoundingFlag(StartDKG)
+	flags.TolerateOfflineFlag(StartDKG)
 	flags.NetworkFlag(StartDKG)
 	flags.ResultPathFlag(StartDKG)
+	flags.MetricsPushURLFlag(StartDKG)
+	flags.TLSCAFlag(StartDKG)
+	flags.TLSPinnedFlag(StartDKG)
+	flags.TLSCertFlag(StartDKG)
+	flags.TLSKeyFlag(StartDKG)
 	flags.ConfigPathFlag(StartDKG)
 	flags.LogLevelFlag(StartDKG)
 	flags.LogFormatFlag(StartDKG)
 	flags.LogLevelFormatFlag(StartDKG)
 	flags.LogFilePathFlag(StartDKG)
-	if err := viper.BindPFlag("withdrawAddress", StartDKG.PersistentFlags().Lookup("withdrawAddress")); err != nil {
-		panic(err)
-	}
-	if err := viper.BindPFlag("operatorIDs", StartDKG.PersistentFlags().Lookup("operatorIDs")); err != nil {
-		panic(err)
-	}
-	if err := viper.BindPFlag("operatorsInfo", StartDKG.PersistentFlags().Lookup("operatorsInfo")); err != nil {
-		panic(err)
-	}
-	if err := viper.BindPFlag("operatorsInfoPath", StartDKG.PersistentFlags().Lookup("operatorsInfoPath")); err != nil {
-		panic(err)
-	}
-	if err := viper.BindPFlag("owner", StartDKG.PersistentFlags().Lookup("owner")); err != nil {
-		panic(err)
-	}
-	if err := viper.BindPFlag("nonce", StartDKG.PersistentFlags().Lookup("nonce")); err != nil {
-		panic(err)
-	}
-	if err := viper.BindPFlag("network", StartDKG.PersistentFlags().Lookup("network")); err != nil {
-		panic(err)
-	}
-	if err := viper.BindPFlag("outputPath", StartDKG.PersistentFlags().Lookup("outputPath")); err != nil {
-		panic(err)
-	}
-	if err := viper.BindPFlag("initiatorPrivKey", StartDKG.PersistentFlags().Lookup("initiatorPrivKey")); err != nil {
-		panic(err)
-	}
-	if err := viper.BindPFlag("initiatorPrivKeyPassword", StartDKG.PersistentFlags().Lookup("initiatorPrivKeyPassword")); err != nil {
-		panic(err)
-	}
-	if err := viper.BindPFlag("generateInitiatorKey", StartDKG.PersistentFlags().Lookup("generateInitiatorKey")); err != nil {
-		panic(err)
-	}
-	if err := viper.BindPFlag("logLevel", StartDKG.PersistentFlags().Lookup("logLevel")); err != nil {
-		panic(err)
-	}
-	if err := viper.BindPFlag("logFormat", StartDKG.PersistentFlags().Lookup("logFormat")); err != nil {
-		panic(err)
-	}
-	if err := viper.BindPFlag("logLevelFormat", StartDKG.PersistentFlags().Lookup("logLevelFormat")); err != nil {
-		panic(err)
-	}
-	if err := viper.BindPFlag("logFilePath", StartDKG.PersistentFlags().Lookup("logFilePath")); err != nil {
-		panic(err)
-	}
 }
 
 var StartDKG = &cobra.Command{
 	Use:   "init",
 	Short: "Initiates a DKG protocol",
+	PreRun: func(cmd *cobra.Command, args []string) {
+		bindFlags(cmd, "withdrawAddress", "operatorIDs", "operatorsInfo", "operatorsInfoPath", "owner", "nonce", "validators", "batchSize", "requestTimeout", "threshold", "minOperators", "maxOperators", "depositAmount", "compounding", "tolerateOffline", "network", "outputPath", "metricsPushURL", "initiatorPrivKey", "initiatorPrivKeyPassword", "generateInitiatorKey", "tlsCA", "tlsPinned", "tlsCert", "tlsKey", "logLevel", "logFormat", "logLevelFormat", "logFilePath")
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		fmt.Println(`
 		█████╗ ██╗  ██╗ ██████╗     ██╗███╗   ██╗██╗████████╗██╗ █████╗ ████████╗ ██████╗ ██████╗ 
@@ -96,101 +71,20 @@ var StartDKG = &cobra.Command{
 		██║  ██║██╔═██╗ ██║   ██║    ██║██║╚██╗██║██║   ██║   ██║██╔══██║   ██║   ██║   ██║██╔══██╗
 		██████╔╝██║  ██╗╚██████╔╝    ██║██║ ╚████║██║   ██║   ██║██║  ██║   ██║   ╚██████╔╝██║  ██║
 		╚═════╝ ╚═╝  ╚═╝ ╚═════╝     ╚═╝╚═╝  ╚═══╝╚═╝   ╚═╝   ╚═╝╚═╝  ╚═╝   ╚═╝    ╚═════╝ ╚═╝  ╚═╝`)
-		viper.SetConfigType("yaml")
-		configPath, err := flags.GetConfigPathFlagValue(cmd)
-		if err != nil {
-			return err
-		}
-		if configPath != "" {
-			viper.SetConfigFile(configPath)
-		}
-		if err := viper.ReadInConfig(); err != nil {
-			if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
-				return err
-			}
-			fmt.Print("⚠️ config file was not provided, using flag parameters \n")
-		}
-		logLevel := viper.GetString("logLevel")
-		logFormat := viper.GetString("logFormat")
-		logLevelFormat := viper.GetString("logLevelFormat")
-		viper.SetDefault("logFilePath", "./initiator_debug.log")
-		logFilePath := viper.GetString("logFilePath")
-		if logFilePath == "" {
-			fmt.Print("⚠️ debug log path was not provided, using default: ./initiator_debug.log \n")
-		}
-		// If the log file doesn't exist, create it
-		_, err = os.OpenFile(logFilePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		logger, err := setGlobalLogger(cmd, "dkg-initiator")
 		if err != nil {
 			return err
 		}
-		if err := logging.SetGlobalLogger(logLevel, logFormat, logLevelFormat, &logging.LogFileOptions{FileName: logFilePath}); err != nil {
-			return fmt.Errorf("logging.SetGlobalLogger: %w", err)
-		}
-		logger := zap.L().Named("dkg-initiator")
 		// Check paths for results
 		outputPath := viper.GetString("outputPath")
 		if outputPath == "" {
-			logger.Fatal("😥 Failed to get deposit result path flag value: ", zap.Error(err))
+			logger.Fatal("😥 Failed to get deposit result path flag value")
 		}
 		if stat, err := os.Stat(outputPath); err != nil || !stat.IsDir() {
 			logger.Fatal("😥 Error to to open path to store results", zap.Error(err))
 		}
 		// Load operators TODO: add more sources.
-		operatorsInfo := viper.GetString("operatorsInfo")
-		operatorsInfoPath := viper.GetString("operatorsInfoPath")
-		if operatorsInfo == "" && operatorsInfoPath == "" {
-			logger.Fatal("😥 Operators string or path have not provided")
-		}
-		if operatorsInfo != "" && operatorsInfoPath != "" {
-			logger.Fatal("😥 Please provide either operator info string or path, not both")
-		}
-		var opMap initiator.Operators
-		if operatorsInfo != "" {
-			logger.Info("📖 reading raw JSON string of operators info")
-			opMap, err = initiator.LoadOperatorsJson([]byte(operatorsInfo))
-			if err != nil {
-				logger.Fatal("😥 Failed to load operators: ", zap.Error(err))
-			}
-		}
-		if operatorsInfoPath != "" {
-			logger.Info("📖 looking operators info 'operators_info.json' file", zap.String("at path", operatorsInfoPath))
-			stat, err := os.Stat(operatorsInfoPath)
-			if os.IsNotExist(err) {
-				logger.Fatal("😥 Failed to read operator info file: ", zap.Error(err))
-			}
-			if stat.IsDir() {
-				filePath := operatorsInfoPath + "operators_info.json"
-				if _, err := os.Stat(filePath); os.IsNotExist(err) {
-					logger.Fatal("😥 Failed to find operator info file at provided path: ", zap.Error(err))
-				}
-				opsfile, err := os.ReadFile(filePath)
-				if err != nil {
-					logger.Fatal("😥 Failed to read operator info file:", zap.Error(err))
-				}
-				opMap, err = initiator.LoadOperatorsJson(opsfile)
-				if err != nil {
-					logger.Fatal("😥 Failed to load operators: ", zap.Error(err))
-				}
-			} else {
-				logger.Info("📖 reading operators info JSON file")
-				opsfile, err := os.ReadFile(operatorsInfoPath)
-				if err != nil {
-					logger.Fatal("😥 Failed to read operator info file: ", zap.Error(err))
-				}
-				opMap, err = initiator.LoadOperatorsJson(opsfile)
-				if err != nil {
-					logger.Fatal("😥 Failed to load operators: ", zap.Error(err))
-				}
-			}
-		}
-		participants := viper.GetStringSlice("operatorIDs")
-		if participants == nil {
-			logger.Fatal("😥 Failed to get operator IDs flag value: ", zap.Error(err))
-		}
-		parts, err := loadParticipants(participants)
-		if err != nil {
-			logger.Fatal("😥 Failed to load participants: ", zap.Error(err))
-		}
+		opMap := loadOperators(logger)
 		privKeyPath := viper.GetString("initiatorPrivKey")
 		generateInitiatorKey := viper.GetBool("generateInitiatorKey")
 		if privKeyPath == "" && !generateInitiatorKey {
@@ -204,32 +98,7 @@ var StartDKG = &cobra.Command{
 		var password string
 		pass := viper.GetString("initiatorPrivKeyPassword")
 		if privKeyPath != "" && !generateInitiatorKey {
-			logger.Info("🔑 opening initiator RSA private key file")
-			if pass != "" {
-				logger.Info("🔑 password for key provided - decrypting")
-				// check if a password string a valid path, then read password from the file
-				if _, err := os.Stat(pass); os.IsNotExist(err) {
-					logger.Fatal("😥 Password file doesn`t exist: ", zap.Error(err))
-				}
-				encryptedRSAJSON, err := os.ReadFile(privKeyPath)
-				if err != nil {
-					logger.Fatal("😥 Cant read operator`s key file", zap.Error(err))
-				}
-				keyStorePassword, err := os.ReadFile(pass)
-				if err != nil {
-					logger.Fatal("😥 Error reading password file: ", zap.Error(err))
-				}
-				privateKey, err = crypto.ConvertEncryptedPemToPrivateKey(encryptedRSAJSON, string(keyStorePassword))
-				if err != nil {
-					logger.Fatal(err.Error())
-				}
-			} else {
-				logger.Info("🔑 password for key NOT provided - trying to read plaintext key")
-				privateKey, err = crypto.PrivateKey(privKeyPath)
-				if err != nil {
-					logger.Fatal("😥 Error reading plaintext private key from file: ", zap.Error(err))
-				}
-			}
+			privateKey = loadInitiatorKey(logger, privKeyPath, pass)
 		}
 		if privKeyPath == "" && generateInitiatorKey {
 			logger.Info("🔑 generating new initiator RSA key pair + password")
@@ -256,58 +125,63 @@ var StartDKG = &cobra.Command{
 				logger.Fatal(err.Error())
 			}
 		}
-
-		dkgInitiator := initiator.New(privateKey, opMap, logger)
-		withdrawAddr := viper.GetString("withdrawAddress")
-		if withdrawAddr == "" {
-			logger.Fatal("😥 Failed to get withdrawal address flag value: ", zap.Error(err))
-		}
-		network := viper.GetString("network")
-		if network == "" {
-			logger.Fatal("😥 Failed to get fork version flag value: ", zap.Error(err))
-		}
-		var forkHEX [4]byte
-		switch network {
-		case "prater":
-			forkHEX = [4]byte{0x00, 0x00, 0x10, 0x20}
-		case "mainnet":
-			forkHEX = [4]byte{0, 0, 0, 0}
-		case "now_test_network":
-			forkHEX = [4]byte{0x99, 0x99, 0x99, 0x99}
-		default:
-			logger.Fatal("😥 Please provide a valid network name: mainnet, prater, or now_test_network")
-		}
-		owner := viper.GetString("owner")
-		if owner == "" {
-			logger.Fatal("😥 Failed to get owner address flag value: ", zap.Error(err))
-		}
-		ownerAddress, err := utils.HexToAddress(owner)
+		registerNetworks(logger)
+		ceremonyReq, err := loadCeremonyRequest()
 		if err != nil {
-			logger.Fatal("😥 Failed to parse owner address: ", zap.Error(err))
+			logger.Fatal("😥 Wrong ceremony parameters: ", zap.Error(err))
 		}
-		nonce := viper.GetUint64("nonce")
-		withdrawAddress, err := utils.HexToAddress(withdrawAddr)
+		opts, err := loadInitiatorOptions(logger)
 		if err != nil {
-			logger.Fatal("😥 Failed to parse withdraw address: ", zap.Error(err))
+			logger.Fatal("😥 Wrong initiator parameters: ", zap.Error(err))
 		}
-		id := crypto.NewID()
-		depositData, keyShares, err := dkgInitiator.StartDKG(id, withdrawAddress.Bytes(), parts, forkHEX, network, ownerAddress, nonce)
-		if err != nil {
-			logger.Fatal("😥 Failed to initiate DKG ceremony: ", zap.Error(err))
+		// the ceremony can be resumed only with the key of the initiator which started it
+		if privKeyPath != "" {
+			opts = append(opts, initiator.WithStateDir(outputPath))
 		}
-		// Save deposit file
-		logger.Info("🎯  All data is validated.")
-		depositFinalPath := fmt.Sprintf("%s/deposit_%s.json", outputPath, depositData.PubKey)
-		logger.Info("💾 Writing deposit data json to file", zap.String("path", depositFinalPath))
-		err = utils.WriteJSON(depositFinalPath, []initiator.DepositDataJson{*depositData})
+		dkgInitiator, err := initiator.NewInitiator(privateKey, opMap, opts...)
 		if err != nil {
-			logger.Warn("Failed writing deposit data file: ", zap.Error(err))
+			logger.Fatal("😥 Failed to create initiator: ", zap.Error(err))
 		}
-		keysharesFinalPath := fmt.Sprintf("%s/keyshares-%v.json", outputPath, depositData.PubKey)
-		logger.Info("💾 Writing keyshares payload to file", zap.String("path", keysharesFinalPath))
-		err = utils.WriteJSON(keysharesFinalPath, keyShares)
-		if err != nil {
-			logger.Warn("Failed writing keyshares file: ", zap.Error(err))
+		var depositData *initiator.DepositDataJson
+		if ceremonyReq.Validators == 1 {
+			ceremonyReq.ID = crypto.NewID()
+			result, err := dkgInitiator.Run(cmd.Context(), ceremonyReq)
+			pushMetrics(logger)
+			writeRunTranscript(logger, result, err, fmt.Sprintf("%s/transcript-%x.json", outputPath, ceremonyReq.ID))
+			if err != nil {
+				logger.Fatal("😥 Failed to initiate DKG ceremony: ", zap.Error(err))
+			}
+			logger.Info("🎯  All data is validated.")
+			depositData = result.DepositData[0]
+			writeResults(logger, outputPath, depositData, result.KeyShares[0])
+		} else {
+			depositFinalPath := fmt.Sprintf("%s/deposit_data.json", outputPath)
+			keysharesFinalPath := fmt.Sprintf("%s/keyshares.json", outputPath)
+			proofsFinalPath := fmt.Sprintf("%s/proofs.json", outputPath)
+			transcriptFinalPath := fmt.Sprintf("%s/transcript.json", outputPath)
+			// don't overwrite results of a previous run
+			for _, path := range []string{depositFinalPath, keysharesFinalPath, proofsFinalPath, transcriptFinalPath} {
+				if _, err := os.Stat(path); err == nil {
+					logger.Fatal("😥 Result file already exists, please provide another output path", zap.String("path", path))
+				}
+			}
+			validators := uint64(ceremonyReq.Validators)
+			logger.Info("🚀 Creating validators", zap.Uint64("validators", validators), zap.Uint64("from_nonce", ceremonyReq.Nonce), zap.Uint64("to_nonce", ceremonyReq.Nonce+validators-1))
+			result, err := dkgInitiator.Run(cmd.Context(), ceremonyReq)
+			pushMetrics(logger)
+			writeRunTranscript(logger, result, err, transcriptFinalPath)
+			if err != nil {
+				// validators of the batches which finished exist at the operators, their results can't be lost
+				var cerr *initiator.CeremonyError
+				if errors.As(err, &cerr) && cerr.Result != nil {
+					logger.Warn("⚠️ Saving results of the validators created before the failure", zap.Int("validators", len(cerr.Result.DepositData)))
+					writeBulkResults(logger, cerr.Result, depositFinalPath, keysharesFinalPath, proofsFinalPath)
+				}
+				logger.Fatal("😥 Failed to initiate DKG ceremonies: ", zap.Error(err))
+			}
+			logger.Info("🎯  All data is validated.")
+			depositData = result.DepositData[0]
+			writeBulkResults(logger, result, depositFinalPath, keysharesFinalPath, proofsFinalPath)
 		}
 		if privKeyPath == "" && generateInitiatorKey {
 			rsaKeyPath := fmt.Sprintf("%s/encrypted_private_key-%v.json", outputPath, depositData.PubKey)
@@ -343,6 +217,84 @@ var StartDKG = &cobra.Command{
 	},
 }
 
+// loadCeremonyRequest parses the flags of the init command describing the created validators
+func loadCeremonyRequest() (initiator.CeremonyRequest, error) {
+	participants := viper.GetStringSlice("operatorIDs")
+	if len(participants) == 0 {
+		return initiator.CeremonyRequest{}, fmt.Errorf("operator IDs should be provided")
+	}
+	parts, err := loadParticipants(participants)
+	if err != nil {
+		return initiator.CeremonyRequest{}, err
+	}
+	withdrawAddr := viper.GetString("withdrawAddress")
+	if withdrawAddr == "" {
+		return initiator.CeremonyRequest{}, fmt.Errorf("withdrawal address should be provided")
+	}
+	withdrawAddress, err := utils.HexToAddress(withdrawAddr)
+	if err != nil {
+		return initiator.CeremonyRequest{}, fmt.Errorf("failed to parse withdraw address: %w", err)
+	}
+	owner := viper.GetString("owner")
+	if owner == "" {
+		return initiator.CeremonyRequest{}, fmt.Errorf("owner address should be provided")
+	}
+	ownerAddress, err := utils.HexToAddress(owner)
+	if err != nil {
+		return initiator.CeremonyRequest{}, fmt.Errorf("failed to parse owner address: %w", err)
+	}
+	networkName := viper.GetString("network")
+	if _, err := network.ByName(networkName); err != nil {
+		return initiator.CeremonyRequest{}, fmt.Errorf("please provide a valid network name: %w", err)
+	}
+	validators := viper.GetUint64("validators")
+	if validators == 0 {
+		return initiator.CeremonyRequest{}, fmt.Errorf("number of validators should be at least 1")
+	}
+	if viper.GetBool("tolerateOffline") && validators > 1 {
+		return initiator.CeremonyRequest{}, fmt.Errorf("offline operators are tolerated only when creating a single validator")
+	}
+	return initiator.CeremonyRequest{
+		OperatorIDs:       parts,
+		WithdrawalAddress: withdrawAddress,
+		Owner:             ownerAddress,
+		Nonce:             viper.GetUint64("nonce"),
+		Network:           networkName,
+		Validators:        int(validators),
+	}, nil
+}
+
+// loadInitiatorOptions parses the flags of the init command configuring the initiator
+func loadInitiatorOptions(logger *zap.Logger) ([]initiator.Option, error) {
+	policy, err := threshold.NewPolicy(viper.GetInt("minOperators"), viper.GetInt("maxOperators"))
+	if err != nil {
+		return nil, fmt.Errorf("wrong operators policy: %w", err)
+	}
+	requestTimeout, err := loadRequestTimeout()
+	if err != nil {
+		return nil, err
+	}
+	opts := []initiator.Option{
+		initiator.WithLogger(logger),
+		initiator.WithTranscript(),
+		initiator.WithThreshold(viper.GetUint64("threshold")),
+		initiator.WithPolicy(policy),
+		initiator.WithDepositAmount(phase0.Gwei(viper.GetUint64("depositAmount"))),
+		initiator.WithBatchSize(int(viper.GetUint64("batchSize"))),
+		initiator.WithRequestTimeout(requestTimeout),
+	}
+	if viper.GetBool("compounding") {
+		opts = append(opts, initiator.WithCompounding())
+	}
+	if viper.GetBool("tolerateOffline") {
+		opts = append(opts, initiator.WithTolerateOffline())
+	}
+	if tlsOpts := loadTLSOptions(); tlsOpts != (initiator.TLSOptions{}) {
+		opts = append(opts, initiator.WithTLS(tlsOpts))
+	}
+	return opts, nil
+}
+
 func loadParticipants(flagdata []string) ([]uint64, error) {
 	partsarr := make([]uint64, 0, len(flagdata))
 	for i := 0; i < len(flagdata); i++ {
@@ -354,3 +306,25 @@ func loadParticipants(flagdata []string) ([]uint64, error) {
 	}
 	return partsarr, nil
 }
+
+// writeResults writes deposit data, keyshares and operator proofs of a created validator
+func writeResults(logger *zap.Logger, outputPath string, depositData *initiator.DepositDataJson, keyShares *initiator.KeyShares) {
+	depositFinalPath := fmt.Sprintf("%s/deposit_%s.json", outputPath, depositData.PubKey)
+	logger.Info("💾 Writing deposit data json to file", zap.String("path", depositFinalPath))
+	err := utils.WriteJSON(depositFinalPath, []initiator.DepositDataJson{*depositData})
+	if err != nil {
+		logger.Warn("Failed writing deposit data file: ", zap.Error(err))
+	}
+	keysharesFinalPath := fmt.Sprintf("%s/keyshares-%v.json", outputPath, depositData.PubKey)
+	logger.Info("💾 Writing keyshares payload to file", zap.String("path", keysharesFinalPath))
+	err = utils.WriteJSON(keysharesFinalPath, keyShares)
+	if err != nil {
+		logger.Warn("Failed writing keyshares file: ", zap.Error(err))
+	}
+	proofsFinalPath := fmt.Sprintf("%s/proofs-%v.json", outputPath, depositData.PubKey)
+	logger.Info("💾 Writing operator proofs to file", zap.String("path", proofsFinalPath))
+	err = utils.WriteJSON(proofsFinalPath, keyShares.Proofs)
+	if err != nil {
+		logger.Warn("Failed writing proofs file: ", zap.Error(err))
+	}
+}
@@ -0,0 +1,56 @@
+package initiator
+
+import (
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv-dkg/cli/flags"
+	"github.com/bloxapp/ssv-dkg/pkgs/initiator"
+)
+
+func init() {
+	flags.OperatorsInfoFlag(Ping)
+	flags.OperatorsInfoPathFlag(Ping)
+	flags.RequestTimeoutFlag(Ping)
+	flags.TLSCAFlag(Ping)
+	flags.TLSPinnedFlag(Ping)
+	flags.TLSCertFlag(Ping)
+	flags.TLSKeyFlag(Ping)
+	flags.ConfigPathFlag(Ping)
+	flags.LogLevelFlag(Ping)
+	flags.LogFormatFlag(Ping)
+	flags.LogLevelFormatFlag(Ping)
+	flags.LogFilePathFlag(Ping)
+}
+
+var Ping = &cobra.Command{
+	Use:   "ping",
+	Short: "Checks health and identity of every operator at operators info before a ceremony",
+	PreRun: func(cmd *cobra.Command, args []string) {
+		bindFlags(cmd, "operatorsInfo", "operatorsInfoPath", "requestTimeout", "tlsCA", "tlsPinned", "tlsCert", "tlsKey", "logLevel", "logFormat", "logLevelFormat", "logFilePath")
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger, err := setGlobalLogger(cmd, "dkg-initiator")
+		if err != nil {
+			return err
+		}
+		opMap := loadOperators(logger)
+		dkgInitiator := initiator.New(nil, opMap, logger)
+		setTLS(logger, dkgInitiator)
+		setRequestTimeout(logger, dkgInitiator)
+		failed := 0
+		for _, res := range dkgInitiator.Ping(cmd.Context()) {
+			if res.Err != nil {
+				failed++
+				logger.Error("😥 Operator check failed", zap.Uint64("id", res.ID), zap.String("addr", res.Addr), zap.Error(res.Err))
+				continue
+			}
+			logger.Info("✅ Operator is ready", zap.Uint64("id", res.ID), zap.String("addr", res.Addr),
+				zap.String("version", res.Identity.Version), zap.Uint64s("protocolVersions", res.Identity.ProtocolVersions), zap.Strings("networks", res.Identity.Networks))
+		}
+		if failed > 0 {
+			logger.Fatal("😥 Some operators are not ready for a ceremony", zap.Int("failed", failed), zap.Int("operators", len(opMap)))
+		}
+		return nil
+	},
+}
@@ -0,0 +1,135 @@
+package initiator
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv-dkg/cli/flags"
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/initiator"
+	"github.com/bloxapp/ssv-dkg/pkgs/threshold"
+	"github.com/bloxapp/ssv-dkg/pkgs/utils"
+)
+
+func init() {
+	flags.InitiatorPrivateKeyFlag(StartReshare)
+	flags.InitiatorPrivateKeyPassFlag(StartReshare)
+	flags.OperatorsInfoFlag(StartReshare)
+	flags.OperatorsInfoPathFlag(StartReshare)
+	flags.OldOperatorIDsFlag(StartReshare)
+	flags.NewOperatorIDsFlag(StartReshare)
+	flags.ValidatorPKFlag(StartReshare)
+	flags.OldThresholdFlag(StartReshare)
+	flags.ThresholdFlag(StartReshare)
+	flags.MinOperatorsFlag(StartReshare)
+	flags.MaxOperatorsFlag(StartReshare)
+	flags.OwnerAddressFlag(StartReshare)
+	flags.NonceFlag(StartReshare)
+	flags.ResultPathFlag(StartReshare)
+	flags.MetricsPushURLFlag(StartReshare)
+	flags.RequestTimeoutFlag(StartReshare)
+	flags.TLSCAFlag(StartReshare)
+	flags.TLSPinnedFlag(StartReshare)
+	flags.TLSCertFlag(StartReshare)
+	flags.TLSKeyFlag(StartReshare)
+	flags.ConfigPathFlag(StartReshare)
+	flags.LogLevelFlag(StartReshare)
+	flags.LogFormatFlag(StartReshare)
+	flags.LogLevelFormatFlag(StartReshare)
+	flags.LogFilePathFlag(StartReshare)
+}
+
+var StartReshare = &cobra.Command{
+	Use:   "reshare",
+	Short: "Reshares the key of an existing validator to a new set of operators",
+	PreRun: func(cmd *cobra.Command, args []string) {
+		bindFlags(cmd, "oldOperatorIDs", "newOperatorIDs", "validatorPK", "oldThreshold", "threshold", "minOperators", "maxOperators", "operatorsInfo", "operatorsInfoPath", "owner", "nonce", "outputPath", "metricsPushURL", "initiatorPrivKey", "initiatorPrivKeyPassword", "requestTimeout", "tlsCA", "tlsPinned", "tlsCert", "tlsKey", "logLevel", "logFormat", "logLevelFormat", "logFilePath")
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger, err := setGlobalLogger(cmd, "dkg-initiator")
+		if err != nil {
+			return err
+		}
+		// Check paths for results
+		outputPath := viper.GetString("outputPath")
+		if outputPath == "" {
+			logger.Fatal("😥 Failed to get result path flag value")
+		}
+		if stat, err := os.Stat(outputPath); err != nil || !stat.IsDir() {
+			logger.Fatal("😥 Error to to open path to store results", zap.Error(err))
+		}
+		opMap := loadOperators(logger)
+		oldParts, err := loadParticipants(viper.GetStringSlice("oldOperatorIDs"))
+		if err != nil {
+			logger.Fatal("😥 Failed to load old operators: ", zap.Error(err))
+		}
+		newParts, err := loadParticipants(viper.GetStringSlice("newOperatorIDs"))
+		if err != nil {
+			logger.Fatal("😥 Failed to load new operators: ", zap.Error(err))
+		}
+		validatorPK, err := hex.DecodeString(strings.TrimPrefix(viper.GetString("validatorPK"), "0x"))
+		if err != nil {
+			logger.Fatal("😥 Failed to parse validator public key: ", zap.Error(err))
+		}
+		oldThreshold := viper.GetUint64("oldThreshold")
+		if oldThreshold == 0 {
+			// shares created by the init command use 3f+1 threshold by default
+			oldThreshold = threshold.Default(len(oldParts))
+		}
+		// the same initiator key which created the validator has to be used
+		privKeyPath := viper.GetString("initiatorPrivKey")
+		if privKeyPath == "" {
+			logger.Fatal("😥 Initiator key flag should be provided")
+		}
+		privateKey := loadInitiatorKey(logger, privKeyPath, viper.GetString("initiatorPrivKeyPassword"))
+		owner := viper.GetString("owner")
+		if owner == "" {
+			logger.Fatal("😥 Failed to get owner address flag value")
+		}
+		ownerAddress, err := utils.HexToAddress(owner)
+		if err != nil {
+			logger.Fatal("😥 Failed to parse owner address: ", zap.Error(err))
+		}
+		nonce := viper.GetUint64("nonce")
+
+		dkgInitiator := initiator.New(privateKey, opMap, logger)
+		setTLS(logger, dkgInitiator)
+		setRequestTimeout(logger, dkgInitiator)
+		dkgInitiator.Threshold = viper.GetUint64("threshold")
+		dkgInitiator.Policy = loadPolicy(logger)
+		dkgInitiator.Transcript = initiator.NewTranscript()
+		id := crypto.NewID()
+		dkgInitiator.StatePath = fmt.Sprintf("%s/ceremony-%x.json", outputPath, id)
+		keyShares, err := dkgInitiator.StartReshare(cmd.Context(), id, oldParts, newParts, validatorPK, oldThreshold, ownerAddress, nonce)
+		pushMetrics(logger)
+		writeTranscript(logger, dkgInitiator, fmt.Sprintf("%s/transcript-reshare-%x.json", outputPath, id))
+		if err != nil {
+			logger.Fatal("😥 Failed to reshare validator key: ", zap.Error(err))
+		}
+		logger.Info("🎯  All data is validated.")
+		writeReshareResults(logger, outputPath, validatorPK, keyShares)
+		return nil
+	},
+}
+
+// writeReshareResults writes keyshares and operator proofs of a reshared validator
+func writeReshareResults(logger *zap.Logger, outputPath string, validatorPK []byte, keyShares *initiator.KeyShares) {
+	keysharesFinalPath := fmt.Sprintf("%s/keyshares-reshare-%x.json", outputPath, validatorPK)
+	logger.Info("💾 Writing keyshares payload to file", zap.String("path", keysharesFinalPath))
+	err := utils.WriteJSON(keysharesFinalPath, keyShares)
+	if err != nil {
+		logger.Warn("Failed writing keyshares file: ", zap.Error(err))
+	}
+	proofsFinalPath := fmt.Sprintf("%s/proofs-reshare-%x.json", outputPath, validatorPK)
+	logger.Info("💾 Writing operator proofs to file", zap.String("path", proofsFinalPath))
+	err = utils.WriteJSON(proofsFinalPath, keyShares.Proofs)
+	if err != nil {
+		logger.Warn("Failed writing proofs file: ", zap.Error(err))
+	}
+}
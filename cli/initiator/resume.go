@@ -0,0 +1,98 @@
+package initiator
+
+import (
+	"encoding/hex"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv-dkg/cli/flags"
+	"github.com/bloxapp/ssv-dkg/pkgs/initiator"
+)
+
+func init() {
+	flags.StatePathFlag(Resume)
+	flags.AbortFlag(Resume)
+	flags.InitiatorPrivateKeyFlag(Resume)
+	flags.InitiatorPrivateKeyPassFlag(Resume)
+	flags.OperatorsInfoFlag(Resume)
+	flags.OperatorsInfoPathFlag(Resume)
+	flags.ResultPathFlag(Resume)
+	flags.MetricsPushURLFlag(Resume)
+	flags.RequestTimeoutFlag(Resume)
+	flags.TLSCAFlag(Resume)
+	flags.TLSPinnedFlag(Resume)
+	flags.TLSCertFlag(Resume)
+	flags.TLSKeyFlag(Resume)
+	flags.ConfigPathFlag(Resume)
+	flags.LogLevelFlag(Resume)
+	flags.LogFormatFlag(Resume)
+	flags.LogLevelFormatFlag(Resume)
+	flags.LogFilePathFlag(Resume)
+}
+
+var Resume = &cobra.Command{
+	Use:   "resume",
+	Short: "Resumes an interrupted ceremony from its state file or aborts it at the operators",
+	PreRun: func(cmd *cobra.Command, args []string) {
+		bindFlags(cmd, "statePath", "abort", "initiatorPrivKey", "initiatorPrivKeyPassword", "operatorsInfo", "operatorsInfoPath", "outputPath", "metricsPushURL", "requestTimeout", "tlsCA", "tlsPinned", "tlsCert", "tlsKey", "logLevel", "logFormat", "logLevelFormat", "logFilePath")
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger, err := setGlobalLogger(cmd, "dkg-initiator")
+		if err != nil {
+			return err
+		}
+		statePath := viper.GetString("statePath")
+		state, err := initiator.LoadCeremonyState(statePath)
+		if err != nil {
+			logger.Fatal("😥 Failed to load ceremony state: ", zap.Error(err))
+		}
+		outputPath := viper.GetString("outputPath")
+		if outputPath == "" {
+			logger.Fatal("😥 Failed to get result path flag value")
+		}
+		if stat, err := os.Stat(outputPath); err != nil || !stat.IsDir() {
+			logger.Fatal("😥 Error to to open path to store results", zap.Error(err))
+		}
+		opMap := loadOperators(logger)
+		// the same initiator key which started the ceremony has to be used
+		privKeyPath := viper.GetString("initiatorPrivKey")
+		if privKeyPath == "" {
+			logger.Fatal("😥 Initiator key flag should be provided")
+		}
+		privateKey := loadInitiatorKey(logger, privKeyPath, viper.GetString("initiatorPrivKeyPassword"))
+
+		dkgInitiator := initiator.New(privateKey, opMap, logger)
+		setTLS(logger, dkgInitiator)
+		setRequestTimeout(logger, dkgInitiator)
+		dkgInitiator.StatePath = statePath
+		if viper.GetBool("abort") {
+			err := dkgInitiator.AbortState(cmd.Context(), state)
+			pushMetrics(logger)
+			if err != nil {
+				logger.Fatal("😥 Failed to abort the ceremony at all operators: ", zap.Error(err))
+			}
+			logger.Info("🛑 Ceremony aborted", zap.String("request_id", state.RequestID))
+			return nil
+		}
+		depositData, keyShares, err := dkgInitiator.Resume(cmd.Context(), state)
+		pushMetrics(logger)
+		if err != nil {
+			logger.Fatal("😥 Failed to resume the ceremony: ", zap.Error(err))
+		}
+		logger.Info("🎯  All data is validated.")
+		if depositData != nil {
+			writeResults(logger, outputPath, depositData, keyShares)
+			return nil
+		}
+		validatorPK, err := hex.DecodeString(strings.TrimPrefix(keyShares.Payload.PublicKey, "0x"))
+		if err != nil {
+			logger.Fatal("😥 Failed to parse validator public key: ", zap.Error(err))
+		}
+		writeReshareResults(logger, outputPath, validatorPK, keyShares)
+		return nil
+	},
+}
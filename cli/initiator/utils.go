@@ -0,0 +1,274 @@
+package initiator
+
+import (
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/bloxapp/ssv/logging"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv-dkg/cli/flags"
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/initiator"
+	"github.com/bloxapp/ssv-dkg/pkgs/network"
+	"github.com/bloxapp/ssv-dkg/pkgs/threshold"
+	"github.com/bloxapp/ssv-dkg/pkgs/utils"
+)
+
+// bindFlags binds flags of the command being executed to viper, binding at PreRun lets commands share flag names
+func bindFlags(cmd *cobra.Command, names ...string) {
+	for _, name := range names {
+		if err := viper.BindPFlag(name, cmd.PersistentFlags().Lookup(name)); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// setGlobalLogger reads the config file and sets the global logger
+func setGlobalLogger(cmd *cobra.Command, name string) (*zap.Logger, error) {
+	viper.SetConfigType("yaml")
+	configPath, err := flags.GetConfigPathFlagValue(cmd)
+	if err != nil {
+		return nil, err
+	}
+	if configPath != "" {
+		viper.SetConfigFile(configPath)
+	}
+	if err := viper.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, err
+		}
+		fmt.Print("⚠️ config file was not provided, using flag parameters \n")
+	}
+	logLevel := viper.GetString("logLevel")
+	logFormat := viper.GetString("logFormat")
+	logLevelFormat := viper.GetString("logLevelFormat")
+	viper.SetDefault("logFilePath", "./initiator_debug.log")
+	logFilePath := viper.GetString("logFilePath")
+	if logFilePath == "" {
+		fmt.Print("⚠️ debug log path was not provided, using default: ./initiator_debug.log \n")
+	}
+	// If the log file doesn't exist, create it
+	_, err = os.OpenFile(logFilePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if err := logging.SetGlobalLogger(logLevel, logFormat, logLevelFormat, &logging.LogFileOptions{FileName: logFilePath}); err != nil {
+		return nil, fmt.Errorf("logging.SetGlobalLogger: %w", err)
+	}
+	return zap.L().Named(name), nil
+}
+
+// loadOperators loads operators info either from a raw JSON string or from a file
+func loadOperators(logger *zap.Logger) initiator.Operators {
+	operatorsInfo := viper.GetString("operatorsInfo")
+	operatorsInfoPath := viper.GetString("operatorsInfoPath")
+	if operatorsInfo == "" && operatorsInfoPath == "" {
+		logger.Fatal("😥 Operators string or path have not provided")
+	}
+	if operatorsInfo != "" && operatorsInfoPath != "" {
+		logger.Fatal("😥 Please provide either operator info string or path, not both")
+	}
+	var opMap initiator.Operators
+	var err error
+	if operatorsInfo != "" {
+		logger.Info("📖 reading raw JSON string of operators info")
+		opMap, err = initiator.LoadOperatorsJson([]byte(operatorsInfo))
+		if err != nil {
+			logger.Fatal("😥 Failed to load operators: ", zap.Error(err))
+		}
+	}
+	if operatorsInfoPath != "" {
+		logger.Info("📖 looking operators info 'operators_info.json' file", zap.String("at path", operatorsInfoPath))
+		stat, err := os.Stat(operatorsInfoPath)
+		if os.IsNotExist(err) {
+			logger.Fatal("😥 Failed to read operator info file: ", zap.Error(err))
+		}
+		if stat.IsDir() {
+			filePath := operatorsInfoPath + "operators_info.json"
+			if _, err := os.Stat(filePath); os.IsNotExist(err) {
+				logger.Fatal("😥 Failed to find operator info file at provided path: ", zap.Error(err))
+			}
+			opsfile, err := os.ReadFile(filePath)
+			if err != nil {
+				logger.Fatal("😥 Failed to read operator info file:", zap.Error(err))
+			}
+			opMap, err = initiator.LoadOperatorsJson(opsfile)
+			if err != nil {
+				logger.Fatal("😥 Failed to load operators: ", zap.Error(err))
+			}
+		} else {
+			logger.Info("📖 reading operators info JSON file")
+			opsfile, err := os.ReadFile(operatorsInfoPath)
+			if err != nil {
+				logger.Fatal("😥 Failed to read operator info file: ", zap.Error(err))
+			}
+			opMap, err = initiator.LoadOperatorsJson(opsfile)
+			if err != nil {
+				logger.Fatal("😥 Failed to load operators: ", zap.Error(err))
+			}
+		}
+	}
+	return opMap
+}
+
+// setTLS configures TLS of requests to operators from the TLS flags
+func setTLS(logger *zap.Logger, c *initiator.Initiator) {
+	opts := loadTLSOptions()
+	if opts == (initiator.TLSOptions{}) {
+		return
+	}
+	if err := c.SetTLS(opts); err != nil {
+		logger.Fatal("😥 Failed to configure TLS: ", zap.Error(err))
+	}
+}
+
+// setRequestTimeout sets the request timeout flag at the initiator
+func setRequestTimeout(logger *zap.Logger, c *initiator.Initiator) {
+	timeout, err := loadRequestTimeout()
+	if err != nil {
+		logger.Fatal("😥 Wrong request timeout: ", zap.String("requestTimeout", viper.GetString("requestTimeout")), zap.Error(err))
+	}
+	c.RequestTimeout = timeout
+}
+
+// loadRequestTimeout parses the request timeout flag
+func loadRequestTimeout() (time.Duration, error) {
+	timeout, err := time.ParseDuration(viper.GetString("requestTimeout"))
+	if err != nil {
+		return 0, fmt.Errorf("wrong request timeout: %w", err)
+	}
+	if timeout <= 0 {
+		return 0, fmt.Errorf("request timeout should be positive")
+	}
+	return timeout, nil
+}
+
+// loadTLSOptions reads the TLS flags
+func loadTLSOptions() initiator.TLSOptions {
+	return initiator.TLSOptions{
+		CAPath:   viper.GetString("tlsCA"),
+		Pinned:   viper.GetBool("tlsPinned"),
+		CertPath: viper.GetString("tlsCert"),
+		KeyPath:  viper.GetString("tlsKey"),
+	}
+}
+
+// loadInitiatorKey reads initiator RSA private key file, decrypting it if a password file is provided
+func loadInitiatorKey(logger *zap.Logger, privKeyPath, pass string) *rsa.PrivateKey {
+	var privateKey *rsa.PrivateKey
+	var err error
+	logger.Info("🔑 opening initiator RSA private key file")
+	if pass != "" {
+		logger.Info("🔑 password for key provided - decrypting")
+		// check if a password string a valid path, then read password from the file
+		if _, err := os.Stat(pass); os.IsNotExist(err) {
+			logger.Fatal("😥 Password file doesn`t exist: ", zap.Error(err))
+		}
+		encryptedRSAJSON, err := os.ReadFile(privKeyPath)
+		if err != nil {
+			logger.Fatal("😥 Cant read operator`s key file", zap.Error(err))
+		}
+		keyStorePassword, err := os.ReadFile(pass)
+		if err != nil {
+			logger.Fatal("😥 Error reading password file: ", zap.Error(err))
+		}
+		privateKey, err = crypto.ConvertEncryptedPemToPrivateKey(encryptedRSAJSON, string(keyStorePassword))
+		if err != nil {
+			logger.Fatal(err.Error())
+		}
+	} else {
+		logger.Info("🔑 password for key NOT provided - trying to read plaintext key")
+		privateKey, err = crypto.PrivateKey(privKeyPath)
+		if err != nil {
+			logger.Fatal("😥 Error reading plaintext private key from file: ", zap.Error(err))
+		}
+	}
+	return privateKey
+}
+
+// loadPolicy creates the operators policy from the min and max operators flags
+func loadPolicy(logger *zap.Logger) threshold.Policy {
+	policy, err := threshold.NewPolicy(viper.GetInt("minOperators"), viper.GetInt("maxOperators"))
+	if err != nil {
+		logger.Fatal("😥 Wrong operators policy: ", zap.Error(err))
+	}
+	return policy
+}
+
+// pushMetrics pushes metrics of requests to operators if a Pushgateway is configured, a failure doesn't fail the command
+func pushMetrics(logger *zap.Logger) {
+	url := viper.GetString("metricsPushURL")
+	if url == "" {
+		return
+	}
+	if err := initiator.PushMetrics(url); err != nil {
+		logger.Warn("Failed to push metrics: ", zap.Error(err))
+		return
+	}
+	logger.Info("📈 Pushed metrics", zap.String("url", url))
+}
+
+// registerNetworks registers custom networks of the config file
+func registerNetworks(logger *zap.Logger) {
+	var networks []network.Config
+	if err := viper.UnmarshalKey("networks", &networks); err != nil {
+		logger.Fatal("😥 Failed to parse custom networks: ", zap.Error(err))
+	}
+	if err := network.RegisterConfigs(networks); err != nil {
+		logger.Fatal("😥 Failed to load custom networks: ", zap.Error(err))
+	}
+}
+
+// writeTranscript signs the transcript of the ceremonies and writes it to the file
+func writeTranscript(logger *zap.Logger, c *initiator.Initiator, path string) {
+	if err := c.SignTranscript(); err != nil {
+		logger.Warn("Failed signing transcript: ", zap.Error(err))
+		return
+	}
+	saveTranscript(logger, c.Transcript, path)
+}
+
+// writeRunTranscript writes the signed transcript returned by a run of the initiator to the file, if it's recorded
+func writeRunTranscript(logger *zap.Logger, result *initiator.CeremonyResult, err error, path string) {
+	var cerr *initiator.CeremonyError
+	switch {
+	case result != nil && result.Transcript != nil:
+		saveTranscript(logger, result.Transcript, path)
+	case errors.As(err, &cerr) && cerr.Transcript != nil:
+		saveTranscript(logger, cerr.Transcript, path)
+	}
+}
+
+// writeBulkResults writes deposit data, keyshares and operator proofs of multiple validators to the files
+func writeBulkResults(logger *zap.Logger, result *initiator.CeremonyResult, depositPath, keysharesPath, proofsPath string) {
+	depositDataArr := make([]initiator.DepositDataJson, 0, len(result.DepositData))
+	for _, d := range result.DepositData {
+		depositDataArr = append(depositDataArr, *d)
+	}
+	logger.Info("💾 Writing deposit data json to file", zap.String("path", depositPath))
+	if err := utils.WriteJSON(depositPath, depositDataArr); err != nil {
+		logger.Warn("Failed writing deposit data file: ", zap.Error(err))
+	}
+	logger.Info("💾 Writing keyshares payload to file", zap.String("path", keysharesPath))
+	if err := utils.WriteJSON(keysharesPath, result.BulkKeyShares()); err != nil {
+		logger.Warn("Failed writing keyshares file: ", zap.Error(err))
+	}
+	logger.Info("💾 Writing operator proofs to file", zap.String("path", proofsPath))
+	if err := utils.WriteJSON(proofsPath, result.Proofs()); err != nil {
+		logger.Warn("Failed writing proofs file: ", zap.Error(err))
+	}
+}
+
+// saveTranscript writes a signed transcript to the file
+func saveTranscript(logger *zap.Logger, t *initiator.Transcript, path string) {
+	logger.Info("💾 Writing ceremony transcript to file", zap.String("path", path))
+	if err := utils.WriteJSON(path, t); err != nil {
+		logger.Warn("Failed writing transcript file: ", zap.Error(err))
+	}
+}
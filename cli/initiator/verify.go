@@ -0,0 +1,131 @@
+package initiator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv-dkg/cli/flags"
+	"github.com/bloxapp/ssv-dkg/pkgs/initiator"
+	"github.com/bloxapp/ssv-dkg/pkgs/network"
+	"github.com/bloxapp/ssv-dkg/pkgs/utils"
+)
+
+func init() {
+	flags.DepositDataPathFlag(Verify)
+	flags.KeysharesPathFlag(Verify)
+	flags.ProofsPathFlag(Verify)
+	flags.WithdrawAddressFlag(Verify)
+	flags.OwnerAddressFlag(Verify)
+	flags.NonceFlag(Verify)
+	flags.NetworkFlag(Verify)
+	flags.ConfigPathFlag(Verify)
+	flags.LogLevelFlag(Verify)
+	flags.LogFormatFlag(Verify)
+	flags.LogLevelFormatFlag(Verify)
+	flags.LogFilePathFlag(Verify)
+}
+
+var Verify = &cobra.Command{
+	Use:   "verify",
+	Short: "Verifies deposit data and keyshares files of validators offline",
+	PreRun: func(cmd *cobra.Command, args []string) {
+		bindFlags(cmd, "depositDataPath", "keysharesPath", "proofsPath", "withdrawAddress", "owner", "nonce", "network", "logLevel", "logFormat", "logLevelFormat", "logFilePath")
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger, err := setGlobalLogger(cmd, "dkg-initiator")
+		if err != nil {
+			return err
+		}
+		registerNetworks(logger)
+		n, err := network.ByName(viper.GetString("network"))
+		if err != nil {
+			logger.Fatal("😥 Please provide a valid network name: ", zap.Error(err))
+		}
+		withdrawAddress, err := utils.HexToAddress(viper.GetString("withdrawAddress"))
+		if err != nil {
+			logger.Fatal("😥 Failed to parse withdraw address: ", zap.Error(err))
+		}
+		ownerAddress, err := utils.HexToAddress(viper.GetString("owner"))
+		if err != nil {
+			logger.Fatal("😥 Failed to parse owner address: ", zap.Error(err))
+		}
+		nonce := viper.GetUint64("nonce")
+		keysharesPath := viper.GetString("keysharesPath")
+		if keysharesPath == "" {
+			logger.Fatal("😥 Keyshares path flag should be provided")
+		}
+
+		var depositData []initiator.DepositDataJson
+		if err := readJSON(viper.GetString("depositDataPath"), &depositData); err != nil {
+			logger.Fatal("😥 Failed to read deposit data file: ", zap.Error(err))
+		}
+		shares, err := readKeyShares(keysharesPath)
+		if err != nil {
+			logger.Fatal("😥 Failed to read keyshares file: ", zap.Error(err))
+		}
+		var proofs []*initiator.SignedProof
+		proofsPath := viper.GetString("proofsPath")
+		if proofsPath != "" {
+			if err := readJSON(proofsPath, &proofs); err != nil {
+				logger.Fatal("😥 Failed to read proofs file: ", zap.Error(err))
+			}
+		}
+		if len(depositData) != len(shares) {
+			logger.Fatal("😥 Deposit data and keyshares files are for a different number of validators", zap.Int("deposits", len(depositData)), zap.Int("keyshares", len(shares)))
+		}
+		// validators of a bulk ceremony are created with consecutive nonces, in the order of the files
+		for i, ks := range shares {
+			d := depositData[i]
+			if !strings.EqualFold(strings.TrimPrefix(ks.Payload.PublicKey, "0x"), strings.TrimPrefix(d.PubKey, "0x")) {
+				logger.Fatal("😥 Deposit data and keyshares are for different validators", zap.String("deposit", d.PubKey), zap.String("keyshares", ks.Payload.PublicKey))
+			}
+			if err := initiator.ValidateDepositData(&d, withdrawAddress, n); err != nil {
+				logger.Fatal("😥 Deposit data is invalid: ", zap.String("validator", d.PubKey), zap.Error(err))
+			}
+			if err := initiator.ValidateKeyShares(ks.Data, ks.Payload, ownerAddress, nonce+uint64(i)); err != nil {
+				logger.Fatal("😥 Keyshares are invalid: ", zap.String("validator", d.PubKey), zap.Error(err))
+			}
+			if proofsPath != "" {
+				if err := initiator.ValidateProofs(proofs, ks.Data, ks.Payload, ownerAddress, nonce+uint64(i)); err != nil {
+					logger.Fatal("😥 Operator proofs are invalid: ", zap.String("validator", d.PubKey), zap.Error(err))
+				}
+			}
+			logger.Info("✅ Validator files are valid", zap.String("validator", d.PubKey), zap.Uint64("nonce", nonce+uint64(i)))
+		}
+		logger.Info("🎯 All deposit data and keyshares are valid", zap.Int("validators", len(shares)))
+		return nil
+	},
+}
+
+func readJSON(path string, v any) error {
+	byts, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(byts, v)
+}
+
+// readKeyShares reads a keyshares file of a single validator or of several validators
+func readKeyShares(path string) ([]initiator.KeySharesItem, error) {
+	bulk := &initiator.BulkKeyShares{}
+	if err := readJSON(path, bulk); err != nil {
+		return nil, err
+	}
+	if len(bulk.Shares) > 0 {
+		return bulk.Shares, nil
+	}
+	ks := &initiator.KeyShares{}
+	if err := readJSON(path, ks); err != nil {
+		return nil, err
+	}
+	if ks.Payload.PublicKey == "" {
+		return nil, fmt.Errorf("no keyshares at %s", path)
+	}
+	return []initiator.KeySharesItem{{Data: ks.Data, Payload: ks.Payload}}, nil
+}
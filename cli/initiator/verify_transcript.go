@@ -0,0 +1,83 @@
+package initiator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv-dkg/cli/flags"
+	"github.com/bloxapp/ssv-dkg/pkgs/initiator"
+)
+
+func init() {
+	flags.TranscriptPathFlag(VerifyTranscript)
+	flags.KeysharesPathFlag(VerifyTranscript)
+	flags.OperatorsInfoFlag(VerifyTranscript)
+	flags.OperatorsInfoPathFlag(VerifyTranscript)
+	flags.ConfigPathFlag(VerifyTranscript)
+	flags.LogLevelFlag(VerifyTranscript)
+	flags.LogFormatFlag(VerifyTranscript)
+	flags.LogLevelFormatFlag(VerifyTranscript)
+	flags.LogFilePathFlag(VerifyTranscript)
+}
+
+var VerifyTranscript = &cobra.Command{
+	Use:   "verify-transcript",
+	Short: "Verifies every signature and kyber bundle of a ceremony transcript and the keys resulting from it",
+	PreRun: func(cmd *cobra.Command, args []string) {
+		bindFlags(cmd, "transcriptPath", "keysharesPath", "operatorsInfo", "operatorsInfoPath", "logLevel", "logFormat", "logLevelFormat", "logFilePath")
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger, err := setGlobalLogger(cmd, "dkg-initiator")
+		if err != nil {
+			return err
+		}
+		byts, err := os.ReadFile(viper.GetString("transcriptPath"))
+		if err != nil {
+			logger.Fatal("😥 Failed to read transcript file: ", zap.Error(err))
+		}
+		transcript := &initiator.Transcript{}
+		if err := json.Unmarshal(byts, transcript); err != nil {
+			logger.Fatal("😥 Failed to parse transcript file: ", zap.Error(err))
+		}
+		// operator keys of the transcript are pinned to the operators info, not trusted as written by the initiator
+		opMap := loadOperators(logger)
+		report, err := initiator.VerifyTranscript(transcript, opMap)
+		if err != nil {
+			logger.Fatal("😥 Transcript verification failed: ", zap.Error(err))
+		}
+		for _, c := range report.Ceremonies {
+			logger.Info("✅ Ceremony verified", zap.String("request_id", fmt.Sprintf("%x", c.RequestID)), zap.Bool("reshare", c.Reshare),
+				zap.String("validator", fmt.Sprintf("%x", c.ValidatorPubKey)), zap.Uint64s("outputs", c.Outputs), zap.Strings("errors", c.Errors), zap.Bool("cancelled", c.Cancelled))
+		}
+		for _, e := range report.OperatorErrors {
+			logger.Warn("Operator returned an error", zap.String("error", e))
+		}
+		if keysharesPath := viper.GetString("keysharesPath"); keysharesPath != "" {
+			if err := checkKeyShares(report, keysharesPath); err != nil {
+				logger.Fatal("😥 Key shares don't follow from the transcript: ", zap.Error(err))
+			}
+			logger.Info("✅ Key shares follow from the transcript", zap.String("path", keysharesPath))
+		}
+		logger.Info("🎯 Transcript is valid", zap.Int("signatures", report.Signatures), zap.Int("bundles", report.Bundles), zap.Int("ceremonies", len(report.Ceremonies)))
+		return nil
+	},
+}
+
+// checkKeyShares checks a keyshares file of a single validator or of several validators against the transcript
+func checkKeyShares(report *initiator.TranscriptReport, path string) error {
+	shares, err := readKeyShares(path)
+	if err != nil {
+		return err
+	}
+	for _, item := range shares {
+		if err := report.CheckKeyShares(item.Data, item.Payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -2,13 +2,20 @@ package operator
 
 import (
 	"crypto/rsa"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"time"
 
 	"github.com/bloxapp/ssv-dkg/cli/flags"
 	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/network"
 	"github.com/bloxapp/ssv-dkg/pkgs/operator"
+	"github.com/bloxapp/ssv-dkg/pkgs/store"
+	"github.com/bloxapp/ssv-dkg/pkgs/threshold"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
 
 	"github.com/bloxapp/ssv/logging"
 	"github.com/spf13/cobra"
@@ -21,6 +28,17 @@ func init() {
 	flags.OperatorPrivateKeyPassFlag(StartDKGOperator)
 	flags.OperatorPortFlag(StartDKGOperator)
 	flags.StoreShareFlag(StartDKGOperator)
+	flags.SharesPathFlag(StartDKGOperator)
+	flags.MinOperatorsFlag(StartDKGOperator)
+	flags.MaxOperatorsFlag(StartDKGOperator)
+	flags.PolicyPathFlag(StartDKGOperator)
+	flags.PhaserFlag(StartDKGOperator)
+	flags.PhaseTimeoutFlag(StartDKGOperator)
+	flags.RequestsPathFlag(StartDKGOperator)
+	flags.TLSCertFlag(StartDKGOperator)
+	flags.TLSKeyFlag(StartDKGOperator)
+	flags.TLSPinnedFlag(StartDKGOperator)
+	flags.TLSClientCAFlag(StartDKGOperator)
 	flags.ConfigPathFlag(StartDKGOperator)
 	flags.LogLevelFlag(StartDKGOperator)
 	flags.LogFormatFlag(StartDKGOperator)
@@ -38,6 +56,39 @@ func init() {
 	if err := viper.BindPFlag("storeShare", StartDKGOperator.PersistentFlags().Lookup("storeShare")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("sharesPath", StartDKGOperator.PersistentFlags().Lookup("sharesPath")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("minOperators", StartDKGOperator.PersistentFlags().Lookup("minOperators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("maxOperators", StartDKGOperator.PersistentFlags().Lookup("maxOperators")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("policyPath", StartDKGOperator.PersistentFlags().Lookup("policyPath")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("phaser", StartDKGOperator.PersistentFlags().Lookup("phaser")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("phaseTimeout", StartDKGOperator.PersistentFlags().Lookup("phaseTimeout")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("requestsPath", StartDKGOperator.PersistentFlags().Lookup("requestsPath")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("tlsCert", StartDKGOperator.PersistentFlags().Lookup("tlsCert")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("tlsKey", StartDKGOperator.PersistentFlags().Lookup("tlsKey")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("tlsPinned", StartDKGOperator.PersistentFlags().Lookup("tlsPinned")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("tlsClientCA", StartDKGOperator.PersistentFlags().Lookup("tlsClientCA")); err != nil {
+		panic(err)
+	}
 	if err := viper.BindPFlag("logLevel", StartDKGOperator.PersistentFlags().Lookup("logLevel")); err != nil {
 		panic(err)
 	}
@@ -101,13 +152,14 @@ var StartDKGOperator = &cobra.Command{
 			logger.Fatal("😥 Failed to get operator private key flag value: ", zap.Error(err))
 		}
 		var privateKey *rsa.PrivateKey
+		var keyStorePassword []byte
 		pass := viper.GetString("password")
 		if pass != "" {
 			// check if a password string a valid path, then read password from the file
 			if _, err := os.Stat(pass); err != nil {
 				logger.Fatal("Password file: ", zap.Error(err))
 			}
-			keyStorePassword, err := os.ReadFile(pass)
+			keyStorePassword, err = os.ReadFile(pass)
 			if err != nil {
 				logger.Fatal("😥 Error reading password file: ", zap.Error(err))
 				return err
@@ -126,7 +178,65 @@ var StartDKGOperator = &cobra.Command{
 			logger.Fatal("😥 Please provide password string or path to password file: ", zap.Error(err))
 			return err
 		}
+		var networks []network.Config
+		if err := viper.UnmarshalKey("networks", &networks); err != nil {
+			logger.Fatal("😥 Failed to parse custom networks: ", zap.Error(err))
+		}
+		if err := network.RegisterConfigs(networks); err != nil {
+			logger.Fatal("😥 Failed to load custom networks: ", zap.Error(err))
+		}
 		srv := operator.New(privateKey, logger)
+		srv.Version = cmd.Root().Version
+		policy, err := threshold.NewPolicy(viper.GetInt("minOperators"), viper.GetInt("maxOperators"))
+		if err != nil {
+			logger.Fatal("😥 Wrong operators policy: ", zap.Error(err))
+		}
+		srv.State.Policy = policy
+		if policyPath := viper.GetString("policyPath"); policyPath != "" {
+			accessPolicy, err := operator.LoadAccessPolicy(policyPath)
+			if err != nil {
+				logger.Fatal("😥 Failed to load operator policy: ", zap.Error(err))
+			}
+			logger.Info("🛡️ Loaded operator policy", zap.String("path", policyPath))
+			srv.State.AccessPolicy = accessPolicy
+		}
+		srv.State.Phaser, err = wire.ParsePhaserType(viper.GetString("phaser"))
+		if err != nil {
+			logger.Fatal("😥 Wrong phaser: ", zap.Error(err))
+		}
+		srv.State.PhaseTimeout, err = time.ParseDuration(viper.GetString("phaseTimeout"))
+		if err != nil || srv.State.PhaseTimeout <= 0 {
+			logger.Fatal("😥 Wrong phase timeout: ", zap.String("phaseTimeout", viper.GetString("phaseTimeout")), zap.Error(err))
+		}
+		tlsOpts := operator.TLSOptions{
+			CertPath:     viper.GetString("tlsCert"),
+			KeyPath:      viper.GetString("tlsKey"),
+			Pinned:       viper.GetBool("tlsPinned"),
+			ClientCAPath: viper.GetString("tlsClientCA"),
+		}
+		if tlsOpts.Enabled() {
+			srv.TLSConfig, err = operator.NewTLSConfig(privateKey, tlsOpts)
+			if err != nil {
+				logger.Fatal("😥 Failed to configure TLS: ", zap.Error(err))
+			}
+			logger.Info("🔒 Serving TLS", zap.Bool("pinned", tlsOpts.Pinned), zap.Bool("mTLS", tlsOpts.ClientCAPath != ""))
+		}
+		requestsPath := viper.GetString("requestsPath")
+		srv.State.Requests, err = store.OpenRequests(requestsPath)
+		if err != nil {
+			logger.Fatal("😥 Failed to load request IDs record: ", zap.Error(err))
+		}
+		logger.Info("🗂️ Loaded request IDs record", zap.String("path", requestsPath), zap.Int("requests", srv.State.Requests.Len()))
+		if viper.GetBool("storeShare") {
+			sharesPath := viper.GetString("sharesPath")
+			// shares are encrypted with the same password as the operator key
+			shareStore, err := store.New(sharesPath, string(keyStorePassword))
+			if err != nil {
+				logger.Fatal("😥 Failed to load stored shares: ", zap.Error(err))
+			}
+			logger.Info("🔐 Loaded stored shares", zap.String("path", sharesPath), zap.Int("shares", len(shareStore.List())))
+			srv.State.Store = shareStore
+		}
 		port := viper.GetUint64("port")
 		if port == 0 {
 			logger.Fatal("😥 Failed to get operator info file path flag value: ", zap.Error(err))
@@ -138,7 +248,18 @@ var StartDKGOperator = &cobra.Command{
 			return err
 		}
 		logger.Info("🚀 Starting DKG operator", zap.Uint64("port", port), zap.String("public key", string(pubKey)))
+		// an interrupt stops the server and cancels ongoing ceremonies
+		go func() {
+			<-cmd.Context().Done()
+			logger.Info("🛑 Stopping DKG operator")
+			if err := srv.Stop(); err != nil {
+				logger.Error("😥 Failed to stop operator: ", zap.Error(err))
+			}
+		}()
 		if err := srv.Start(uint16(port)); err != nil {
+			if errors.Is(err, http.ErrServerClosed) {
+				return nil
+			}
 			log.Fatalf("Error in operator %v", err)
 			return err
 		}
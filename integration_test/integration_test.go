@@ -2,30 +2,59 @@ package integration_test
 
 import (
 	"bytes"
+	"context"
 	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/imroc/req/v3"
 
 	"github.com/bloxapp/ssv/logging"
 	"github.com/bloxapp/ssv/utils/rsaencryption"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	eth_crypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 
+	"github.com/bloxapp/ssv-dkg/pkgs/consts"
 	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
 	ourcrypto "github.com/bloxapp/ssv-dkg/pkgs/crypto"
 	"github.com/bloxapp/ssv-dkg/pkgs/dkg"
 	"github.com/bloxapp/ssv-dkg/pkgs/initiator"
+	"github.com/bloxapp/ssv-dkg/pkgs/initiator/local"
+	"github.com/bloxapp/ssv-dkg/pkgs/metrics"
+	"github.com/bloxapp/ssv-dkg/pkgs/network"
 	"github.com/bloxapp/ssv-dkg/pkgs/operator"
+	"github.com/bloxapp/ssv-dkg/pkgs/store"
+	"github.com/bloxapp/ssv-dkg/pkgs/threshold"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
 )
 
 const encryptedKeyLength = 256
@@ -73,7 +102,7 @@ func TestHappyFlows(t *testing.T) {
 	owner := newEthAddress(t)
 	t.Run("test 4 operators happy flow", func(t *testing.T) {
 		id := crypto.NewID()
-		depositData, ks, err := clnt.StartDKG(id, withdraw.Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
+		depositData, ks, err := clnt.StartDKG(context.Background(), id, withdraw.Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
 		require.NoError(t, err)
 		sharesDataSigned, err := hex.DecodeString(ks.Payload.SharesData[2:])
 		require.NoError(t, err)
@@ -85,7 +114,7 @@ func TestHappyFlows(t *testing.T) {
 	})
 	t.Run("test 7 operators happy flow", func(t *testing.T) {
 		id := crypto.NewID()
-		depositData, ks, err := clnt.StartDKG(id, withdraw.Bytes(), []uint64{1, 2, 3, 4, 5, 6, 7}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
+		depositData, ks, err := clnt.StartDKG(context.Background(), id, withdraw.Bytes(), []uint64{1, 2, 3, 4, 5, 6, 7}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
 		require.NoError(t, err)
 		sharesDataSigned, err := hex.DecodeString(ks.Payload.SharesData[2:])
 		require.NoError(t, err)
@@ -97,7 +126,7 @@ func TestHappyFlows(t *testing.T) {
 	})
 	t.Run("test 10 operators happy flow", func(t *testing.T) {
 		id := crypto.NewID()
-		depositData, ks, err := clnt.StartDKG(id, withdraw.Bytes(), []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
+		depositData, ks, err := clnt.StartDKG(context.Background(), id, withdraw.Bytes(), []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
 		require.NoError(t, err)
 		sharesDataSigned, err := hex.DecodeString(ks.Payload.SharesData[2:])
 		require.NoError(t, err)
@@ -109,7 +138,7 @@ func TestHappyFlows(t *testing.T) {
 	})
 	t.Run("test 13 operators happy flow", func(t *testing.T) {
 		id := crypto.NewID()
-		depositData, ks, err := clnt.StartDKG(id, withdraw.Bytes(), []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
+		depositData, ks, err := clnt.StartDKG(context.Background(), id, withdraw.Bytes(), []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
 		require.NoError(t, err)
 		sharesDataSigned, err := hex.DecodeString(ks.Payload.SharesData[2:])
 		require.NoError(t, err)
@@ -121,7 +150,7 @@ func TestHappyFlows(t *testing.T) {
 	})
 	t.Run("test 13 operators - random operators order", func(t *testing.T) {
 		id := crypto.NewID()
-		depositData, ks, err := clnt.StartDKG(id, withdraw.Bytes(), []uint64{13, 3, 2, 4, 5, 6, 7, 8, 9, 10, 11, 12, 1}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
+		depositData, ks, err := clnt.StartDKG(context.Background(), id, withdraw.Bytes(), []uint64{13, 3, 2, 4, 5, 6, 7, 8, 9, 10, 11, 12, 1}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
 		require.NoError(t, err)
 		sharesDataSigned, err := hex.DecodeString(ks.Payload.SharesData[2:])
 		require.NoError(t, err)
@@ -188,7 +217,7 @@ func TestThreshold(t *testing.T) {
 	owner := newEthAddress(t)
 	t.Run("test 13 operators threshold", func(t *testing.T) {
 		id := crypto.NewID()
-		_, ks, err := clnt.StartDKG(id, withdraw.Bytes(), []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
+		_, ks, err := clnt.StartDKG(context.Background(), id, withdraw.Bytes(), []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
 		require.NoError(t, err)
 		sharesDataSigned, err := hex.DecodeString(ks.Payload.SharesData[2:])
 		require.NoError(t, err)
@@ -208,7 +237,7 @@ func TestThreshold(t *testing.T) {
 	})
 	t.Run("test 10 operators threshold", func(t *testing.T) {
 		id := crypto.NewID()
-		_, ks, err := clnt.StartDKG(id, withdraw.Bytes(), []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
+		_, ks, err := clnt.StartDKG(context.Background(), id, withdraw.Bytes(), []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
 		require.NoError(t, err)
 		sharesDataSigned, err := hex.DecodeString(ks.Payload.SharesData[2:])
 		require.NoError(t, err)
@@ -228,7 +257,7 @@ func TestThreshold(t *testing.T) {
 	})
 	t.Run("test 7 operators threshold", func(t *testing.T) {
 		id := crypto.NewID()
-		_, ks, err := clnt.StartDKG(id, withdraw.Bytes(), []uint64{1, 2, 3, 4, 5, 6, 7}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
+		_, ks, err := clnt.StartDKG(context.Background(), id, withdraw.Bytes(), []uint64{1, 2, 3, 4, 5, 6, 7}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
 		require.NoError(t, err)
 		sharesDataSigned, err := hex.DecodeString(ks.Payload.SharesData[2:])
 		require.NoError(t, err)
@@ -248,7 +277,7 @@ func TestThreshold(t *testing.T) {
 	})
 	t.Run("test 4 operators threshold", func(t *testing.T) {
 		id := crypto.NewID()
-		_, ks, err := clnt.StartDKG(id, withdraw.Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
+		_, ks, err := clnt.StartDKG(context.Background(), id, withdraw.Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
 		require.NoError(t, err)
 		sharesDataSigned, err := hex.DecodeString(ks.Payload.SharesData[2:])
 		require.NoError(t, err)
@@ -280,6 +309,1184 @@ func TestThreshold(t *testing.T) {
 	srv13.HttpSrv.Close()
 }
 
+// TestThresholdPolicy runs with its own operators, the init rate limit of the operators allows only 5 ceremonies per minute
+func TestThresholdPolicy(t *testing.T) {
+	if err := logging.SetGlobalLogger("info", "capital", "console", nil); err != nil {
+		panic(err)
+	}
+	logger := zap.L().Named("integration-tests")
+	ops := make(map[uint64]initiator.Operator)
+	srvs := make([]*operator.TestOperator, 0, 7)
+	for i := uint64(1); i <= 7; i++ {
+		srv := operator.CreateTestOperator(t, i)
+		srvs = append(srvs, srv)
+		ops[i] = initiator.Operator{Addr: srv.HttpSrv.URL, ID: i, PubKey: &srv.PrivKey.PublicKey}
+	}
+	// Initiator priv key
+	_, pv, err := rsaencryption.GenerateKeys()
+	require.NoError(t, err)
+	priv, err := rsaencryption.ConvertPemToPrivateKey(string(pv))
+	require.NoError(t, err)
+	clnt := initiator.New(priv, ops, logger)
+	withdraw := newEthAddress(t)
+	owner := newEthAddress(t)
+	t.Run("test 7 operators custom threshold", func(t *testing.T) {
+		clnt.Threshold = 4
+		defer func() { clnt.Threshold = 0 }()
+		_, ks, err := clnt.StartDKG(context.Background(), crypto.NewID(), withdraw.Bytes(), []uint64{1, 2, 3, 4, 5, 6, 7}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
+		require.NoError(t, err)
+		sharesDataSigned, err := hex.DecodeString(ks.Payload.SharesData[2:])
+		require.NoError(t, err)
+		pubkeyraw, err := hex.DecodeString(ks.Payload.PublicKey[2:])
+		require.NoError(t, err)
+		err = testSharesData(ops, 7, []*rsa.PrivateKey{srvs[0].PrivKey, srvs[1].PrivKey, srvs[2].PrivKey}, sharesDataSigned, pubkeyraw, owner, 0)
+		require.ErrorContains(t, err, "could not reconstruct a valid signature")
+		err = testSharesData(ops, 7, []*rsa.PrivateKey{srvs[0].PrivKey, srvs[1].PrivKey, srvs[2].PrivKey, srvs[3].PrivKey}, sharesDataSigned, pubkeyraw, owner, 0)
+		require.NoError(t, err)
+	})
+	t.Run("test unsafe threshold", func(t *testing.T) {
+		clnt.Threshold = 3
+		defer func() { clnt.Threshold = 0 }()
+		_, _, err := clnt.StartDKG(context.Background(), crypto.NewID(), withdraw.Bytes(), []uint64{1, 2, 3, 4, 5, 6, 7}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
+		require.ErrorContains(t, err, "threshold 3 for 7 operators should be between 4 and 7")
+	})
+	t.Run("test operators reject ceremonies out of their policy", func(t *testing.T) {
+		for _, srv := range srvs {
+			srv.Srv.State.Policy = threshold.Policy{MinOperators: 4, MaxOperators: 4}
+		}
+		defer func() {
+			for _, srv := range srvs {
+				srv.Srv.State.Policy = threshold.DefaultPolicy
+			}
+		}()
+		_, _, err := clnt.StartDKG(context.Background(), crypto.NewID(), withdraw.Bytes(), []uint64{1, 2, 3, 4, 5, 6, 7}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
+		require.ErrorContains(t, err, "maximum supported amount of operators is 4")
+	})
+	for _, srv := range srvs {
+		srv.HttpSrv.Close()
+	}
+}
+
+func TestPhasers(t *testing.T) {
+	if err := logging.SetGlobalLogger("info", "capital", "console", nil); err != nil {
+		panic(err)
+	}
+	logger := zap.L().Named("integration-tests")
+	ops := make(map[uint64]initiator.Operator)
+	srvs := make([]*operator.TestOperator, 0, 4)
+	for i := uint64(1); i <= 4; i++ {
+		srv := operator.CreateTestOperator(t, i)
+		srvs = append(srvs, srv)
+		ops[i] = initiator.Operator{Addr: srv.HttpSrv.URL, ID: i, PubKey: &srv.PrivKey.PublicKey}
+	}
+	_, pv, err := rsaencryption.GenerateKeys()
+	require.NoError(t, err)
+	priv, err := rsaencryption.ConvertPemToPrivateKey(string(pv))
+	require.NoError(t, err)
+	clnt := initiator.New(priv, ops, logger)
+	withdraw := newEthAddress(t)
+	owner := newEthAddress(t)
+	runDKG := func(t *testing.T) {
+		_, ks, err := clnt.StartDKG(context.Background(), crypto.NewID(), withdraw.Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
+		require.NoError(t, err)
+		sharesDataSigned, err := hex.DecodeString(ks.Payload.SharesData[2:])
+		require.NoError(t, err)
+		pubkeyraw, err := hex.DecodeString(ks.Payload.PublicKey[2:])
+		require.NoError(t, err)
+		err = testSharesData(ops, 4, []*rsa.PrivateKey{srvs[0].PrivKey, srvs[1].PrivKey, srvs[2].PrivKey, srvs[3].PrivKey}, sharesDataSigned, pubkeyraw, owner, 0)
+		require.NoError(t, err)
+	}
+	t.Run("test event phaser doesn't wait for the phase timeout", func(t *testing.T) {
+		for _, srv := range srvs {
+			srv.Srv.State.PhaseTimeout = time.Minute
+		}
+		start := time.Now()
+		runDKG(t)
+		require.Less(t, time.Since(start), time.Minute)
+	})
+	t.Run("test time phaser", func(t *testing.T) {
+		for _, srv := range srvs {
+			srv.Srv.State.Phaser = wire.TimePhaser
+			srv.Srv.State.PhaseTimeout = time.Second
+		}
+		start := time.Now()
+		runDKG(t)
+		require.GreaterOrEqual(t, time.Since(start), 2*time.Second)
+	})
+	for _, srv := range srvs {
+		srv.HttpSrv.Close()
+	}
+}
+
+func TestInstanceStatus(t *testing.T) {
+	if err := logging.SetGlobalLogger("info", "capital", "console", nil); err != nil {
+		panic(err)
+	}
+	logger := zap.L().Named("integration-tests")
+	ops := make(map[uint64]initiator.Operator)
+	srvs := make([]*operator.TestOperator, 0, 4)
+	for i := uint64(1); i <= 4; i++ {
+		srv := operator.CreateTestOperator(t, i)
+		srvs = append(srvs, srv)
+		ops[i] = initiator.Operator{Addr: srv.HttpSrv.URL, ID: i, PubKey: &srv.PrivKey.PublicKey}
+	}
+	_, pv, err := rsaencryption.GenerateKeys()
+	require.NoError(t, err)
+	priv, err := rsaencryption.ConvertPemToPrivateKey(string(pv))
+	require.NoError(t, err)
+	clnt := initiator.New(priv, ops, logger)
+	id := crypto.NewID()
+	_, ks, err := clnt.StartDKG(context.Background(), id, newEthAddress(t).Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", newEthAddress(t), 0)
+	require.NoError(t, err)
+	getStatus := func(t *testing.T, path string, status int, v interface{}) {
+		resp, err := http.Get(srvs[0].HttpSrv.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, status, resp.StatusCode)
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(v))
+	}
+	initiatorID := sha256.Sum256(priv.PublicKey.N.Bytes())
+	expected := operator.InstanceStatus{
+		RequestID:       hex.EncodeToString(id[:]),
+		Initiator:       hex.EncodeToString(initiatorID[:]),
+		Operators:       []uint64{1, 2, 3, 4},
+		Threshold:       3,
+		Phase:           dkg.PhaseOutput,
+		ValidatorPubKey: ks.Payload.PublicKey[2:],
+	}
+	t.Run("test list instances", func(t *testing.T) {
+		var statuses []operator.InstanceStatus
+		getStatus(t, "/instances", http.StatusOK, &statuses)
+		require.Len(t, statuses, 1)
+		require.False(t, statuses[0].StartTime.IsZero())
+		statuses[0].StartTime = time.Time{}
+		require.Equal(t, expected, statuses[0])
+	})
+	t.Run("test get instance", func(t *testing.T) {
+		var status operator.InstanceStatus
+		getStatus(t, "/instances/"+hex.EncodeToString(id[:]), http.StatusOK, &status)
+		status.StartTime = time.Time{}
+		require.Equal(t, expected, status)
+	})
+	t.Run("test get missing instance", func(t *testing.T) {
+		var resp map[string]string
+		missing := crypto.NewID()
+		getStatus(t, "/instances/"+hex.EncodeToString(missing[:]), http.StatusNotFound, &resp)
+		require.Contains(t, resp["error"], "not found")
+		getStatus(t, "/instances/1234", http.StatusBadRequest, &resp)
+		require.Contains(t, resp["error"], "wrong request ID")
+	})
+	for _, srv := range srvs {
+		srv.HttpSrv.Close()
+	}
+}
+
+func TestPing(t *testing.T) {
+	if err := logging.SetGlobalLogger("info", "capital", "console", nil); err != nil {
+		panic(err)
+	}
+	logger := zap.L().Named("integration-tests")
+	ops := make(map[uint64]initiator.Operator)
+	srvs := make([]*operator.TestOperator, 0, 3)
+	for i := uint64(1); i <= 3; i++ {
+		srv := operator.CreateTestOperator(t, i)
+		srv.Srv.Version = "v1.2.3"
+		srvs = append(srvs, srv)
+		ops[i] = initiator.Operator{Addr: srv.HttpSrv.URL, ID: i, PubKey: &srv.PrivKey.PublicKey}
+	}
+	t.Run("test identity", func(t *testing.T) {
+		resp, err := http.Get(srvs[0].HttpSrv.URL + "/identity")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var identity wire.Identity
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&identity))
+		pubKey, err := crypto.EncodePublicKey(&srvs[0].PrivKey.PublicKey)
+		require.NoError(t, err)
+		require.Equal(t, string(pubKey), identity.PubKey)
+		require.Equal(t, "v1.2.3", identity.Version)
+		require.Equal(t, []uint64{wire.ProtocolVersion}, identity.ProtocolVersions)
+		require.Contains(t, identity.Networks, "mainnet")
+	})
+	t.Run("test all operators ready", func(t *testing.T) {
+		results := initiator.New(nil, ops, logger).Ping(context.Background())
+		require.Len(t, results, 3)
+		for i, res := range results {
+			require.NoError(t, res.Err)
+			require.Equal(t, uint64(i+1), res.ID)
+			require.Equal(t, "v1.2.3", res.Identity.Version)
+		}
+	})
+	t.Run("test key mismatch and unreachable operator", func(t *testing.T) {
+		wrongOps := make(map[uint64]initiator.Operator)
+		for id, op := range ops {
+			wrongOps[id] = op
+		}
+		wrongOps[2] = initiator.Operator{Addr: ops[2].Addr, ID: 2, PubKey: ops[1].PubKey}
+		down := operator.CreateTestOperator(t, 4)
+		down.HttpSrv.Close()
+		wrongOps[4] = initiator.Operator{Addr: down.HttpSrv.URL, ID: 4, PubKey: &down.PrivKey.PublicKey}
+		results := initiator.New(nil, wrongOps, logger).Ping(context.Background())
+		require.Len(t, results, 4)
+		require.NoError(t, results[0].Err)
+		require.ErrorContains(t, results[1].Err, "public key mismatch")
+		require.NoError(t, results[2].Err)
+		require.ErrorContains(t, results[3].Err, "operator 4 is not healthy")
+		require.Nil(t, results[3].Identity)
+	})
+	t.Run("test no common protocol version", func(t *testing.T) {
+		newer := operator.CreateTestOperator(t, 5)
+		defer newer.HttpSrv.Close()
+		pubKey, err := crypto.EncodePublicKey(&newer.PrivKey.PublicKey)
+		require.NoError(t, err)
+		// an operator of a future release which supports only newer protocol versions
+		fake := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/identity" {
+				json.NewEncoder(w).Encode(wire.Identity{PubKey: string(pubKey), ProtocolVersions: []uint64{wire.ProtocolVersion + 1}})
+				return
+			}
+			newer.HttpSrv.Config.Handler.ServeHTTP(w, r)
+		}))
+		defer fake.Close()
+		newerOps := map[uint64]initiator.Operator{5: {Addr: fake.URL, ID: 5, PubKey: &newer.PrivKey.PublicKey}}
+		for id, op := range ops {
+			newerOps[id] = op
+		}
+		clnt := initiator.New(nil, newerOps, logger)
+		results := clnt.Ping(context.Background())
+		require.ErrorContains(t, results[3].Err, "no protocol version is supported by all participants")
+		_, pv, err := rsaencryption.GenerateKeys()
+		require.NoError(t, err)
+		clnt.PrivateKey, err = rsaencryption.ConvertPemToPrivateKey(string(pv))
+		require.NoError(t, err)
+		_, _, err = clnt.StartDKG(context.Background(), crypto.NewID(), newEthAddress(t).Bytes(), []uint64{1, 2, 3, 5}, [4]byte{0, 0, 0, 0}, "mainnnet", newEthAddress(t), 0)
+		require.ErrorContains(t, err, "no protocol version is supported by all participants")
+	})
+	for _, srv := range srvs {
+		srv.HttpSrv.Close()
+	}
+}
+
+func TestTLS(t *testing.T) {
+	if err := logging.SetGlobalLogger("info", "capital", "console", nil); err != nil {
+		panic(err)
+	}
+	logger := zap.L().Named("integration-tests")
+	dir := t.TempDir()
+	// an authority of operator certificates, and a client certificate of the initiator
+	caCert, caKey, caPath, _ := writeTestCert(t, dir, "ca", nil, nil, true, x509.ExtKeyUsageAny)
+	_, _, clientCertPath, clientKeyPath := writeTestCert(t, dir, "client", nil, nil, false, x509.ExtKeyUsageClientAuth)
+	_, _, certPath, keyPath := writeTestCert(t, dir, "operator", caCert, caKey, false, x509.ExtKeyUsageServerAuth)
+	_, pv, err := rsaencryption.GenerateKeys()
+	require.NoError(t, err)
+	priv, err := rsaencryption.ConvertPemToPrivateKey(string(pv))
+	require.NoError(t, err)
+	createOperators := func(opts operator.TLSOptions) ([]*operator.TestOperator, map[uint64]initiator.Operator) {
+		ops := make(map[uint64]initiator.Operator)
+		srvs := make([]*operator.TestOperator, 0, 4)
+		for i := uint64(1); i <= 4; i++ {
+			srv := operator.CreateTestTLSOperator(t, i, opts)
+			srvs = append(srvs, srv)
+			ops[i] = initiator.Operator{Addr: srv.HttpSrv.URL, ID: i, PubKey: &srv.PrivKey.PublicKey}
+		}
+		return srvs, ops
+	}
+	closeAll := func(srvs []*operator.TestOperator) {
+		for _, srv := range srvs {
+			srv.HttpSrv.Close()
+		}
+	}
+	t.Run("test certificates pinned to operator keys", func(t *testing.T) {
+		srvs, ops := createOperators(operator.TLSOptions{Pinned: true})
+		defer closeAll(srvs)
+		clnt := initiator.New(priv, ops, logger)
+		require.NoError(t, clnt.SetTLS(initiator.TLSOptions{Pinned: true}))
+		_, _, err := clnt.StartDKG(context.Background(), crypto.NewID(), newEthAddress(t).Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", newEthAddress(t), 0)
+		require.NoError(t, err)
+		// a certificate of another operator key isn't accepted
+		wrongOps := make(map[uint64]initiator.Operator)
+		for id, op := range ops {
+			wrongOps[id] = op
+		}
+		wrongOps[2] = initiator.Operator{Addr: ops[2].Addr, ID: 2, PubKey: ops[1].PubKey}
+		clnt = initiator.New(nil, wrongOps, logger)
+		require.NoError(t, clnt.SetTLS(initiator.TLSOptions{Pinned: true}))
+		results := clnt.Ping(context.Background())
+		require.NoError(t, results[0].Err)
+		require.ErrorContains(t, results[1].Err, "TLS certificate isn't pinned to the operator key")
+		// pinned certificates aren't verified by certificate authorities
+		results = initiator.New(nil, ops, logger).Ping(context.Background())
+		require.ErrorContains(t, results[0].Err, "failed to verify certificate")
+	})
+	t.Run("test certificates of an authority", func(t *testing.T) {
+		srvs, ops := createOperators(operator.TLSOptions{CertPath: certPath, KeyPath: keyPath})
+		defer closeAll(srvs)
+		clnt := initiator.New(nil, ops, logger)
+		require.NoError(t, clnt.SetTLS(initiator.TLSOptions{CAPath: caPath}))
+		for _, res := range clnt.Ping(context.Background()) {
+			require.NoError(t, res.Err)
+		}
+		clnt = initiator.New(nil, ops, logger)
+		require.NoError(t, clnt.SetTLS(initiator.TLSOptions{Pinned: true}))
+		require.ErrorContains(t, clnt.Ping(context.Background())[0].Err, "TLS certificate isn't pinned to the operator key")
+	})
+	t.Run("test operators accept trusted client certificates only", func(t *testing.T) {
+		srvs, ops := createOperators(operator.TLSOptions{Pinned: true, ClientCAPath: clientCertPath})
+		defer closeAll(srvs)
+		clnt := initiator.New(priv, ops, logger)
+		require.NoError(t, clnt.SetTLS(initiator.TLSOptions{Pinned: true, CertPath: clientCertPath, KeyPath: clientKeyPath}))
+		_, _, err := clnt.StartDKG(context.Background(), crypto.NewID(), newEthAddress(t).Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", newEthAddress(t), 0)
+		require.NoError(t, err)
+		clnt = initiator.New(nil, ops, logger)
+		require.NoError(t, clnt.SetTLS(initiator.TLSOptions{Pinned: true}))
+		require.Error(t, clnt.Ping(context.Background())[0].Err)
+	})
+	t.Run("test pinned certificates need https addresses", func(t *testing.T) {
+		clnt := initiator.New(nil, map[uint64]initiator.Operator{1: {Addr: "http://localhost:3030", ID: 1, PubKey: &priv.PublicKey}}, logger)
+		require.ErrorContains(t, clnt.SetTLS(initiator.TLSOptions{Pinned: true}), "address http://localhost:3030 isn't https")
+	})
+}
+
+// writeTestCert creates a certificate for 127.0.0.1 issued by the parent, or a self-signed one,
+// and writes it and its key to PEM files
+func writeTestCert(t *testing.T, dir, name string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey, isCA bool, usage x509.ExtKeyUsage) (*x509.Certificate, *ecdsa.PrivateKey, string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{usage},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	certPath := filepath.Join(dir, name+".crt")
+	keyPath := filepath.Join(dir, name+".key")
+	require.NoError(t, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600))
+	return cert, key, certPath, keyPath
+}
+
+func TestOfflineOperators(t *testing.T) {
+	if err := logging.SetGlobalLogger("info", "capital", "console", nil); err != nil {
+		panic(err)
+	}
+	logger := zap.L().Named("integration-tests")
+	ops := make(map[uint64]initiator.Operator)
+	srvs := make([]*operator.TestOperator, 0, 4)
+	for i := uint64(1); i <= 4; i++ {
+		srv := operator.CreateTestOperator(t, i)
+		srvs = append(srvs, srv)
+		ops[i] = initiator.Operator{Addr: srv.HttpSrv.URL, ID: i, PubKey: &srv.PrivKey.PublicKey}
+	}
+	// operator 4 is offline
+	srvs[3].HttpSrv.Close()
+	_, pv, err := rsaencryption.GenerateKeys()
+	require.NoError(t, err)
+	priv, err := rsaencryption.ConvertPemToPrivateKey(string(pv))
+	require.NoError(t, err)
+	clnt := initiator.New(priv, ops, logger)
+	withdraw := newEthAddress(t)
+	owner := newEthAddress(t)
+	t.Run("test offline operator fails the ceremony by default", func(t *testing.T) {
+		_, _, err := clnt.StartDKG(context.Background(), crypto.NewID(), withdraw.Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
+		require.ErrorContains(t, err, "connection refused")
+	})
+	t.Run("test ceremony goes on without an offline operator", func(t *testing.T) {
+		clnt.TolerateOffline = true
+		defer func() { clnt.TolerateOffline = false }()
+		depositData, ks, err := clnt.StartDKG(context.Background(), crypto.NewID(), withdraw.Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 1)
+		require.NoError(t, err)
+		require.Equal(t, []uint64{4}, ks.MissingShares)
+		require.Equal(t, []uint64{1, 2, 3}, ks.Payload.OperatorIDs)
+		sharesDataSigned, err := hex.DecodeString(ks.Payload.SharesData[2:])
+		require.NoError(t, err)
+		pubkeyraw, err := hex.DecodeString(ks.Payload.PublicKey[2:])
+		require.NoError(t, err)
+		err = testSharesData(ops, 3, []*rsa.PrivateKey{srvs[0].PrivKey, srvs[1].PrivKey, srvs[2].PrivKey}, sharesDataSigned, pubkeyraw, owner, 1)
+		require.NoError(t, err)
+		testDepositData(t, depositData, withdraw.Bytes(), owner, 1)
+	})
+	t.Run("test threshold can't be met without offline operators", func(t *testing.T) {
+		srvs[2].HttpSrv.Close()
+		clnt.TolerateOffline = true
+		defer func() { clnt.TolerateOffline = false }()
+		_, _, err := clnt.StartDKG(context.Background(), crypto.NewID(), withdraw.Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 2)
+		require.ErrorContains(t, err, "2 of 4 operators are online, threshold 3 can't be met")
+		_, err = clnt.StartBulkDKG(context.Background(), withdraw.Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 2, 2, 2)
+		require.ErrorIs(t, err, initiator.ErrOfflineNotSupported)
+	})
+	for _, srv := range srvs {
+		srv.HttpSrv.Close()
+	}
+}
+
+func TestLocalTransport(t *testing.T) {
+	if err := logging.SetGlobalLogger("info", "capital", "console", nil); err != nil {
+		panic(err)
+	}
+	logger := zap.L().Named("integration-tests")
+	ops := make(map[uint64]initiator.Operator)
+	switches := make(map[uint64]*operator.Switch)
+	keys := make([]*rsa.PrivateKey, 0, 4)
+	for i := uint64(1); i <= 4; i++ {
+		_, pv, err := rsaencryption.GenerateKeys()
+		require.NoError(t, err)
+		opPriv, err := rsaencryption.ConvertPemToPrivateKey(string(pv))
+		require.NoError(t, err)
+		keys = append(keys, opPriv)
+		switches[i] = operator.NewSwitch(opPriv, logger)
+		ops[i] = initiator.Operator{ID: i, PubKey: &opPriv.PublicKey}
+	}
+	transport := local.NewTransport(switches)
+	_, pv, err := rsaencryption.GenerateKeys()
+	require.NoError(t, err)
+	priv, err := rsaencryption.ConvertPemToPrivateKey(string(pv))
+	require.NoError(t, err)
+	clnt := initiator.New(priv, ops, logger)
+	clnt.Transport = transport
+	// requests over HTTP fail the test
+	clnt.Client = nil
+	withdraw := newEthAddress(t)
+	owner := newEthAddress(t)
+	t.Run("test ceremony with operators in process", func(t *testing.T) {
+		var identities int32
+		transport.Fault = func(operatorID uint64, method string, payload []byte) error {
+			if method == consts.API_IDENTITY_URL {
+				atomic.AddInt32(&identities, 1)
+			}
+			return nil
+		}
+		defer func() { transport.Fault = nil }()
+		depositData, ks, err := clnt.StartDKG(context.Background(), crypto.NewID(), withdraw.Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
+		require.NoError(t, err)
+		// the protocol version is negotiated with the operators in process
+		require.Equal(t, int32(4), atomic.LoadInt32(&identities))
+		sharesDataSigned, err := hex.DecodeString(ks.Payload.SharesData[2:])
+		require.NoError(t, err)
+		pubkeyraw, err := hex.DecodeString(ks.Payload.PublicKey[2:])
+		require.NoError(t, err)
+		err = testSharesData(ops, 4, keys, sharesDataSigned, pubkeyraw, owner, 0)
+		require.NoError(t, err)
+		testDepositData(t, depositData, withdraw.Bytes(), owner, 0)
+	})
+	t.Run("test ping operators in process", func(t *testing.T) {
+		for _, res := range clnt.Ping(context.Background()) {
+			require.NoError(t, res.Err)
+			require.Equal(t, []uint64{wire.ProtocolVersion}, res.Identity.ProtocolVersions)
+		}
+	})
+	t.Run("test ceremony finishing after a rejected message", func(t *testing.T) {
+		var injected int32
+		transport.Fault = func(operatorID uint64, method string, payload []byte) error {
+			if operatorID != 1 || method != consts.API_DKG_URL || !atomic.CompareAndSwapInt32(&injected, 0, 1) {
+				return nil
+			}
+			// the exchange message of operator 2 with an invalid signature reaches operator 1 before the valid one
+			msgs := &wire.MultipleSignedTransports{}
+			if err := msgs.UnmarshalSSZ(payload); err != nil {
+				return err
+			}
+			for _, msg := range msgs.Messages {
+				if msg.Signer != 2 {
+					continue
+				}
+				bad := *msg
+				bad.Signature = append([]byte{}, msg.Signature...)
+				bad.Signature[0] ^= 0xff
+				switches[1].Mtx.RLock()
+				inst := switches[1].Instances[operator.InstanceID(msg.Message.Identifier)]
+				switches[1].Mtx.RUnlock()
+				require.Error(t, inst.Process(2, &bad))
+			}
+			return nil
+		}
+		defer func() { transport.Fault = nil }()
+		id := crypto.NewID()
+		_, ks, err := clnt.StartDKG(context.Background(), id, withdraw.Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
+		require.NoError(t, err)
+		require.Equal(t, int32(1), atomic.LoadInt32(&injected))
+		status, err := switches[1].InstanceStatus(id)
+		require.NoError(t, err)
+		require.Equal(t, dkg.PhaseOutput, status.Phase)
+		require.Equal(t, ks.Payload.PublicKey[2:], status.ValidatorPubKey)
+		require.Empty(t, status.Error)
+		require.Equal(t, uint64(1), status.RejectedMessages)
+		require.NotEmpty(t, status.LastRejection)
+	})
+	t.Run("test operator failing in the middle of a ceremony", func(t *testing.T) {
+		transport.Fault = func(operatorID uint64, method string, payload []byte) error {
+			if operatorID == 4 && method == consts.API_DKG_URL {
+				return errors.New("injected fault")
+			}
+			return nil
+		}
+		defer func() { transport.Fault = nil }()
+		id := crypto.NewID()
+		_, _, err := clnt.StartDKG(context.Background(), id, withdraw.Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 1)
+		require.ErrorContains(t, err, "injected fault")
+		// the failed ceremony is cancelled at the operators
+		for _, s := range switches {
+			_, err := s.InstanceStatus(id)
+			require.Error(t, err)
+		}
+	})
+	t.Run("test bulk returns validators of batches finished before a failure", func(t *testing.T) {
+		var inits int32
+		transport.Fault = func(operatorID uint64, method string, payload []byte) error {
+			// the second batch fails
+			if operatorID == 4 && method == consts.API_INIT_BATCH_URL && atomic.AddInt32(&inits, 1) == 2 {
+				return errors.New("injected fault")
+			}
+			return nil
+		}
+		defer func() { transport.Fault = nil }()
+		held := make(map[string]bool)
+		for _, s := range switches {
+			for _, status := range s.InstanceStatuses() {
+				held[status.RequestID] = true
+			}
+		}
+		results, err := clnt.StartBulkDKG(context.Background(), withdraw.Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 20, 4, 2)
+		require.ErrorContains(t, err, "injected fault")
+		require.Len(t, results, 2)
+		for i, res := range results {
+			require.Equal(t, uint64(20+i), res.Nonce)
+			testDepositData(t, res.DepositData, withdraw.Bytes(), owner, uint16(res.Nonce))
+			held[hex.EncodeToString(res.ID[:])] = true
+		}
+		// the instances of the failed batch are cancelled at the operators
+		for id, s := range switches {
+			for _, status := range s.InstanceStatuses() {
+				require.True(t, held[status.RequestID], "operator %d holds instance %s of the failed batch", id, status.RequestID)
+			}
+		}
+
+		atomic.StoreInt32(&inits, 0)
+		runClnt, err := initiator.NewInitiator(priv, ops, initiator.WithLogger(logger), initiator.WithTransport(transport), initiator.WithBatchSize(2))
+		require.NoError(t, err)
+		_, err = runClnt.Run(context.Background(), initiator.CeremonyRequest{
+			OperatorIDs:       []uint64{1, 2, 3, 4},
+			WithdrawalAddress: withdraw,
+			Owner:             owner,
+			Nonce:             30,
+			Validators:        4,
+			Network:           "mainnet",
+		})
+		var cerr *initiator.CeremonyError
+		require.ErrorAs(t, err, &cerr)
+		require.NotNil(t, cerr.Result)
+		require.Len(t, cerr.Result.DepositData, 2)
+		require.Len(t, cerr.Result.BulkKeyShares().Shares, 2)
+	})
+	t.Run("test ceremony goes on without an operator removed from the process", func(t *testing.T) {
+		transport.Remove(4)
+		defer transport.Add(4, switches[4])
+		clnt.TolerateOffline = true
+		defer func() { clnt.TolerateOffline = false }()
+		_, ks, err := clnt.StartDKG(context.Background(), crypto.NewID(), withdraw.Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 2)
+		require.NoError(t, err)
+		require.Equal(t, []uint64{4}, ks.MissingShares)
+	})
+}
+
+func TestRunCeremony(t *testing.T) {
+	if err := logging.SetGlobalLogger("info", "capital", "console", nil); err != nil {
+		panic(err)
+	}
+	logger := zap.L().Named("integration-tests")
+	ops := make(map[uint64]initiator.Operator)
+	switches := make(map[uint64]*operator.Switch)
+	keys := make([]*rsa.PrivateKey, 0, 4)
+	for i := uint64(1); i <= 4; i++ {
+		_, pv, err := rsaencryption.GenerateKeys()
+		require.NoError(t, err)
+		opPriv, err := rsaencryption.ConvertPemToPrivateKey(string(pv))
+		require.NoError(t, err)
+		keys = append(keys, opPriv)
+		switches[i] = operator.NewSwitch(opPriv, logger)
+		ops[i] = initiator.Operator{ID: i, PubKey: &opPriv.PublicKey}
+	}
+	transport := local.NewTransport(switches)
+	_, pv, err := rsaencryption.GenerateKeys()
+	require.NoError(t, err)
+	priv, err := rsaencryption.ConvertPemToPrivateKey(string(pv))
+	require.NoError(t, err)
+	withdraw := newEthAddress(t)
+	owner := newEthAddress(t)
+	req := initiator.CeremonyRequest{
+		OperatorIDs:       []uint64{1, 2, 3, 4},
+		WithdrawalAddress: withdraw,
+		Owner:             owner,
+		Network:           "mainnet",
+	}
+	t.Run("test ceremony of a single validator", func(t *testing.T) {
+		clnt, err := initiator.NewInitiator(priv, ops, initiator.WithLogger(logger), initiator.WithTransport(transport), initiator.WithTranscript())
+		require.NoError(t, err)
+		result, err := clnt.Run(context.Background(), req)
+		require.NoError(t, err)
+		require.Len(t, result.DepositData, 1)
+		require.Len(t, result.KeyShares, 1)
+		sharesDataSigned, err := hex.DecodeString(result.KeyShares[0].Payload.SharesData[2:])
+		require.NoError(t, err)
+		pubkeyraw, err := hex.DecodeString(result.KeyShares[0].Payload.PublicKey[2:])
+		require.NoError(t, err)
+		require.NoError(t, testSharesData(ops, 4, keys, sharesDataSigned, pubkeyraw, owner, 0))
+		testDepositData(t, result.DepositData[0], withdraw.Bytes(), owner, 0)
+		require.Len(t, result.Proofs(), 4)
+		require.NotNil(t, result.Transcript)
+		_, err = initiator.VerifyTranscript(result.Transcript, ops)
+		require.NoError(t, err)
+	})
+	t.Run("test ceremony of multiple validators", func(t *testing.T) {
+		clnt, err := initiator.NewInitiator(priv, ops, initiator.WithLogger(logger), initiator.WithTransport(transport), initiator.WithCompounding(), initiator.WithDepositAmount(64_000_000_000))
+		require.NoError(t, err)
+		bulkReq := req
+		bulkReq.Nonce = 1
+		bulkReq.Validators = 2
+		result, err := clnt.Run(context.Background(), bulkReq)
+		require.NoError(t, err)
+		require.Len(t, result.DepositData, 2)
+		require.Len(t, result.BulkKeyShares().Shares, 2)
+		require.Nil(t, result.Transcript)
+		for i, ks := range result.KeyShares {
+			require.NoError(t, initiator.ValidateKeyShares(ks.Data, ks.Payload, owner, uint64(1+i)))
+			require.Equal(t, phase0.Gwei(64_000_000_000), result.DepositData[i].Amount)
+		}
+	})
+	t.Run("test initiator runs ceremonies again and in parallel", func(t *testing.T) {
+		core, logs := observer.New(zap.InfoLevel)
+		clnt, err := initiator.NewInitiator(priv, ops, initiator.WithLogger(zap.New(core)), initiator.WithTransport(transport), initiator.WithTranscript())
+		require.NoError(t, err)
+		check := func(t *testing.T, result *initiator.CeremonyResult, nonce uint64) {
+			require.NoError(t, initiator.ValidateKeyShares(result.KeyShares[0].Data, result.KeyShares[0].Payload, owner, nonce))
+			// the transcript has only the ceremony of the run
+			report, err := initiator.VerifyTranscript(result.Transcript, ops)
+			require.NoError(t, err)
+			require.Len(t, report.Ceremonies, 1)
+			require.NoError(t, report.CheckKeyShares(result.KeyShares[0].Data, result.KeyShares[0].Payload))
+		}
+		for nonce := uint64(10); nonce < 12; nonce++ {
+			againReq := req
+			againReq.Nonce = nonce
+			result, err := clnt.Run(context.Background(), againReq)
+			require.NoError(t, err)
+			check(t, result, nonce)
+		}
+		var wg sync.WaitGroup
+		results := make([]*initiator.CeremonyResult, 2)
+		errs := make([]error, 2)
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				parallelReq := req
+				parallelReq.Nonce = uint64(12 + i)
+				results[i], errs[i] = clnt.Run(context.Background(), parallelReq)
+			}(i)
+		}
+		wg.Wait()
+		for i := range results {
+			require.NoError(t, errs[i])
+			check(t, results[i], uint64(12+i))
+		}
+		require.Empty(t, clnt.Transcript.Messages)
+		// every log entry has the ID of a single ceremony
+		for _, entry := range logs.All() {
+			ids := 0
+			for _, f := range entry.Context {
+				if f.Key == "instance_id" {
+					ids++
+				}
+			}
+			require.LessOrEqual(t, ids, 1, entry.Message)
+		}
+	})
+	t.Run("test invalid requests and options", func(t *testing.T) {
+		clnt, err := initiator.NewInitiator(priv, ops, initiator.WithLogger(logger), initiator.WithTransport(transport))
+		require.NoError(t, err)
+		wrongReq := req
+		wrongReq.OperatorIDs = []uint64{1, 2, 3, 5}
+		_, err = clnt.Run(context.Background(), wrongReq)
+		require.ErrorIs(t, err, initiator.ErrInvalidRequest)
+		wrongReq = req
+		wrongReq.Network = "unknown"
+		_, err = clnt.Run(context.Background(), wrongReq)
+		require.ErrorIs(t, err, initiator.ErrInvalidRequest)
+		_, err = initiator.NewInitiator(priv, ops, initiator.WithDepositAmount(64_000_000_000))
+		require.ErrorIs(t, err, initiator.ErrInvalidOption)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = clnt.Run(ctx, req)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+	t.Run("test cancelled ceremony", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		faulty := local.NewTransport(switches)
+		faulty.Fault = func(operatorID uint64, method string, payload []byte) error {
+			if method == consts.API_DKG_URL {
+				cancel()
+			}
+			return nil
+		}
+		clnt, err := initiator.NewInitiator(priv, ops, initiator.WithLogger(logger), initiator.WithTransport(faulty))
+		require.NoError(t, err)
+		cancelledReq := req
+		cancelledReq.ID = crypto.NewID()
+		cancelledReq.Nonce = 3
+		_, err = clnt.Run(ctx, cancelledReq)
+		require.ErrorIs(t, err, context.Canceled)
+		var ceremonyErr *initiator.CeremonyError
+		require.ErrorAs(t, err, &ceremonyErr)
+		require.Equal(t, cancelledReq.ID, ceremonyErr.ID)
+		// the ceremony is cancelled at the operators
+		for _, s := range switches {
+			_, err := s.InstanceStatus(cancelledReq.ID)
+			require.Error(t, err)
+		}
+	})
+	t.Run("test ceremony deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+		defer cancel()
+		stuck := local.NewTransport(switches)
+		// operator 4 doesn't respond to dkg messages until the deadline of the ceremony
+		stuck.Fault = func(operatorID uint64, method string, payload []byte) error {
+			if operatorID == 4 && method == consts.API_DKG_URL {
+				<-ctx.Done()
+				return errors.New("operator is stuck")
+			}
+			return nil
+		}
+		clnt, err := initiator.NewInitiator(priv, ops, initiator.WithLogger(logger), initiator.WithTransport(stuck))
+		require.NoError(t, err)
+		deadlineReq := req
+		deadlineReq.ID = crypto.NewID()
+		deadlineReq.Nonce = 4
+		_, err = clnt.Run(ctx, deadlineReq)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		var ceremonyErr *initiator.CeremonyError
+		require.ErrorAs(t, err, &ceremonyErr)
+		require.Equal(t, deadlineReq.ID, ceremonyErr.ID)
+		for _, s := range switches {
+			_, err := s.InstanceStatus(deadlineReq.ID)
+			require.Error(t, err)
+		}
+	})
+}
+
+func TestResume(t *testing.T) {
+	if err := logging.SetGlobalLogger("info", "capital", "console", nil); err != nil {
+		panic(err)
+	}
+	logger := zap.L().Named("integration-tests")
+	ops := make(map[uint64]initiator.Operator)
+	srvs := make([]*operator.TestOperator, 0, 4)
+	for i := uint64(1); i <= 4; i++ {
+		srv := operator.CreateTestOperator(t, i)
+		srvs = append(srvs, srv)
+		ops[i] = initiator.Operator{Addr: srv.HttpSrv.URL, ID: i, PubKey: &srv.PrivKey.PublicKey}
+	}
+	_, pv, err := rsaencryption.GenerateKeys()
+	require.NoError(t, err)
+	priv, err := rsaencryption.ConvertPemToPrivateKey(string(pv))
+	require.NoError(t, err)
+	withdraw := newEthAddress(t)
+	owner := newEthAddress(t)
+	// interruptedInitiator loses responses of the operators to dkg messages after the given number of them
+	interruptedInitiator := func(after int32, statePath string) *initiator.Initiator {
+		clnt := initiator.New(priv, ops, logger)
+		clnt.StatePath = statePath
+		var n int32
+		clnt.Client.OnAfterResponse(func(client *req.Client, resp *req.Response) error {
+			if strings.HasSuffix(resp.Request.RawURL, "/"+consts.API_DKG_URL) && atomic.AddInt32(&n, 1) > after {
+				return errors.New("connection lost")
+			}
+			return nil
+		})
+		return clnt
+	}
+	resume := func(t *testing.T, statePath string, phase int, nonce uint64) {
+		state, err := initiator.LoadCeremonyState(statePath)
+		require.NoError(t, err)
+		require.Equal(t, phase, state.Phase)
+		clnt := initiator.New(priv, ops, logger)
+		clnt.StatePath = statePath
+		depositData, ks, err := clnt.Resume(context.Background(), state)
+		require.NoError(t, err)
+		require.NoFileExists(t, statePath)
+		sharesDataSigned, err := hex.DecodeString(ks.Payload.SharesData[2:])
+		require.NoError(t, err)
+		pubkeyraw, err := hex.DecodeString(ks.Payload.PublicKey[2:])
+		require.NoError(t, err)
+		err = testSharesData(ops, 4, []*rsa.PrivateKey{srvs[0].PrivKey, srvs[1].PrivKey, srvs[2].PrivKey, srvs[3].PrivKey}, sharesDataSigned, pubkeyraw, owner, uint16(nonce))
+		require.NoError(t, err)
+		testDepositData(t, depositData, withdraw.Bytes(), owner, uint16(nonce))
+		require.NoError(t, initiator.ValidateProofs(ks.Proofs, ks.Data, ks.Payload, owner, nonce))
+	}
+	t.Run("test ceremony resumes after lost results", func(t *testing.T) {
+		statePath := filepath.Join(t.TempDir(), "ceremony.json")
+		clnt := interruptedInitiator(4, statePath)
+		_, _, err := clnt.StartDKG(context.Background(), crypto.NewID(), withdraw.Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
+		require.ErrorContains(t, err, "connection lost")
+		// operators finished the ceremony and respond again with their results
+		resume(t, statePath, initiator.PhaseExchangeCompleted, 0)
+	})
+	t.Run("test ceremony resumes after lost deals", func(t *testing.T) {
+		statePath := filepath.Join(t.TempDir(), "ceremony.json")
+		clnt := interruptedInitiator(0, statePath)
+		_, _, err := clnt.StartDKG(context.Background(), crypto.NewID(), withdraw.Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 1)
+		require.ErrorContains(t, err, "connection lost")
+		resume(t, statePath, initiator.PhaseInitCompleted, 1)
+	})
+	t.Run("test aborted ceremony frees its instances", func(t *testing.T) {
+		statePath := filepath.Join(t.TempDir(), "ceremony.json")
+		id := crypto.NewID()
+		clnt := interruptedInitiator(0, statePath)
+		_, _, err := clnt.StartDKG(context.Background(), id, withdraw.Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 2)
+		require.ErrorContains(t, err, "connection lost")
+		// operators hold the instance of a ceremony which can be resumed
+		for _, srv := range srvs {
+			_, err := srv.Srv.State.InstanceStatus(id)
+			require.NoError(t, err)
+		}
+		state, err := initiator.LoadCeremonyState(statePath)
+		require.NoError(t, err)
+		clnt = initiator.New(priv, ops, logger)
+		clnt.StatePath = statePath
+		require.NoError(t, clnt.AbortState(context.Background(), state))
+		require.NoFileExists(t, statePath)
+		for _, srv := range srvs {
+			_, err := srv.Srv.State.InstanceStatus(id)
+			require.ErrorContains(t, err, "not found")
+		}
+		// request IDs are used once, even if the ceremony was aborted
+		clnt = initiator.New(priv, ops, logger)
+		_, _, err = clnt.StartDKG(context.Background(), id, withdraw.Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 2)
+		require.ErrorContains(t, err, "request ID of the init message was already used")
+	})
+	t.Run("test failed ceremony without state is aborted", func(t *testing.T) {
+		id := crypto.NewID()
+		clnt := interruptedInitiator(0, "")
+		_, _, err := clnt.StartDKG(context.Background(), id, withdraw.Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 3)
+		require.ErrorContains(t, err, "connection lost")
+		for _, srv := range srvs {
+			_, err := srv.Srv.State.InstanceStatus(id)
+			require.ErrorContains(t, err, "not found")
+		}
+	})
+	for _, srv := range srvs {
+		srv.HttpSrv.Close()
+	}
+}
+
+func TestTranscript(t *testing.T) {
+	if err := logging.SetGlobalLogger("info", "capital", "console", nil); err != nil {
+		panic(err)
+	}
+	logger := zap.L().Named("integration-tests")
+	ops := make(map[uint64]initiator.Operator)
+	srvs := make(map[uint64]*operator.TestOperator)
+	for i := uint64(1); i <= 5; i++ {
+		srv := operator.CreateTestOperator(t, i)
+		shareStore, err := store.New(t.TempDir(), "password")
+		require.NoError(t, err)
+		srv.Srv.State.Store = shareStore
+		srvs[i] = srv
+		ops[i] = initiator.Operator{Addr: srv.HttpSrv.URL, ID: i, PubKey: &srv.PrivKey.PublicKey}
+	}
+	_, pv, err := rsaencryption.GenerateKeys()
+	require.NoError(t, err)
+	priv, err := rsaencryption.ConvertPemToPrivateKey(string(pv))
+	require.NoError(t, err)
+	withdraw := newEthAddress(t)
+	owner := newEthAddress(t)
+	clnt := initiator.New(priv, ops, logger)
+	clnt.Transcript = initiator.NewTranscript()
+	_, ks, err := clnt.StartDKG(context.Background(), crypto.NewID(), withdraw.Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
+	require.NoError(t, err)
+	require.NoError(t, clnt.SignTranscript())
+	validatorPK, err := hex.DecodeString(ks.Payload.PublicKey[2:])
+	require.NoError(t, err)
+	// transcript as read from a file
+	load := func(t *testing.T, tr *initiator.Transcript) *initiator.Transcript {
+		byts, err := json.Marshal(tr)
+		require.NoError(t, err)
+		loaded := &initiator.Transcript{}
+		require.NoError(t, json.Unmarshal(byts, loaded))
+		return loaded
+	}
+	t.Run("test verify dkg transcript", func(t *testing.T) {
+		report, err := initiator.VerifyTranscript(load(t, clnt.Transcript), ops)
+		require.NoError(t, err)
+		require.Len(t, report.Ceremonies, 1)
+		require.Equal(t, validatorPK, report.Ceremonies[0].ValidatorPubKey)
+		require.Equal(t, []uint64{1, 2, 3, 4}, report.Ceremonies[0].Outputs)
+		require.Equal(t, 4, report.Bundles)
+		require.NoError(t, report.CheckKeyShares(ks.Data, ks.Payload))
+		other := *ks
+		other.Payload.PublicKey = "0x" + hex.EncodeToString(make([]byte, 48))
+		require.ErrorContains(t, report.CheckKeyShares(other.Data, other.Payload), "is not a result of the transcript")
+		// operator keys of the keyshares have to be the ones of the transcript
+		swapped := *ks
+		swapped.Data.Operators = append([]initiator.OperatorData{}, ks.Data.Operators...)
+		swapped.Data.Operators[0].OperatorKey, swapped.Data.Operators[1].OperatorKey = swapped.Data.Operators[1].OperatorKey, swapped.Data.Operators[0].OperatorKey
+		require.ErrorContains(t, report.CheckKeyShares(swapped.Data, swapped.Payload), "public key of operator 1 doesn't follow from the transcript")
+	})
+	t.Run("test transcript signed with swapped operator keys", func(t *testing.T) {
+		swappedOps := make(map[uint64]initiator.Operator)
+		for id, op := range ops {
+			swappedOps[id] = op
+		}
+		op1, op2 := swappedOps[1], swappedOps[2]
+		op1.PubKey, op2.PubKey = op2.PubKey, op1.PubKey
+		swappedOps[1], swappedOps[2] = op1, op2
+		forged := load(t, clnt.Transcript)
+		signer := initiator.New(priv, swappedOps, logger)
+		signer.Transcript = forged
+		require.NoError(t, signer.SignTranscript())
+		_, err := initiator.VerifyTranscript(load(t, forged), ops)
+		require.ErrorContains(t, err, "public key of operator 1 doesn't match operators info")
+		_, err = initiator.VerifyTranscript(load(t, clnt.Transcript), nil)
+		require.ErrorContains(t, err, "operators to verify the transcript against are required")
+	})
+	t.Run("test tampered transcript", func(t *testing.T) {
+		tampered := load(t, clnt.Transcript)
+		tampered.Messages = tampered.Messages[1:]
+		_, err := initiator.VerifyTranscript(tampered, ops)
+		require.ErrorContains(t, err, "transcript signature isn't valid")
+	})
+	t.Run("test tampered operator message", func(t *testing.T) {
+		tampered := load(t, clnt.Transcript)
+		for i, msg := range tampered.Messages {
+			if msg.Route != consts.API_INIT_URL {
+				continue
+			}
+			// flip a byte of the exchange message of the operator and sign the transcript again
+			res := []byte(msg.Response)
+			if res[len(res)-1] == '0' {
+				res[len(res)-1] = '1'
+			} else {
+				res[len(res)-1] = '0'
+			}
+			tampered.Messages[i].Response = string(res)
+			break
+		}
+		signer := initiator.New(priv, ops, logger)
+		signer.Transcript = tampered
+		require.NoError(t, signer.SignTranscript())
+		_, err := initiator.VerifyTranscript(load(t, tampered), ops)
+		require.Error(t, err)
+	})
+	t.Run("test verify reshare transcript", func(t *testing.T) {
+		clnt := initiator.New(priv, ops, logger)
+		clnt.Transcript = initiator.NewTranscript()
+		ks, err := clnt.StartReshare(context.Background(), crypto.NewID(), []uint64{1, 2, 3, 4}, []uint64{2, 3, 4, 5}, validatorPK, 3, owner, 1)
+		require.NoError(t, err)
+		require.NoError(t, clnt.SignTranscript())
+		report, err := initiator.VerifyTranscript(load(t, clnt.Transcript), ops)
+		require.NoError(t, err)
+		require.Len(t, report.Ceremonies, 1)
+		require.True(t, report.Ceremonies[0].Reshare)
+		require.Equal(t, validatorPK, report.Ceremonies[0].ValidatorPubKey)
+		require.NoError(t, report.CheckKeyShares(ks.Data, ks.Payload))
+	})
+	t.Run("test verify aborted ceremony transcript", func(t *testing.T) {
+		switches := make(map[uint64]*operator.Switch)
+		for id, srv := range srvs {
+			switches[id] = srv.Srv.State
+		}
+		faulty := local.NewTransport(switches)
+		faulty.Fault = func(operatorID uint64, method string, payload []byte) error {
+			if method == consts.API_DKG_URL {
+				return errors.New("injected fault")
+			}
+			return nil
+		}
+		clnt := initiator.New(priv, ops, logger)
+		clnt.Transport = faulty
+		clnt.Transcript = initiator.NewTranscript()
+		id := crypto.NewID()
+		_, _, err := clnt.StartDKG(context.Background(), id, withdraw.Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 2)
+		require.ErrorContains(t, err, "injected fault")
+		require.NoError(t, clnt.SignTranscript())
+		cancels := 0
+		for _, msg := range clnt.Transcript.Messages {
+			if msg.Route == consts.API_CANCEL_URL {
+				cancels++
+			}
+		}
+		require.Equal(t, 4, cancels)
+		report, err := initiator.VerifyTranscript(load(t, clnt.Transcript), ops)
+		require.NoError(t, err)
+		require.Len(t, report.Ceremonies, 1)
+		require.Equal(t, id, report.Ceremonies[0].RequestID)
+		require.True(t, report.Ceremonies[0].Cancelled)
+		require.Empty(t, report.Ceremonies[0].Outputs)
+	})
+	t.Run("test verify bulk transcript", func(t *testing.T) {
+		clnt := initiator.New(priv, ops, logger)
+		clnt.Transcript = initiator.NewTranscript()
+		results, err := clnt.StartBulkDKG(context.Background(), withdraw.Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 2, 2, 2)
+		require.NoError(t, err)
+		require.NoError(t, clnt.SignTranscript())
+		report, err := initiator.VerifyTranscript(load(t, clnt.Transcript), ops)
+		require.NoError(t, err)
+		require.Len(t, report.Ceremonies, 2)
+		for _, item := range initiator.GenerateBulkKeyShares(results).Shares {
+			require.NoError(t, report.CheckKeyShares(item.Data, item.Payload))
+		}
+	})
+	for _, srv := range srvs {
+		srv.HttpSrv.Close()
+	}
+}
+
+func TestVerifyOutputs(t *testing.T) {
+	if err := logging.SetGlobalLogger("info", "capital", "console", nil); err != nil {
+		panic(err)
+	}
+	logger := zap.L().Named("integration-tests")
+	ops := make(map[uint64]initiator.Operator)
+	srvs := make([]*operator.TestOperator, 0, 4)
+	for i := uint64(1); i <= 4; i++ {
+		srv := operator.CreateTestOperator(t, i)
+		srvs = append(srvs, srv)
+		ops[i] = initiator.Operator{Addr: srv.HttpSrv.URL, ID: i, PubKey: &srv.PrivKey.PublicKey}
+	}
+	_, pv, err := rsaencryption.GenerateKeys()
+	require.NoError(t, err)
+	priv, err := rsaencryption.ConvertPemToPrivateKey(string(pv))
+	require.NoError(t, err)
+	clnt := initiator.New(priv, ops, logger)
+	withdraw := newEthAddress(t)
+	owner := newEthAddress(t)
+	depositData, ks, err := clnt.StartDKG(context.Background(), crypto.NewID(), withdraw.Bytes(), []uint64{1, 2, 3, 4}, network.Mainnet.ForkVersion, network.Mainnet.Name, owner, 3)
+	require.NoError(t, err)
+	t.Run("test valid outputs", func(t *testing.T) {
+		require.NoError(t, initiator.ValidateDepositData(depositData, withdraw, network.Mainnet))
+		require.NoError(t, initiator.ValidateKeyShares(ks.Data, ks.Payload, owner, 3))
+	})
+	t.Run("test deposit data of another withdrawal address or network", func(t *testing.T) {
+		require.ErrorContains(t, initiator.ValidateDepositData(depositData, newEthAddress(t), network.Mainnet), "don't match withdrawal address")
+		require.ErrorContains(t, initiator.ValidateDepositData(depositData, withdraw, network.Holesky), "deposit data is for network mainnet")
+		tampered := *depositData
+		tampered.Amount = 2 * initiator.MaxEffectiveBalanceInGwei / 4
+		require.ErrorContains(t, initiator.ValidateDepositData(&tampered, withdraw, network.Mainnet), "deposit data signature is invalid")
+	})
+	t.Run("test keyshares of another owner or nonce", func(t *testing.T) {
+		require.ErrorContains(t, initiator.ValidateKeyShares(ks.Data, ks.Payload, newEthAddress(t), 3), "owner and nonce signature")
+		require.ErrorContains(t, initiator.ValidateKeyShares(ks.Data, ks.Payload, owner, 4), "owner and nonce signature")
+	})
+	t.Run("test tampered keyshares", func(t *testing.T) {
+		payload := ks.Payload
+		payload.SharesData = payload.SharesData[:len(payload.SharesData)-2]
+		require.ErrorContains(t, initiator.ValidateKeyShares(ks.Data, payload, owner, 3), "malformed ssv share data")
+		// swap share public keys of the first two operators
+		sharesData, err := hex.DecodeString(ks.Payload.SharesData[2:])
+		require.NoError(t, err)
+		first := append([]byte{}, sharesData[phase0.SignatureLength:phase0.SignatureLength+phase0.PublicKeyLength]...)
+		copy(sharesData[phase0.SignatureLength:], sharesData[phase0.SignatureLength+phase0.PublicKeyLength:phase0.SignatureLength+2*phase0.PublicKeyLength])
+		copy(sharesData[phase0.SignatureLength+phase0.PublicKeyLength:], first)
+		payload.SharesData = "0x" + hex.EncodeToString(sharesData)
+		require.ErrorContains(t, initiator.ValidateKeyShares(ks.Data, payload, owner, 3), "not equal to the key recovered from shares")
+	})
+	t.Run("test operator proofs", func(t *testing.T) {
+		require.Len(t, ks.Proofs, 4)
+		for i, p := range ks.Proofs {
+			require.Equal(t, uint64(i+1), p.OperatorID)
+			require.NoError(t, p.Verify(&srvs[i].PrivKey.PublicKey))
+			require.Error(t, p.Verify(&srvs[(i+1)%4].PrivKey.PublicKey))
+		}
+		require.NoError(t, initiator.ValidateProofs(ks.Proofs, ks.Data, ks.Payload, owner, 3))
+		require.ErrorContains(t, initiator.ValidateProofs(ks.Proofs, ks.Data, ks.Payload, owner, 4), "doesn't match the key shares")
+		require.ErrorContains(t, initiator.ValidateProofs(ks.Proofs[1:], ks.Data, ks.Payload, owner, 3), "no proof of operator 1")
+		tampered := *ks.Proofs[0]
+		tampered.SharePubKey = ks.Proofs[1].SharePubKey
+		require.ErrorContains(t, initiator.ValidateProofs([]*initiator.SignedProof{&tampered, ks.Proofs[1], ks.Proofs[2], ks.Proofs[3]}, ks.Data, ks.Payload, owner, 3), "proof signature of operator 1 isn't valid")
+	})
+	for _, srv := range srvs {
+		srv.HttpSrv.Close()
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	if err := logging.SetGlobalLogger("info", "capital", "console", nil); err != nil {
+		panic(err)
+	}
+	logger := zap.L().Named("integration-tests")
+	ops := make(map[uint64]initiator.Operator)
+	srvs := make([]*operator.TestOperator, 0, 4)
+	for i := uint64(1); i <= 4; i++ {
+		srv := operator.CreateTestOperator(t, i)
+		srvs = append(srvs, srv)
+		ops[i] = initiator.Operator{Addr: srv.HttpSrv.URL, ID: i, PubKey: &srv.PrivKey.PublicKey}
+	}
+	_, pv, err := rsaencryption.GenerateKeys()
+	require.NoError(t, err)
+	priv, err := rsaencryption.ConvertPemToPrivateKey(string(pv))
+	require.NoError(t, err)
+	clnt := initiator.New(priv, ops, logger)
+	completed := testutil.ToFloat64(metrics.CeremoniesCompleted)
+	rejected := testutil.ToFloat64(metrics.CeremoniesFailed.WithLabelValues(metrics.ReasonInvalidInit))
+	initErrors := testutil.ToFloat64(metrics.OperatorRequestErrors.WithLabelValues("1", consts.API_INIT_URL))
+	_, _, err = clnt.StartDKG(context.Background(), crypto.NewID(), newEthAddress(t).Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", newEthAddress(t), 0)
+	require.NoError(t, err)
+	for _, srv := range srvs {
+		srv.Srv.State.Policy = threshold.Policy{MinOperators: 7, MaxOperators: 7}
+	}
+	_, _, err = clnt.StartDKG(context.Background(), crypto.NewID(), newEthAddress(t).Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", newEthAddress(t), 0)
+	require.ErrorContains(t, err, "minimum supported amount of operators is 7")
+	t.Run("test operator metrics", func(t *testing.T) {
+		require.Equal(t, completed+4, testutil.ToFloat64(metrics.CeremoniesCompleted))
+		require.Equal(t, rejected+4, testutil.ToFloat64(metrics.CeremoniesFailed.WithLabelValues(metrics.ReasonInvalidInit)))
+		resp, err := http.Get(srvs[0].HttpSrv.URL + "/metrics")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		for _, line := range []string{
+			`ssv_dkg_operator_ceremonies_started_total{type="init"}`,
+			`ssv_dkg_operator_phase_duration_seconds_count{phase="deal"}`,
+			`ssv_dkg_operator_active_instances 0`,
+			`ssv_dkg_operator_instances_saturation 0.0009765625`,
+		} {
+			require.Contains(t, string(body), line)
+		}
+	})
+	t.Run("test initiator metrics", func(t *testing.T) {
+		require.Equal(t, initErrors+1, testutil.ToFloat64(metrics.OperatorRequestErrors.WithLabelValues("1", consts.API_INIT_URL)))
+		pushed := make(chan string, 1)
+		gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			pushed <- r.URL.Path + " " + string(body)
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer gateway.Close()
+		require.NoError(t, initiator.PushMetrics(gateway.URL))
+		push := <-pushed
+		require.Contains(t, push, "/metrics/job/ssv_dkg_initiator")
+		require.Contains(t, push, "ssv_dkg_initiator_operator_request_duration_seconds")
+		require.Contains(t, push, "ssv_dkg_initiator_operator_request_errors_total")
+	})
+	for _, srv := range srvs {
+		srv.HttpSrv.Close()
+	}
+}
+
 func TestUnhappyFlows(t *testing.T) {
 	if err := logging.SetGlobalLogger("info", "capital", "console", nil); err != nil {
 		panic(err)
@@ -321,7 +1528,7 @@ func TestUnhappyFlows(t *testing.T) {
 	withdraw := newEthAddress(t)
 	owner := newEthAddress(t)
 	id := crypto.NewID()
-	depositData, ks, err := clnt.StartDKG(id, withdraw.Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
+	depositData, ks, err := clnt.StartDKG(context.Background(), id, withdraw.Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
 	require.NoError(t, err)
 	sharesDataSigned, err := hex.DecodeString(ks.Payload.SharesData[2:])
 	require.NoError(t, err)
@@ -334,7 +1541,7 @@ func TestUnhappyFlows(t *testing.T) {
 		withdraw := newEthAddress(t)
 		owner := newEthAddress(t)
 		id := crypto.NewID()
-		_, ks, err = clnt.StartDKG(id, withdraw.Bytes(), []uint64{13, 3, 2, 4, 5, 6, 7, 8, 9, 10, 11, 12, 1}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
+		_, ks, err = clnt.StartDKG(context.Background(), id, withdraw.Bytes(), []uint64{13, 3, 2, 4, 5, 6, 7, 8, 9, 10, 11, 12, 1}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
 		require.NoError(t, err)
 		sharesDataSigned, err := hex.DecodeString(ks.Payload.SharesData[2:])
 		require.NoError(t, err)
@@ -353,14 +1560,14 @@ func TestUnhappyFlows(t *testing.T) {
 		require.ErrorContains(t, err, "shares order is incorrect")
 	})
 	t.Run("test same ID", func(t *testing.T) {
-		_, _, err = clnt.StartDKG(id, withdraw.Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
+		_, _, err = clnt.StartDKG(context.Background(), id, withdraw.Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
 		require.ErrorContains(t, err, "got init msg for existing instance")
 	})
 	t.Run("test wrong operator IDs", func(t *testing.T) {
 		withdraw := newEthAddress(t)
 		owner := newEthAddress(t)
 		id := crypto.NewID()
-		_, _, err = clnt.StartDKG(id, withdraw.Bytes(), []uint64{101, 6, 7, 8}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
+		_, _, err = clnt.StartDKG(context.Background(), id, withdraw.Bytes(), []uint64{101, 6, 7, 8}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
 		require.ErrorContains(t, err, "operator is not in given operator data list")
 	})
 	srv1.HttpSrv.Close()
@@ -378,6 +1585,299 @@ func TestUnhappyFlows(t *testing.T) {
 	srv13.HttpSrv.Close()
 }
 
+func TestReshare(t *testing.T) {
+	if err := logging.SetGlobalLogger("info", "capital", "console", nil); err != nil {
+		panic(err)
+	}
+	logger := zap.L().Named("integration-tests")
+
+	ops := make(map[uint64]initiator.Operator)
+	srvs := make(map[uint64]*operator.TestOperator)
+	for i := uint64(1); i <= 7; i++ {
+		srv := operator.CreateTestOperator(t, i)
+		shareStore, err := store.New(t.TempDir(), "password")
+		require.NoError(t, err)
+		srv.Srv.State.Store = shareStore
+		srvs[i] = srv
+		ops[i] = initiator.Operator{Addr: srv.HttpSrv.URL, ID: i, PubKey: &srv.PrivKey.PublicKey}
+	}
+	// Initiator priv key
+	_, pv, err := rsaencryption.GenerateKeys()
+	require.NoError(t, err)
+	priv, err := rsaencryption.ConvertPemToPrivateKey(string(pv))
+	require.NoError(t, err)
+	clnt := initiator.New(priv, ops, logger)
+	withdraw := newEthAddress(t)
+	owner := newEthAddress(t)
+	id := crypto.NewID()
+	_, ks, err := clnt.StartDKG(context.Background(), id, withdraw.Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
+	require.NoError(t, err)
+	validatorPK, err := hex.DecodeString(ks.Payload.PublicKey[2:])
+	require.NoError(t, err)
+
+	t.Run("test reshare 4 operators to 5 operators", func(t *testing.T) {
+		clnt := initiator.New(priv, ops, logger)
+		id := crypto.NewID()
+		ks, err := clnt.StartReshare(context.Background(), id, []uint64{1, 2, 3, 4}, []uint64{3, 4, 5, 6, 7}, validatorPK, 3, owner, 1)
+		require.NoError(t, err)
+		require.Equal(t, []uint64{3, 4, 5, 6, 7}, ks.Payload.OperatorIDs)
+		require.NoError(t, initiator.ValidateProofs(ks.Proofs, ks.Data, ks.Payload, owner, 1))
+		sharesDataSigned, err := hex.DecodeString(ks.Payload.SharesData[2:])
+		require.NoError(t, err)
+		pubkeyraw, err := hex.DecodeString(ks.Payload.PublicKey[2:])
+		require.NoError(t, err)
+		require.Equal(t, validatorPK, pubkeyraw)
+		err = testSharesData(ops, 5, []*rsa.PrivateKey{srvs[3].PrivKey, srvs[4].PrivKey, srvs[5].PrivKey, srvs[6].PrivKey, srvs[7].PrivKey}, sharesDataSigned, pubkeyraw, owner, 1)
+		require.NoError(t, err)
+	})
+	t.Run("test reshare by another initiator", func(t *testing.T) {
+		_, pv, err := rsaencryption.GenerateKeys()
+		require.NoError(t, err)
+		priv, err := rsaencryption.ConvertPemToPrivateKey(string(pv))
+		require.NoError(t, err)
+		clnt := initiator.New(priv, ops, logger)
+		id := crypto.NewID()
+		_, err = clnt.StartReshare(context.Background(), id, []uint64{1, 2, 3, 4}, []uint64{4, 5, 6, 7}, validatorPK, 3, owner, 1)
+		require.ErrorContains(t, err, "resharing is allowed only by the initiator who created the validator")
+	})
+	for _, srv := range srvs {
+		srv.HttpSrv.Close()
+	}
+}
+
+func TestThresholdSign(t *testing.T) {
+	if err := logging.SetGlobalLogger("info", "capital", "console", nil); err != nil {
+		panic(err)
+	}
+	logger := zap.L().Named("integration-tests")
+
+	ops := make(map[uint64]initiator.Operator)
+	srvs := make(map[uint64]*operator.TestOperator)
+	for i := uint64(1); i <= 4; i++ {
+		srv := operator.CreateTestOperator(t, i)
+		shareStore, err := store.New(t.TempDir(), "password")
+		require.NoError(t, err)
+		srv.Srv.State.Store = shareStore
+		srvs[i] = srv
+		ops[i] = initiator.Operator{Addr: srv.HttpSrv.URL, ID: i, PubKey: &srv.PrivKey.PublicKey}
+	}
+	// Initiator priv key
+	_, pv, err := rsaencryption.GenerateKeys()
+	require.NoError(t, err)
+	priv, err := rsaencryption.ConvertPemToPrivateKey(string(pv))
+	require.NoError(t, err)
+	clnt := initiator.New(priv, ops, logger)
+	withdraw := newEthAddress(t)
+	owner := newEthAddress(t)
+	_, ks, err := clnt.StartDKG(context.Background(), crypto.NewID(), withdraw.Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
+	require.NoError(t, err)
+	validatorPK, err := hex.DecodeString(ks.Payload.PublicKey[2:])
+	require.NoError(t, err)
+	validatorPubKey := &bls.PublicKey{}
+	require.NoError(t, validatorPubKey.Deserialize(validatorPK))
+	root := eth_crypto.Keccak256([]byte("voluntary exit"))
+
+	t.Run("test threshold sign", func(t *testing.T) {
+		sig, err := clnt.ThresholdSign(context.Background(), crypto.NewID(), ks, 3, root)
+		require.NoError(t, err)
+		require.True(t, sig.VerifyByte(validatorPubKey, root))
+	})
+	t.Run("test threshold sign by another initiator", func(t *testing.T) {
+		_, pv, err := rsaencryption.GenerateKeys()
+		require.NoError(t, err)
+		priv, err := rsaencryption.ConvertPemToPrivateKey(string(pv))
+		require.NoError(t, err)
+		clnt := initiator.New(priv, ops, logger)
+		_, err = clnt.ThresholdSign(context.Background(), crypto.NewID(), ks, 3, root)
+		require.ErrorContains(t, err, "initiator signature isn't valid")
+	})
+	t.Run("test threshold sign by shares of another operators set", func(t *testing.T) {
+		_, err := clnt.ThresholdSign(context.Background(), crypto.NewID(), ks, 2, root)
+		require.ErrorContains(t, err, "no stored share of validator")
+	})
+	t.Run("test threshold sign by an initiator not allowed by the policy", func(t *testing.T) {
+		_, pv, err := rsaencryption.GenerateKeys()
+		require.NoError(t, err)
+		otherInitiator, err := rsaencryption.ConvertPemToPrivateKey(string(pv))
+		require.NoError(t, err)
+		encOtherInitiator, err := crypto.EncodePublicKey(&otherInitiator.PublicKey)
+		require.NoError(t, err)
+		policy, err := operator.NewAccessPolicy(operator.AccessPolicyConfig{Initiators: []string{string(encOtherInitiator)}})
+		require.NoError(t, err)
+		for _, srv := range srvs {
+			srv.Srv.State.AccessPolicy = policy
+		}
+		defer func() {
+			for _, srv := range srvs {
+				srv.Srv.State.AccessPolicy = nil
+			}
+		}()
+		_, err = clnt.ThresholdSign(context.Background(), crypto.NewID(), ks, 3, root)
+		require.ErrorContains(t, err, "is not allowed by the operator policy")
+	})
+	t.Run("test threshold sign with a partial signature of another key", func(t *testing.T) {
+		share, err := srvs[1].Srv.State.Store.Find(validatorPK, []uint64{1, 2, 3, 4}, 3)
+		require.NoError(t, err)
+		secretKey := share.SecretKey
+		defer func() { share.SecretKey = secretKey }()
+		// operator 1 signs with a key of its own choosing and returns its public key as the share public key
+		otherKey := &bls.SecretKey{}
+		otherKey.SetByCSPRNG()
+		share.SecretKey = otherKey
+		sig, err := clnt.ThresholdSign(context.Background(), crypto.NewID(), ks, 3, root)
+		require.NoError(t, err)
+		require.True(t, sig.VerifyByte(validatorPubKey, root))
+	})
+	t.Run("test expired threshold sign request", func(t *testing.T) {
+		created := time.Now().Add(-time.Hour)
+		timestamp, expiry := wire.InitTime(created, wire.DefaultInitTTL)
+		keySign := &wire.KeySign{
+			ValidatorPK: validatorPK,
+			SigningRoot: root,
+			Operators:   []uint64{1, 2, 3, 4},
+			T:           3,
+			Timestamp:   timestamp,
+			Expiry:      expiry,
+		}
+		data, err := keySign.MarshalSSZ()
+		require.NoError(t, err)
+		signMsg := &wire.Transport{
+			Version:    wire.ProtocolVersion,
+			Type:       wire.BlsSignRequestType,
+			Identifier: crypto.NewID(),
+			Data:       data,
+		}
+		signMsgBts, err := signMsg.MarshalSSZ()
+		require.NoError(t, err)
+		sig, err := crypto.SignRSA(priv, signMsgBts)
+		require.NoError(t, err)
+		_, err = srvs[1].Srv.State.ProcessSignRequest(signMsg, sig)
+		require.ErrorIs(t, err, wire.ErrInitExpired)
+	})
+	t.Run("test threshold sign with an operator offline", func(t *testing.T) {
+		srvs[4].HttpSrv.Close()
+		sig, err := clnt.ThresholdSign(context.Background(), crypto.NewID(), ks, 3, root)
+		require.NoError(t, err)
+		require.True(t, sig.VerifyByte(validatorPubKey, root))
+	})
+	for _, srv := range srvs {
+		srv.HttpSrv.Close()
+	}
+}
+
+func TestBulkDKG(t *testing.T) {
+	if err := logging.SetGlobalLogger("info", "capital", "console", nil); err != nil {
+		panic(err)
+	}
+	logger := zap.L().Named("integration-tests")
+	ops := make(map[uint64]initiator.Operator)
+	srvs := make(map[uint64]*operator.TestOperator)
+	for i := uint64(1); i <= 4; i++ {
+		srv := operator.CreateTestOperator(t, i)
+		srvs[i] = srv
+		ops[i] = initiator.Operator{Addr: srv.HttpSrv.URL, ID: i, PubKey: &srv.PrivKey.PublicKey}
+	}
+	// Initiator priv key
+	_, pv, err := rsaencryption.GenerateKeys()
+	require.NoError(t, err)
+	priv, err := rsaencryption.ConvertPemToPrivateKey(string(pv))
+	require.NoError(t, err)
+	clnt := initiator.New(priv, ops, logger)
+	withdraw := newEthAddress(t)
+	owner := newEthAddress(t)
+	t.Run("test 3 validators in batches of 2", func(t *testing.T) {
+		results, err := clnt.StartBulkDKG(context.Background(), withdraw.Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 5, 3, 2)
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		bulk := initiator.GenerateBulkKeyShares(results)
+		require.Len(t, bulk.Shares, 3)
+		for i, res := range results {
+			nonce := uint64(5 + i)
+			require.Equal(t, nonce, res.Nonce)
+			require.Equal(t, res.KeyShares.Payload, bulk.Shares[i].Payload)
+			sharesDataSigned, err := hex.DecodeString(res.KeyShares.Payload.SharesData[2:])
+			require.NoError(t, err)
+			pubkeyraw, err := hex.DecodeString(res.KeyShares.Payload.PublicKey[2:])
+			require.NoError(t, err)
+			require.Equal(t, hex.EncodeToString(pubkeyraw), res.DepositData.PubKey)
+			err = testSharesData(ops, 4, []*rsa.PrivateKey{srvs[1].PrivKey, srvs[2].PrivKey, srvs[3].PrivKey, srvs[4].PrivKey}, sharesDataSigned, pubkeyraw, owner, uint16(nonce))
+			require.NoError(t, err)
+			testDepositData(t, res.DepositData, withdraw.Bytes(), owner, uint16(nonce))
+		}
+	})
+	t.Run("test batch larger than the init rate limit", func(t *testing.T) {
+		_, err := clnt.StartBulkDKG(context.Background(), withdraw.Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 10, 6, 6)
+		require.ErrorContains(t, err, "max 5 ceremonies fit in a batch")
+	})
+	t.Run("test 12 validators in batches of 5", func(t *testing.T) {
+		results, err := clnt.StartBulkDKG(context.Background(), withdraw.Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 10, 12, 5)
+		require.NoError(t, err)
+		require.Len(t, results, 12)
+		for i, res := range results {
+			require.Equal(t, uint64(10+i), res.Nonce)
+			testDepositData(t, res.DepositData, withdraw.Bytes(), owner, uint16(res.Nonce))
+		}
+	})
+	t.Run("test bulk fails if an operator is offline", func(t *testing.T) {
+		srvs[4].HttpSrv.Close()
+		_, err := clnt.StartBulkDKG(context.Background(), withdraw.Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0, 2, 2)
+		require.Error(t, err)
+	})
+	for _, srv := range srvs {
+		srv.HttpSrv.Close()
+	}
+}
+
+func TestCompoundingDeposit(t *testing.T) {
+	if err := logging.SetGlobalLogger("info", "capital", "console", nil); err != nil {
+		panic(err)
+	}
+	logger := zap.L().Named("integration-tests")
+	ops := make(map[uint64]initiator.Operator)
+	srvs := make(map[uint64]*operator.TestOperator)
+	for i := uint64(1); i <= 4; i++ {
+		srv := operator.CreateTestOperator(t, i)
+		srvs[i] = srv
+		ops[i] = initiator.Operator{Addr: srv.HttpSrv.URL, ID: i, PubKey: &srv.PrivKey.PublicKey}
+	}
+	// Initiator priv key
+	_, pv, err := rsaencryption.GenerateKeys()
+	require.NoError(t, err)
+	priv, err := rsaencryption.ConvertPemToPrivateKey(string(pv))
+	require.NoError(t, err)
+	clnt := initiator.New(priv, ops, logger)
+	withdraw := newEthAddress(t)
+	owner := newEthAddress(t)
+	t.Run("test 64 ETH deposit with compounding credentials", func(t *testing.T) {
+		clnt.DepositAmount = 64000000000
+		clnt.WithdrawalPrefix = crypto.CompoundingWithdrawalPrefixByte
+		depositData, _, err := clnt.StartDKG(context.Background(), crypto.NewID(), withdraw.Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0x01, 0x01, 0x70, 0x00}, "holesky", owner, 0)
+		require.NoError(t, err)
+		require.Equal(t, clnt.DepositAmount, depositData.Amount)
+		withdrawalCredentials := hexutil.MustDecode("0x" + depositData.WithdrawalCredentials)
+		require.Equal(t, crypto.CompoundingWithdrawalCredentialsHash(withdraw.Bytes()), withdrawalCredentials)
+		masterSig := &bls.Sign{}
+		require.NoError(t, masterSig.DeserializeHexStr(depositData.Signature))
+		validatorPubKey := &bls.PublicKey{}
+		require.NoError(t, validatorPubKey.DeserializeHexStr(depositData.PubKey))
+		signedDepositData, root, err := crypto.DepositData(masterSig.Serialize(), withdrawalCredentials, validatorPubKey.Serialize(), network.Holesky, depositData.Amount)
+		require.NoError(t, err)
+		require.Equal(t, hex.EncodeToString(root[:]), depositData.DepositDataRoot)
+		valid, err := crypto.VerifyDepositData(signedDepositData, network.Holesky)
+		require.NoError(t, err)
+		require.True(t, valid)
+	})
+	t.Run("test amount over 32 ETH requires compounding credentials", func(t *testing.T) {
+		clnt.DepositAmount = 64000000000
+		clnt.WithdrawalPrefix = crypto.ETH1WithdrawalPrefixByte
+		_, _, err := clnt.StartDKG(context.Background(), crypto.NewID(), withdraw.Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0x01, 0x01, 0x70, 0x00}, "holesky", owner, 0)
+		require.ErrorContains(t, err, "deposit amount 64000000000 should be between")
+	})
+	for _, srv := range srvs {
+		srv.HttpSrv.Close()
+	}
+}
+
 func testSharesData(ops map[uint64]initiator.Operator, operatorCount int, keys []*rsa.PrivateKey, sharesData []byte, validatorPublicKey []byte, owner common.Address, nonce uint16) error {
 	signatureOffset := phase0.SignatureLength
 	pubKeysOffset := phase0.PublicKeyLength*operatorCount + signatureOffset
@@ -518,13 +2018,15 @@ func testDepositData(t *testing.T, depsitDataJson *initiator.DepositDataJson, wi
 	// Check root
 	var fork [4]byte
 	copy(fork[:], hexutil.MustDecode("0x"+depsitDataJson.ForkVersion))
-	depositDataRoot, err := ourcrypto.DepositDataRoot(withdrawCred, valdatorPubKey, dkg.GetNetworkByFork(fork), initiator.MaxEffectiveBalanceInGwei)
+	n, err := network.ByFork(fork)
+	require.NoError(t, err)
+	depositDataRoot, err := ourcrypto.DepositDataRoot(ourcrypto.ETH1WithdrawalCredentialsHash(withdrawCred), valdatorPubKey, n, initiator.MaxEffectiveBalanceInGwei)
 	require.NoError(t, err)
 	res := masterSig.VerifyByte(valdatorPubKey, depositDataRoot[:])
 	require.True(t, res)
-	depositData, _, err := ourcrypto.DepositData(masterSig.Serialize(), withdrawCred, valdatorPubKey.Serialize(), dkg.GetNetworkByFork(fork), initiator.MaxEffectiveBalanceInGwei)
+	depositData, _, err := ourcrypto.DepositData(masterSig.Serialize(), ourcrypto.ETH1WithdrawalCredentialsHash(withdrawCred), valdatorPubKey.Serialize(), n, initiator.MaxEffectiveBalanceInGwei)
 	require.NoError(t, err)
-	res, err = ourcrypto.VerifyDepositData(depositData, dkg.GetNetworkByFork(fork))
+	res, err = ourcrypto.VerifyDepositData(depositData, n)
 	require.NoError(t, err)
 	require.True(t, res)
 	depositMsg := &phase0.DepositMessage{
@@ -2,3 +2,9 @@ package consts
 
 const API_INIT_URL = "init"
 const API_DKG_URL = "dkg"
+const API_INIT_BATCH_URL = "init/batch"
+const API_DKG_BATCH_URL = "dkg/batch"
+const API_SIGN_URL = "sign"
+const API_CANCEL_URL = "cancel"
+const API_HEALTH_URL = "health"
+const API_IDENTITY_URL = "identity"
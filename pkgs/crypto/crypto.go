@@ -17,7 +17,6 @@ import (
 	"strings"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
-	eth2_key_manager_core "github.com/bloxapp/eth2-key-manager/core"
 	"github.com/drand/kyber/share"
 	"github.com/drand/kyber/share/dkg"
 	"github.com/ethereum/go-ethereum/common"
@@ -28,12 +27,25 @@ import (
 	types "github.com/wealdtech/go-eth2-types/v2"
 	util "github.com/wealdtech/go-eth2-util"
 	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/network"
 )
 
 const (
 	// BLSWithdrawalPrefixByte is the BLS withdrawal prefix
 	BLSWithdrawalPrefixByte  = byte(0)
 	ETH1WithdrawalPrefixByte = byte(1)
+	// CompoundingWithdrawalPrefixByte is the prefix of compounding withdrawal credentials introduced at Pectra
+	CompoundingWithdrawalPrefixByte = byte(2)
+)
+
+const (
+	// MinDepositAmountInGwei is the min amount of a deposit
+	MinDepositAmountInGwei phase0.Gwei = 1000000000
+	// MaxEffectiveBalanceInGwei is the max effective balance of a validator with 0x01 withdrawal credentials
+	MaxEffectiveBalanceInGwei phase0.Gwei = 32000000000
+	// MaxEffectiveBalanceElectraInGwei is the max effective balance of a validator with compounding withdrawal credentials
+	MaxEffectiveBalanceElectraInGwei phase0.Gwei = 2048000000000
 )
 
 func init() {
@@ -118,6 +130,9 @@ func ParseRSAPubkey(pk []byte) (*rsa.PublicKey, error) {
 		return nil, err
 	}
 	pemblock, _ := pem.Decode(operatorKeyByte)
+	if pemblock == nil {
+		return nil, fmt.Errorf("wrong pub key string")
+	}
 	pbkey, err := x509.ParsePKIXPublicKey(pemblock.Bytes)
 	if err != nil {
 		return nil, err
@@ -258,13 +273,9 @@ func RecoverMasterSig(sigDepositShares map[uint64]*bls.Sign) (*bls.Sign, error)
 	return &reconstructedDepositMasterSig, nil
 }
 
-func DepositData(masterSig, withdrawalPubKey, publicKey []byte, network eth2_key_manager_core.Network, amount phase0.Gwei) (*phase0.DepositData, [32]byte, error) {
-	if !IsSupportedDepositNetwork(network) {
-		return nil, [32]byte{}, fmt.Errorf("network %s is not supported", network)
-	}
-
+func DepositData(masterSig, withdrawalCredentials, publicKey []byte, network network.Network, amount phase0.Gwei) (*phase0.DepositData, [32]byte, error) {
 	depositMessage := &phase0.DepositMessage{
-		WithdrawalCredentials: ETH1WithdrawalCredentialsHash(withdrawalPubKey),
+		WithdrawalCredentials: withdrawalCredentials,
 		Amount:                amount,
 	}
 	copy(depositMessage.PublicKey[:], publicKey)
@@ -275,7 +286,7 @@ func DepositData(masterSig, withdrawalPubKey, publicKey []byte, network eth2_key
 	}
 
 	// Compute domain
-	genesisForkVersion := network.GenesisForkVersion()
+	genesisForkVersion := network.GenesisForkVersion
 	domain, err := types.ComputeDomain(types.DomainDeposit, genesisForkVersion[:], types.ZeroGenesisValidatorsRoot)
 	if err != nil {
 		return nil, [32]byte{}, fmt.Errorf("failed to calculate domain: %s", err)
@@ -323,18 +334,44 @@ func ETH1WithdrawalCredentialsHash(withdrawalAddr []byte) []byte {
 	return withdrawalCredentials
 }
 
-// IsSupportedDepositNetwork returns true if the given network is supported
-var IsSupportedDepositNetwork = func(network eth2_key_manager_core.Network) bool {
-	return network == eth2_key_manager_core.PyrmontNetwork || network == eth2_key_manager_core.PraterNetwork || network == eth2_key_manager_core.MainNetwork
+// CompoundingWithdrawalCredentialsHash forms compounding (0x02) withdrawal credentials of an execution layer address
+func CompoundingWithdrawalCredentialsHash(withdrawalAddr []byte) []byte {
+	withdrawalCredentials := make([]byte, 32)
+	copy(withdrawalCredentials[:1], []byte{CompoundingWithdrawalPrefixByte})
+	copy(withdrawalCredentials[12:], withdrawalAddr)
+	return withdrawalCredentials
 }
 
-func DepositDataRoot(withdrawalPubKey []byte, publicKey *bls.PublicKey, network eth2_key_manager_core.Network, amount phase0.Gwei) ([]byte, error) {
-	if !IsSupportedDepositNetwork(network) {
-		return nil, fmt.Errorf("network %s is not supported", network)
+// WithdrawalCredentials forms withdrawal credentials of the given type for an execution layer address
+func WithdrawalCredentials(prefix byte, withdrawalAddr []byte) ([]byte, error) {
+	if len(withdrawalAddr) != 20 {
+		return nil, fmt.Errorf("withdrawal address should be 20 bytes, got %d", len(withdrawalAddr))
 	}
+	switch prefix {
+	case ETH1WithdrawalPrefixByte:
+		return ETH1WithdrawalCredentialsHash(withdrawalAddr), nil
+	case CompoundingWithdrawalPrefixByte:
+		return CompoundingWithdrawalCredentialsHash(withdrawalAddr), nil
+	default:
+		return nil, fmt.Errorf("unsupported withdrawal credentials type 0x%02x", prefix)
+	}
+}
 
+// ValidateDepositAmount checks the deposit amount is allowed for withdrawal credentials of the given type
+func ValidateDepositAmount(prefix byte, amount phase0.Gwei) error {
+	maxAmount := MaxEffectiveBalanceInGwei
+	if prefix == CompoundingWithdrawalPrefixByte {
+		maxAmount = MaxEffectiveBalanceElectraInGwei
+	}
+	if amount < MinDepositAmountInGwei || amount > maxAmount {
+		return fmt.Errorf("deposit amount %d should be between %d and %d Gwei for 0x%02x withdrawal credentials", amount, MinDepositAmountInGwei, maxAmount, prefix)
+	}
+	return nil
+}
+
+func DepositDataRoot(withdrawalCredentials []byte, publicKey *bls.PublicKey, network network.Network, amount phase0.Gwei) ([]byte, error) {
 	depositMessage := &phase0.DepositMessage{
-		WithdrawalCredentials: ETH1WithdrawalCredentialsHash(withdrawalPubKey),
+		WithdrawalCredentials: withdrawalCredentials,
 		Amount:                amount,
 	}
 	copy(depositMessage.PublicKey[:], publicKey.Serialize())
@@ -345,7 +382,7 @@ func DepositDataRoot(withdrawalPubKey []byte, publicKey *bls.PublicKey, network
 	}
 
 	// Compute domain
-	genesisForkVersion := network.GenesisForkVersion()
+	genesisForkVersion := network.GenesisForkVersion
 	domain, err := types.ComputeDomain(types.DomainDeposit, genesisForkVersion[:], types.ZeroGenesisValidatorsRoot)
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate domain: %s", err)
@@ -364,7 +401,7 @@ func DepositDataRoot(withdrawalPubKey []byte, publicKey *bls.PublicKey, network
 	return root[:], nil
 }
 
-func VerifyDepositData(depositData *phase0.DepositData, network eth2_key_manager_core.Network) (bool, error) {
+func VerifyDepositData(depositData *phase0.DepositData, network network.Network) (bool, error) {
 	depositMessage := &phase0.DepositMessage{
 		WithdrawalCredentials: depositData.WithdrawalCredentials,
 		Amount:                depositData.Amount,
@@ -387,7 +424,7 @@ func VerifyDepositData(depositData *phase0.DepositData, network eth2_key_manager
 		ObjectRoot: depositMsgRoot,
 	}
 
-	genesisForkVersion := network.GenesisForkVersion()
+	genesisForkVersion := network.GenesisForkVersion
 	domain, err := types.ComputeDomain(types.DomainDeposit, genesisForkVersion[:], types.ZeroGenesisValidatorsRoot)
 	if err != nil {
 		return false, err
@@ -408,13 +445,9 @@ func VerifyDepositData(depositData *phase0.DepositData, network eth2_key_manager
 	return sig.Verify(signingRoot[:], pubkey), nil
 }
 
-func SignDepositData(validationKey *bls.SecretKey, withdrawalPubKey []byte, validatorPublicKey *bls.PublicKey, network eth2_key_manager_core.Network, amount phase0.Gwei) (*bls.Sign, []byte, error) {
-	if !IsSupportedDepositNetwork(network) {
-		return nil, nil, errors.Errorf("Network %s is not supported", network)
-	}
-
+func SignDepositData(validationKey *bls.SecretKey, withdrawalCredentials []byte, validatorPublicKey *bls.PublicKey, network network.Network, amount phase0.Gwei) (*bls.Sign, []byte, error) {
 	depositMessage := &phase0.DepositMessage{
-		WithdrawalCredentials: ETH1WithdrawalCredentialsHash(withdrawalPubKey),
+		WithdrawalCredentials: withdrawalCredentials,
 		Amount:                amount,
 	}
 	copy(depositMessage.PublicKey[:], validatorPublicKey.Serialize())
@@ -425,7 +458,7 @@ func SignDepositData(validationKey *bls.SecretKey, withdrawalPubKey []byte, vali
 	}
 
 	// Compute domain
-	genesisForkVersion := network.GenesisForkVersion()
+	genesisForkVersion := network.GenesisForkVersion
 	domain, err := types.ComputeDomain(types.DomainDeposit, genesisForkVersion[:], types.ZeroGenesisValidatorsRoot)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "failed to calculate domain")
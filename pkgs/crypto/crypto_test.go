@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/bloxapp/ssv-spec/types"
 	"github.com/drand/kyber"
 	kyber_bls12381 "github.com/drand/kyber-bls12381"
@@ -14,8 +15,11 @@ import (
 	drand_bls "github.com/drand/kyber/sign/bls"
 	"github.com/drand/kyber/sign/tbls"
 	"github.com/drand/kyber/util/random"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/stretchr/testify/require"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/network"
 )
 
 func TestDKGFull(t *testing.T) {
@@ -398,3 +402,53 @@ func (t *TestBoard) IncomingResponse() <-chan dkg.ResponseBundle {
 func (t *TestBoard) IncomingJustification() <-chan dkg.JustificationBundle {
 	return t.newJusts
 }
+
+func TestCompoundingDepositData(t *testing.T) {
+	withdrawalAddr := common.HexToAddress("0x81592c3de184a3e2c0dcb5a261bc107bfa91f494").Bytes()
+	t.Run("withdrawal credentials", func(t *testing.T) {
+		creds, err := WithdrawalCredentials(ETH1WithdrawalPrefixByte, withdrawalAddr)
+		require.NoError(t, err)
+		require.Equal(t, ETH1WithdrawalCredentialsHash(withdrawalAddr), creds)
+		creds, err = WithdrawalCredentials(CompoundingWithdrawalPrefixByte, withdrawalAddr)
+		require.NoError(t, err)
+		require.Equal(t, CompoundingWithdrawalPrefixByte, creds[0])
+		require.Equal(t, make([]byte, 11), creds[1:12])
+		require.Equal(t, withdrawalAddr, creds[12:])
+		_, err = WithdrawalCredentials(BLSWithdrawalPrefixByte, withdrawalAddr)
+		require.ErrorContains(t, err, "unsupported withdrawal credentials type 0x00")
+		_, err = WithdrawalCredentials(CompoundingWithdrawalPrefixByte, withdrawalAddr[1:])
+		require.ErrorContains(t, err, "withdrawal address should be 20 bytes")
+	})
+	t.Run("deposit amount", func(t *testing.T) {
+		require.NoError(t, ValidateDepositAmount(ETH1WithdrawalPrefixByte, MaxEffectiveBalanceInGwei))
+		require.NoError(t, ValidateDepositAmount(ETH1WithdrawalPrefixByte, MinDepositAmountInGwei))
+		require.Error(t, ValidateDepositAmount(ETH1WithdrawalPrefixByte, MaxEffectiveBalanceInGwei+1))
+		require.Error(t, ValidateDepositAmount(ETH1WithdrawalPrefixByte, MinDepositAmountInGwei-1))
+		require.NoError(t, ValidateDepositAmount(CompoundingWithdrawalPrefixByte, MaxEffectiveBalanceElectraInGwei))
+		require.Error(t, ValidateDepositAmount(CompoundingWithdrawalPrefixByte, MaxEffectiveBalanceElectraInGwei+1))
+	})
+	t.Run("sign and verify", func(t *testing.T) {
+		sk := &bls.SecretKey{}
+		sk.SetByCSPRNG()
+		creds, err := WithdrawalCredentials(CompoundingWithdrawalPrefixByte, withdrawalAddr)
+		require.NoError(t, err)
+		amount := phase0.Gwei(64000000000)
+		sig, root, err := SignDepositData(sk, creds, sk.GetPublicKey(), network.Holesky, amount)
+		require.NoError(t, err)
+		expectedRoot, err := DepositDataRoot(creds, sk.GetPublicKey(), network.Holesky, amount)
+		require.NoError(t, err)
+		require.Equal(t, expectedRoot, root)
+		depositData, _, err := DepositData(sig.Serialize(), creds, sk.GetPublicKey().Serialize(), network.Holesky, amount)
+		require.NoError(t, err)
+		require.Equal(t, amount, depositData.Amount)
+		require.Equal(t, creds, depositData.WithdrawalCredentials)
+		valid, err := VerifyDepositData(depositData, network.Holesky)
+		require.NoError(t, err)
+		require.True(t, valid)
+		// the signature doesn't cover a different amount
+		depositData.Amount = MaxEffectiveBalanceInGwei
+		valid, err = VerifyDepositData(depositData, network.Holesky)
+		require.NoError(t, err)
+		require.False(t, valid)
+	})
+}
@@ -0,0 +1,96 @@
+package crypto
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+)
+
+// PinnedCertificateValidity is the validity of a TLS certificate pinned to an operator key
+const PinnedCertificateValidity = 365 * 24 * time.Hour
+
+// PinnedCertificate creates a TLS certificate of a new key issued by the RSA key. The certificate is pinned to the
+// RSA key: an initiator verifies it with the operator public key instead of certificate authorities.
+// The RSA key only signs the certificate, the TLS handshake is signed by the new key.
+func PinnedCertificate(sk *rsa.PrivateKey) (tls.Certificate, error) {
+	tlsKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	now := time.Now()
+	issuer := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: "ssv-dkg operator"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(PinnedCertificateValidity),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	template := &x509.Certificate{
+		SerialNumber:       serial,
+		Subject:            pkix.Name{CommonName: "ssv-dkg operator"},
+		NotBefore:          issuer.NotBefore,
+		NotAfter:           issuer.NotAfter,
+		KeyUsage:           x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:        []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		SignatureAlgorithm: x509.SHA256WithRSA,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, &tlsKey.PublicKey, sk)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: tlsKey}, nil
+}
+
+// VerifyPinnedCertificate returns a verification of TLS certificates checking the certificate is issued by the RSA key
+func VerifyPinnedCertificate(pk *rsa.PublicKey) func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return fmt.Errorf("no TLS certificate")
+		}
+		cert, err := x509.ParseCertificate(rawCerts[0])
+		if err != nil {
+			return err
+		}
+		if cert.SignatureAlgorithm != x509.SHA256WithRSA {
+			return fmt.Errorf("TLS certificate isn't pinned to the operator key: unexpected signature algorithm %s", cert.SignatureAlgorithm)
+		}
+		hash := sha256.Sum256(cert.RawTBSCertificate)
+		if err := rsa.VerifyPKCS1v15(pk, crypto.SHA256, hash[:], cert.Signature); err != nil {
+			return fmt.Errorf("TLS certificate isn't pinned to the operator key: %w", err)
+		}
+		now := time.Now()
+		if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+			return fmt.Errorf("TLS certificate is valid from %s to %s", cert.NotBefore, cert.NotAfter)
+		}
+		return nil
+	}
+}
+
+// LoadCertPool reads PEM encoded certificates of a file
+func LoadCertPool(path string) (*x509.CertPool, error) {
+	pemCerts, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pemCerts) {
+		return nil, fmt.Errorf("no certificates at %s", path)
+	}
+	return pool, nil
+}
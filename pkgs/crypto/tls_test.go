@@ -0,0 +1,42 @@
+package crypto
+
+import (
+	"crypto/rsa"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPinnedCertificate(t *testing.T) {
+	operatorKey, _, err := GenerateKeys()
+	require.NoError(t, err)
+	otherKey, _, err := GenerateKeys()
+	require.NoError(t, err)
+	cert, err := PinnedCertificate(operatorKey)
+	require.NoError(t, err)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	srv.TLS = &tls.Config{Certificates: []tls.Certificate{cert}}
+	srv.StartTLS()
+	defer srv.Close()
+
+	get := func(pk *rsa.PublicKey) error {
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			// the certificate is verified by the pinned key instead of certificate authorities
+			InsecureSkipVerify:    true,
+			VerifyPeerCertificate: VerifyPinnedCertificate(pk),
+		}}}
+		resp, err := client.Get(srv.URL)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+	require.NoError(t, get(&operatorKey.PublicKey))
+	require.ErrorContains(t, get(&otherKey.PublicKey), "TLS certificate isn't pinned to the operator key")
+}
@@ -2,16 +2,20 @@ package dkg
 
 import (
 	"bytes"
+	"context"
 	"crypto/rsa"
-	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
-	eth2_key_manager_core "github.com/bloxapp/eth2-key-manager/core"
 	"github.com/bloxapp/ssv-dkg/pkgs/board"
 	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
-	"github.com/bloxapp/ssv-dkg/pkgs/utils"
+	"github.com/bloxapp/ssv-dkg/pkgs/metrics"
+	"github.com/bloxapp/ssv-dkg/pkgs/network"
+	"github.com/bloxapp/ssv-dkg/pkgs/store"
 	"github.com/bloxapp/ssv-dkg/pkgs/wire"
 	ssvspec_types "github.com/bloxapp/ssv-spec/types"
 	"github.com/drand/kyber"
@@ -22,23 +26,14 @@ import (
 	eth_crypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/herumi/bls-eth-go-binary/bls"
 	"github.com/pkg/errors"
-	"github.com/spf13/viper"
 	"go.uber.org/zap"
 )
 
 const (
-	// MaxEffectiveBalanceInGwei is the max effective balance
-	MaxEffectiveBalanceInGwei phase0.Gwei = 32000000000
-
 	// BLSWithdrawalPrefixByte is the BLS withdrawal prefix
 	BLSWithdrawalPrefixByte = byte(0)
 )
 
-// IsSupportedDepositNetwork returns true if the given network is supported
-var IsSupportedDepositNetwork = func(network eth2_key_manager_core.Network) bool {
-	return network == eth2_key_manager_core.PyrmontNetwork || network == eth2_key_manager_core.PraterNetwork || network == eth2_key_manager_core.MainNetwork
-}
-
 type Operator struct {
 	IP     string
 	ID     uint64
@@ -49,6 +44,11 @@ type DKGData struct {
 	ReqID  [24]byte
 	init   *wire.Init
 	Secret kyber.Scalar
+
+	// reshare is set when the instance reshares an existing validator key
+	reshare *wire.Reshare
+	// share is the current key share of an old operator when resharing
+	share *dkg.DistKeyShare
 }
 
 // Result is the last message in every DKG which marks a specific node's end of process
@@ -69,6 +69,8 @@ type Result struct {
 	DepositPartialSignature []byte
 	// SSV owner + nonce signature
 	OwnerNoncePartialSignature []byte
+	// Proof of the operator that it created the share, nil for operators which don't receive a share when resharing
+	Proof *wire.SignedProof
 }
 
 // Encode returns a msg encoded bytes or error
@@ -83,6 +85,9 @@ func (msg *Result) Decode(data []byte) error {
 
 var ErrAlreadyExists = errors.New("duplicate message")
 
+// ErrCancelled is returned to messages of a ceremony cancelled by the initiator or torn down by the operator
+var ErrCancelled = errors.New("ceremony cancelled")
+
 type LocalOwner struct {
 	Logger      *zap.Logger
 	startedDKG  chan struct{}
@@ -102,40 +107,87 @@ type LocalOwner struct {
 	Owner       common.Address
 	Nonce       uint64
 	done        chan struct{}
+
+	// ReshareExchanges are the exchange messages of all operators participating in a resharing
+	ReshareExchanges map[uint64]*wire.ReshareExchange
+	// Store keeps the created shares, shares aren't stored if nil
+	Store *store.Store
+	// Phaser moves the DKG protocol between phases
+	Phaser wire.PhaserType
+	// PhaseTimeout is the max duration of a DKG phase, wire.DefaultPhaseTimeout if not set
+	PhaseTimeout time.Duration
+	// Version of the wire protocol of the ceremony, set on broadcasted messages and required from incoming ones
+	Version uint64
+
+	statusMtx  sync.Mutex
+	status     Status
+	phaseStart time.Time
+
+	// ctx is done when the ceremony is cancelled, the lock guards starting the protocol against cancelling
+	ctx       context.Context
+	cancel    context.CancelFunc
+	cancelled bool
+	cancelMtx sync.Mutex
 }
 
 type OwnerOpts struct {
-	Logger      *zap.Logger
-	ID          uint64
-	BroadcastF  func([]byte) error
-	Suite       pairing.Suite
-	VerifyFunc  func(id uint64, msg, sig []byte) error
-	SignFunc    func([]byte) ([]byte, error)
-	EncryptFunc func([]byte) ([]byte, error)
-	DecryptFunc func([]byte) ([]byte, error)
-	RSAPub      *rsa.PublicKey
-	Owner       [20]byte
-	Nonce       uint64
+	Logger       *zap.Logger
+	ID           uint64
+	BroadcastF   func([]byte) error
+	Suite        pairing.Suite
+	VerifyFunc   func(id uint64, msg, sig []byte) error
+	SignFunc     func([]byte) ([]byte, error)
+	EncryptFunc  func([]byte) ([]byte, error)
+	DecryptFunc  func([]byte) ([]byte, error)
+	RSAPub       *rsa.PublicKey
+	Owner        [20]byte
+	Nonce        uint64
+	Store        *store.Store
+	Phaser       wire.PhaserType
+	PhaseTimeout time.Duration
+	// Version of the wire protocol negotiated by the initiator
+	Version uint64
+	// Ctx cancels the ceremony when it's done, context.Background() if not set
+	Ctx context.Context
 }
 
 func New(opts OwnerOpts) *LocalOwner {
-	owner := &LocalOwner{
-		Logger:      opts.Logger,
-		startedDKG:  make(chan struct{}, 1),
-		ErrorChan:   make(chan error, 1),
-		ID:          opts.ID,
-		BroadcastF:  opts.BroadcastF,
-		Exchanges:   make(map[uint64]*wire.Exchange),
-		SignFunc:    opts.SignFunc,
-		VerifyFunc:  opts.VerifyFunc,
-		EncryptFunc: opts.EncryptFunc,
-		DecryptFunc: opts.DecryptFunc,
-		RSAPub:      opts.RSAPub,
-		done:        make(chan struct{}, 1),
-		suite:       opts.Suite,
-		Owner:       opts.Owner,
-		Nonce:       opts.Nonce,
+	parent := opts.Ctx
+	if parent == nil {
+		parent = context.Background()
 	}
+	ctx, cancel := context.WithCancel(parent)
+	owner := &LocalOwner{
+		Logger:           opts.Logger,
+		startedDKG:       make(chan struct{}, 1),
+		ErrorChan:        make(chan error, 1),
+		ID:               opts.ID,
+		BroadcastF:       opts.BroadcastF,
+		Exchanges:        make(map[uint64]*wire.Exchange),
+		ReshareExchanges: make(map[uint64]*wire.ReshareExchange),
+		SignFunc:         opts.SignFunc,
+		VerifyFunc:       opts.VerifyFunc,
+		EncryptFunc:      opts.EncryptFunc,
+		DecryptFunc:      opts.DecryptFunc,
+		RSAPub:           opts.RSAPub,
+		done:             make(chan struct{}, 1),
+		suite:            opts.Suite,
+		Owner:            opts.Owner,
+		Nonce:            opts.Nonce,
+		Store:            opts.Store,
+		Phaser:           opts.Phaser,
+		PhaseTimeout:     opts.PhaseTimeout,
+		Version:          opts.Version,
+		status:           Status{Phase: PhaseExchange},
+		phaseStart:       time.Now(),
+		ctx:              ctx,
+		cancel:           cancel,
+	}
+	// the ceremony is torn down when the parent context is done, cancelling it ends the goroutine
+	go func() {
+		<-ctx.Done()
+		owner.Cancel()
+	}()
 	return owner
 }
 
@@ -158,29 +210,142 @@ func (o *LocalOwner) StartDKG() error {
 		o.Logger.Debug("node: ", zap.String("nodes", n.Public.String()))
 	}
 	// New protocol
-	p, err := wire.NewDKGProtocol(&wire.Config{
-		Identifier: o.data.ReqID[:],
-		Secret:     o.data.Secret,
-		Nodes:      nodes,
-		Suite:      o.suite,
-		T:          int(o.data.init.T),
-		Board:      o.b,
+	return o.startProtocol(&wire.Config{
+		Identifier:   o.data.ReqID[:],
+		Secret:       o.data.Secret,
+		Nodes:        nodes,
+		Suite:        o.suite,
+		T:            int(o.data.init.T),
+		Board:        o.b,
+		Phaser:       o.Phaser,
+		PhaseTimeout: o.PhaseTimeout,
+		PhaseF:       o.setPhase,
 
 		Logger: o.Logger,
 	})
+}
+
+// startProtocol starts the kyber protocol and creates the result of the operator when it ends.
+// The result of a cancelled ceremony isn't created, its secrets are dropped instead.
+func (o *LocalOwner) startProtocol(config *wire.Config) error {
+	o.cancelMtx.Lock()
+	defer o.cancelMtx.Unlock()
+	if o.isCancelled() {
+		return ErrCancelled
+	}
+	config.Stop = o.ctx.Done()
+	p, err := wire.NewDKGProtocol(config)
 	if err != nil {
 		return err
 	}
 
 	go func(p *dkg.Protocol, postF func(res *dkg.OptionResult) error) {
 		res := <-p.WaitEnd()
+		if o.isCancelled() {
+			o.dropSecrets()
+			return
+		}
 		postF(&res)
 	}(p, o.PostDKG)
 	close(o.startedDKG)
 	return nil
 }
 
+// Cancel stops the ceremony: the phases of the protocol end right away, messages waiting for the protocol
+// are released and the secrets of the ceremony are dropped when the protocol ends
+func (o *LocalOwner) Cancel() {
+	o.cancelMtx.Lock()
+	defer o.cancelMtx.Unlock()
+	if o.cancelled {
+		return
+	}
+	o.cancelled = true
+	o.cancel()
+	// the protocol goroutine drops the secrets when it ends, they are in use until then
+	if !o.dkgStarted() {
+		o.dropSecrets()
+	}
+	o.Logger.Info("🛑 ceremony cancelled")
+}
+
+// Cancelled is closed when the ceremony is cancelled
+func (o *LocalOwner) Cancelled() <-chan struct{} {
+	return o.ctx.Done()
+}
+
+func (o *LocalOwner) isCancelled() bool {
+	return o.ctx.Err() != nil
+}
+
+// dropSecrets zeroes the session key and forgets the key shares of the ceremony
+func (o *LocalOwner) dropSecrets() {
+	if o.data != nil {
+		if o.data.Secret != nil {
+			o.data.Secret.Zero()
+			o.data.Secret = nil
+		}
+		o.data.share = nil
+	}
+	o.SecretShare = nil
+}
+
+// StartWithExchanges starts the DKG with the operators whose exchange messages were received, unless it started
+// when all operators exchanged. Operators are missing only if the initiator opted in to tolerate offline operators
+// at the init message, the threshold of the key shares should still be met.
+func (o *LocalOwner) StartWithExchanges() (err error) {
+	defer func() {
+		if err != nil {
+			o.setError(metrics.ReasonMessage, err)
+		}
+	}()
+	if o.data == nil || o.data.init == nil || o.dkgStarted() {
+		return nil
+	}
+	if !o.data.init.TolerateOffline {
+		return fmt.Errorf("exchange messages of %d of %d operators, the initiator doesn't tolerate offline operators", len(o.Exchanges), len(o.data.init.Operators))
+	}
+	if _, ok := o.Exchanges[o.ID]; !ok {
+		return fmt.Errorf("exchange message of operator %d is missing", o.ID)
+	}
+	if uint64(len(o.Exchanges)) < o.data.init.T {
+		return fmt.Errorf("exchange messages of %d operators don't meet threshold %d", len(o.Exchanges), o.data.init.T)
+	}
+	o.Logger.Warn("⚠️ starting DKG without offline operators", zap.Uint64s("missing", o.missingOperators()))
+	return o.StartDKG()
+}
+
+func (o *LocalOwner) dkgStarted() bool {
+	select {
+	case <-o.startedDKG:
+		return true
+	default:
+		return false
+	}
+}
+
+// participants returns IDs of the operators taking part in the DKG, sorted
+func (o *LocalOwner) participants() []uint64 {
+	ids := make([]uint64, 0, len(o.Exchanges))
+	for id := range o.Exchanges {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// missingOperators returns IDs of the operators of the init message which didn't exchange
+func (o *LocalOwner) missingOperators() []uint64 {
+	var missing []uint64
+	for _, op := range o.data.init.Operators {
+		if _, ok := o.Exchanges[op.ID]; !ok {
+			missing = append(missing, op.ID)
+		}
+	}
+	return missing
+}
+
 func (o *LocalOwner) Broadcast(ts *wire.Transport) error {
+	ts.Version = o.Version
 	bts, err := ts.MarshalSSZ()
 	if err != nil {
 		return err
@@ -206,6 +371,9 @@ func (o *LocalOwner) Broadcast(ts *wire.Transport) error {
 }
 
 func (o *LocalOwner) PostDKG(res *dkg.OptionResult) error {
+	if o.data.reshare != nil && !containsOperator(o.data.reshare.NewOperators, o.ID) {
+		return o.postReshareLeave(res)
+	}
 	if res.Error != nil {
 		o.Logger.Error("DKG ceremony returned error: ", zap.Error(res.Error))
 		o.broadcastError(res.Error)
@@ -222,6 +390,11 @@ func (o *LocalOwner) PostDKG(res *dkg.OptionResult) error {
 		return err
 	}
 	o.Logger.Debug("Validator`s public key %x", zap.String("key", fmt.Sprintf("%x", validatorPubKey.Serialize())))
+	if o.data.reshare != nil && !bytes.Equal(validatorPubKey.Serialize(), o.data.reshare.ValidatorPubKey) {
+		err := fmt.Errorf("resharing resulted in a different validator public key %x", validatorPubKey.Serialize())
+		o.broadcastError(err)
+		return err
+	}
 
 	// Get BLS partial secret key share from DKG
 	secretKeyBLS, err := crypto.ResultToShareSecretKey(res.Result)
@@ -229,19 +402,15 @@ func (o *LocalOwner) PostDKG(res *dkg.OptionResult) error {
 		o.broadcastError(err)
 		return err
 	}
-	// Store secret if requested
-	if viper.GetBool("storeShare") {
-		type shareStorage struct {
-			Index  int    `json:"index"`
-			Secret string `json:"secret"`
+	// Store secret if the operator keeps its shares
+	if o.Store != nil {
+		if o.data.reshare != nil {
+			err = o.storeShare(res.Result.Key, secretKeyBLS, validatorPubKey.Serialize(), operatorIDs(o.data.reshare.NewOperators), o.data.reshare.NewT, o.data.reshare.InitiatorPublicKey)
+		} else {
+			err = o.storeShare(res.Result.Key, secretKeyBLS, validatorPubKey.Serialize(), o.participants(), o.data.init.T, o.data.init.InitiatorPublicKey)
 		}
-		data := shareStorage{
-			Index:  res.Result.Key.Share.I,
-			Secret: secretKeyBLS.SerializeToHexStr(),
-		}
-		err = utils.WriteJSON("./secret_share_"+hex.EncodeToString(o.data.ReqID[:]), &data)
 		if err != nil {
-			o.Logger.Error("Cant write secret share to file: ", zap.Error(err))
+			o.Logger.Error("Cant store secret share: ", zap.Error(err))
 			o.broadcastError(err)
 			return err
 		}
@@ -272,18 +441,16 @@ func (o *LocalOwner) PostDKG(res *dkg.OptionResult) error {
 	}
 
 	o.Logger.Debug("Encrypted share", zap.String("share", fmt.Sprintf("%x", ciphertext)))
-	o.Logger.Debug("Withdrawal Credentials", zap.String("creds", fmt.Sprintf("%x", o.data.init.WithdrawalCredentials)))
-	o.Logger.Debug("Fork Version", zap.String("v", fmt.Sprintf("%x", o.data.init.Fork[:])))
-	o.Logger.Debug("Domain", zap.String("bytes", fmt.Sprintf("%x", ssvspec_types.DomainDeposit[:])))
 
-	// Sign root
-	depositRootSig, signRoot, err := crypto.SignDepositData(secretKeyBLS, o.data.init.WithdrawalCredentials[:], validatorPubKey, GetNetworkByFork(o.data.init.Fork), MaxEffectiveBalanceInGwei)
-	o.Logger.Debug("Root", zap.String("", fmt.Sprintf("%x", signRoot)))
-	// Validate partial signature
-	val := depositRootSig.VerifyByte(secretKeyBLS.GetPublicKey(), signRoot)
-	if !val {
-		o.broadcastError(err)
-		return fmt.Errorf("partial deposit root signature is not valid %x", depositRootSig.Serialize())
+	// The validator is already deposited when resharing, no deposit signature is needed
+	var depositSig []byte
+	if o.data.reshare == nil {
+		depositRootSig, err := o.signDepositData(secretKeyBLS, validatorPubKey)
+		if err != nil {
+			o.broadcastError(err)
+			return err
+		}
+		depositSig = depositRootSig.Serialize()
 	}
 	// Sign SSV owner + nonce
 	data := []byte(fmt.Sprintf("%s:%d", o.Owner.String(), o.Nonce))
@@ -296,7 +463,7 @@ func (o *LocalOwner) PostDKG(res *dkg.OptionResult) error {
 		return err
 	}
 	// Verify partial SSV owner + nonce signature
-	val = sigOwnerNonce.VerifyByte(secretKeyBLS.GetPublicKey(), hash)
+	val := sigOwnerNonce.VerifyByte(secretKeyBLS.GetPublicKey(), hash)
 	if !val {
 		o.broadcastError(err)
 		return fmt.Errorf("partial owner + nonce signature isnt valid %x", sigOwnerNonce.Serialize())
@@ -306,11 +473,16 @@ func (o *LocalOwner) PostDKG(res *dkg.OptionResult) error {
 		EncryptedShare:             ciphertext,
 		SharePubKey:                secretKeyBLS.GetPublicKey().Serialize(),
 		ValidatorPubKey:            validatorPubKey.Serialize(),
-		DepositPartialSignature:    depositRootSig.Serialize(),
+		DepositPartialSignature:    depositSig,
 		PubKeyRSA:                  o.RSAPub,
 		OperatorID:                 o.ID,
 		OwnerNoncePartialSignature: sigOwnerNonce.Serialize(),
 	}
+	out.Proof, err = o.signProof(out.SharePubKey, out.ValidatorPubKey)
+	if err != nil {
+		o.broadcastError(err)
+		return err
+	}
 
 	encodedOutput, err := out.Encode()
 	if err != nil {
@@ -324,19 +496,83 @@ func (o *LocalOwner) PostDKG(res *dkg.OptionResult) error {
 		Data:       encodedOutput,
 	}
 
+	o.setResult(out.ValidatorPubKey)
 	o.Broadcast(tsMsg)
 	close(o.done)
 	return nil
 }
 
+// signProof signs a proof that the operator created the share of the validator at the ceremony
+func (o *LocalOwner) signProof(sharePubKey, validatorPubKey []byte) (*wire.SignedProof, error) {
+	proof := &wire.Proof{
+		OperatorID:      o.ID,
+		SharePubKey:     sharePubKey,
+		ValidatorPubKey: validatorPubKey,
+		Owner:           o.Owner,
+		Nonce:           o.Nonce,
+		RequestID:       o.data.ReqID,
+	}
+	byts, err := proof.MarshalSSZ()
+	if err != nil {
+		return nil, err
+	}
+	sig, err := o.SignFunc(byts)
+	if err != nil {
+		return nil, err
+	}
+	return &wire.SignedProof{Proof: proof, Signature: sig}, nil
+}
+
+func (o *LocalOwner) signDepositData(secretKeyBLS *bls.SecretKey, validatorPubKey *bls.PublicKey) (*bls.Sign, error) {
+	o.Logger.Debug("Withdrawal Credentials", zap.String("creds", fmt.Sprintf("%x", o.data.init.WithdrawalCredentials)))
+	o.Logger.Debug("Fork Version", zap.String("v", fmt.Sprintf("%x", o.data.init.Fork[:])))
+	o.Logger.Debug("Domain", zap.String("bytes", fmt.Sprintf("%x", ssvspec_types.DomainDeposit[:])))
+
+	n, err := network.ByFork(o.data.init.Fork)
+	if err != nil {
+		return nil, err
+	}
+	amount := phase0.Gwei(o.data.init.Amount)
+	if err := crypto.ValidateDepositAmount(o.data.init.WithdrawalPrefix, amount); err != nil {
+		return nil, err
+	}
+	withdrawalCredentials, err := crypto.WithdrawalCredentials(o.data.init.WithdrawalPrefix, o.data.init.WithdrawalCredentials)
+	if err != nil {
+		return nil, err
+	}
+	// Sign root
+	depositRootSig, signRoot, err := crypto.SignDepositData(secretKeyBLS, withdrawalCredentials, validatorPubKey, n, amount)
+	if err != nil {
+		return nil, err
+	}
+	o.Logger.Debug("Root", zap.String("", fmt.Sprintf("%x", signRoot)))
+	// Validate partial signature
+	if !depositRootSig.VerifyByte(secretKeyBLS.GetPublicKey(), signRoot) {
+		return nil, fmt.Errorf("partial deposit root signature is not valid %x", depositRootSig.Serialize())
+	}
+	return depositRootSig, nil
+}
+
 func (o *LocalOwner) Init(reqID [24]byte, init *wire.Init) (*wire.Transport, error) {
 	if o.data == nil {
 		o.data = &DKGData{}
 	}
 	o.data.init = init
 	o.data.ReqID = reqID
+	o.b = o.newBoard()
+
+	eciesSK, pk := InitSecret(o.suite)
+	o.data.Secret = eciesSK
+	bts, _, err := CreateExchange(pk)
+	if err != nil {
+		return nil, err
+	}
+	return ExchangeWireMessage(bts, reqID), nil
+}
+
+func (o *LocalOwner) newBoard() *board.Board {
 	kyberLogger := o.Logger.With(zap.String("reqid", fmt.Sprintf("%x", o.data.ReqID[:])))
-	o.b = board.NewBoard(
+	return board.NewBoard(
 		kyberLogger,
 		func(msg *wire.KyberMessage) error {
 			kyberLogger.Debug("server: broadcasting kyber message")
@@ -361,14 +597,6 @@ func (o *LocalOwner) Init(reqID [24]byte, init *wire.Init) (*wire.Transport, err
 			return nil
 		},
 	)
-
-	eciesSK, pk := InitSecret(o.suite)
-	o.data.Secret = eciesSK
-	bts, _, err := CreateExchange(pk)
-	if err != nil {
-		return nil, err
-	}
-	return ExchangeWireMessage(bts, reqID), nil
 }
 
 func (o *LocalOwner) processDKG(from uint64, msg *wire.Transport) error {
@@ -386,7 +614,11 @@ func (o *LocalOwner) processDKG(from uint64, msg *wire.Transport) error {
 			return err
 		}
 		o.Logger.Debug("operator: received deal bundle from", zap.Uint64("ID", from))
-		o.b.DealC <- *b
+		select {
+		case o.b.DealC <- *b:
+		case <-o.ctx.Done():
+			return ErrCancelled
+		}
 	case wire.KyberResponseBundleMessageType:
 
 		b, err := wire.DecodeResponseBundle(kyberMsg.Data)
@@ -394,33 +626,55 @@ func (o *LocalOwner) processDKG(from uint64, msg *wire.Transport) error {
 			return err
 		}
 		o.Logger.Debug("operator: received response bundle from", zap.Uint64("ID", from))
-		o.b.ResponseC <- *b
+		select {
+		case o.b.ResponseC <- *b:
+		case <-o.ctx.Done():
+			return ErrCancelled
+		}
 	case wire.KyberJustificationBundleMessageType:
 		b, err := wire.DecodeJustificationBundle(kyberMsg.Data, o.suite.G1().(dkg.Suite))
 		if err != nil {
 			return err
 		}
 		o.Logger.Debug("operator: received justification bundle from", zap.Uint64("ID", from))
-		o.b.JustificationC <- *b
+		select {
+		case o.b.JustificationC <- *b:
+		case <-o.ctx.Done():
+			return ErrCancelled
+		}
 	default:
 		return fmt.Errorf("unknown kyber message type")
 	}
 	return nil
 }
 
-func (o *LocalOwner) Process(from uint64, st *wire.SignedTransport) error {
+// Process processes a message of another operator. A message which fails to process is rejected without failing
+// the ceremony, only failing to start the DKG once all exchange messages are received fails it.
+func (o *LocalOwner) Process(from uint64, st *wire.SignedTransport) (err error) {
+	defer func() {
+		if err != nil {
+			o.rejectMessage(err)
+		}
+	}()
 	msgbts, err := st.Message.MarshalSSZ()
 	if err != nil {
 		return err
 	}
 	// Verify operator signatures
 	if err := o.VerifyFunc(st.Signer, msgbts, st.Signature); err != nil {
+		metrics.SignatureFailures.WithLabelValues("operator").Inc()
 		return err
 	}
 	t := st.Message
+	if t.Version != o.Version {
+		return fmt.Errorf("message of protocol version %d from operator %d, ceremony runs version %d", t.Version, st.Signer, o.Version)
+	}
 	o.Logger.Info("✅ Successfully verified incoming DKG", zap.String("message type", t.Type.String()), zap.Uint64("from", st.Signer))
 	switch t.Type {
 	case wire.ExchangeMessageType:
+		if o.data.init == nil {
+			return fmt.Errorf("received exchange message for a resharing instance")
+		}
 		exchMsg := &wire.Exchange{}
 		if err := exchMsg.UnmarshalSSZ(t.Data); err != nil {
 			return err
@@ -433,11 +687,36 @@ func (o *LocalOwner) Process(from uint64, st *wire.SignedTransport) error {
 
 		if len(o.Exchanges) == len(o.data.init.Operators) {
 			if err := o.StartDKG(); err != nil {
+				o.setError(metrics.ReasonDKG, err)
+				return err
+			}
+		}
+	case wire.ReshareExchangeMessageType:
+		if o.data.reshare == nil {
+			return fmt.Errorf("received reshare exchange message for a non resharing instance")
+		}
+		exchMsg := &wire.ReshareExchange{}
+		if err := exchMsg.UnmarshalSSZ(t.Data); err != nil {
+			return err
+		}
+		if _, ok := o.ReshareExchanges[from]; ok {
+			return ErrAlreadyExists
+		}
+
+		o.ReshareExchanges[from] = exchMsg
+
+		if len(o.ReshareExchanges) == len(ReshareOperators(o.data.reshare)) {
+			if err := o.StartReshare(); err != nil {
+				o.setError(metrics.ReasonDKG, err)
 				return err
 			}
 		}
 	case wire.KyberMessageType:
-		<-o.startedDKG
+		select {
+		case <-o.startedDKG:
+		case <-o.ctx.Done():
+			return ErrCancelled
+		}
 		return o.processDKG(from, t)
 	default:
 		return fmt.Errorf("unknown message type")
@@ -476,18 +755,8 @@ func ExchangeWireMessage(exchData []byte, reqID [24]byte) *wire.Transport {
 	}
 }
 
-func GetNetworkByFork(fork [4]byte) eth2_key_manager_core.Network {
-	switch fork {
-	case [4]byte{0x00, 0x00, 0x10, 0x20}:
-		return eth2_key_manager_core.PraterNetwork
-	case [4]byte{0, 0, 0, 0}:
-		return eth2_key_manager_core.MainNetwork
-	default:
-		return eth2_key_manager_core.MainNetwork
-	}
-}
-
 func (o *LocalOwner) broadcastError(err error) {
+	o.setError(metrics.ReasonDKG, err)
 	errMsgEnc, _ := json.Marshal(err.Error())
 	errMsg := &wire.Transport{
 		Type:       wire.ErrorMessageType,
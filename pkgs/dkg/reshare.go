@@ -0,0 +1,229 @@
+package dkg
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/drand/kyber"
+	"github.com/drand/kyber/share/dkg"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+)
+
+// ReshareOperators returns all operators participating in a resharing, old operators first
+func ReshareOperators(reshare *wire.Reshare) []*wire.Operator {
+	ops := make([]*wire.Operator, 0, len(reshare.OldOperators)+len(reshare.NewOperators))
+	seen := make(map[uint64]struct{})
+	for _, op := range append(reshare.OldOperators, reshare.NewOperators...) {
+		if _, ok := seen[op.ID]; ok {
+			continue
+		}
+		seen[op.ID] = struct{}{}
+		ops = append(ops, op)
+	}
+	return ops
+}
+
+func containsOperator(ops []*wire.Operator, id uint64) bool {
+	for _, op := range ops {
+		if op.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func operatorIDs(ops []*wire.Operator) []uint64 {
+	ids := make([]uint64, 0, len(ops))
+	for _, op := range ops {
+		ids = append(ids, op.ID)
+	}
+	return ids
+}
+
+// InitReshare prepares the resharing instance. Old operators load their stored share of the validator
+// and send its public commitments along with the exchange message.
+func (o *LocalOwner) InitReshare(reqID [24]byte, reshare *wire.Reshare) (*wire.Transport, error) {
+	if o.data == nil {
+		o.data = &DKGData{}
+	}
+	o.data.reshare = reshare
+	o.data.ReqID = reqID
+	o.b = o.newBoard()
+
+	var commits []byte
+	if containsOperator(reshare.OldOperators, o.ID) {
+		if o.Store == nil {
+			return nil, fmt.Errorf("operator doesn't store shares, can't reshare")
+		}
+		stored, err := o.Store.Find(reshare.ValidatorPubKey, operatorIDs(reshare.OldOperators), reshare.OldT)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(stored.InitiatorPublicKey, reshare.InitiatorPublicKey) {
+			return nil, fmt.Errorf("resharing is allowed only by the initiator who created the validator")
+		}
+		o.data.share, err = distKeyShare(stored, o.suite.G1().(dkg.Suite))
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range o.data.share.Commits {
+			byts, err := c.MarshalBinary()
+			if err != nil {
+				return nil, err
+			}
+			commits = append(commits, byts...)
+		}
+	}
+
+	eciesSK, pk := InitSecret(o.suite)
+	o.data.Secret = eciesSK
+	pkByts, err := pk.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	exch := &wire.ReshareExchange{
+		PK:      pkByts,
+		Commits: commits,
+	}
+	exchByts, err := exch.MarshalSSZ()
+	if err != nil {
+		return nil, err
+	}
+	return &wire.Transport{
+		Type:       wire.ReshareExchangeMessageType,
+		Identifier: reqID,
+		Data:       exchByts,
+	}, nil
+}
+
+func (o *LocalOwner) StartReshare() error {
+	o.Logger.Info("Starting resharing")
+	oldNodes, err := o.reshareNodes(o.data.reshare.OldOperators)
+	if err != nil {
+		return err
+	}
+	newNodes, err := o.reshareNodes(o.data.reshare.NewOperators)
+	if err != nil {
+		return err
+	}
+	var coeffs []kyber.Point
+	if o.data.share == nil {
+		coeffs, err = o.oldPublicCoeffs()
+		if err != nil {
+			return err
+		}
+	}
+	err = o.startProtocol(&wire.Config{
+		Identifier:   o.data.ReqID[:],
+		Secret:       o.data.Secret,
+		Nodes:        newNodes,
+		OldNodes:     oldNodes,
+		Suite:        o.suite,
+		T:            int(o.data.reshare.NewT),
+		OldT:         int(o.data.reshare.OldT),
+		Share:        o.data.share,
+		PublicCoeffs: coeffs,
+		Board:        o.b,
+		Phaser:       o.Phaser,
+		PhaseTimeout: o.PhaseTimeout,
+		PhaseF:       o.setPhase,
+
+		Logger: o.Logger,
+	})
+	if err != nil {
+		return err
+	}
+
+	if o.data.share == nil {
+		// new operators don't deal, let the initiator know we are waiting for the deals
+		return o.Broadcast(&wire.Transport{
+			Type:       wire.ReshareReadyMessageType,
+			Identifier: o.data.ReqID,
+		})
+	}
+	return nil
+}
+
+func (o *LocalOwner) reshareNodes(ops []*wire.Operator) ([]dkg.Node, error) {
+	nodes := make([]dkg.Node, 0, len(ops))
+	for _, op := range ops {
+		e, ok := o.ReshareExchanges[op.ID]
+		if !ok {
+			return nil, fmt.Errorf("missing exchange message from operator %d", op.ID)
+		}
+		p := o.suite.G1().Point()
+		if err := p.UnmarshalBinary(e.PK); err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, dkg.Node{
+			Index:  dkg.Index(op.ID - 1),
+			Public: p,
+		})
+	}
+	return nodes, nil
+}
+
+// oldPublicCoeffs returns the public polynomial of the validator key as sent by the old operators,
+// all of them have to agree on it
+func (o *LocalOwner) oldPublicCoeffs() ([]kyber.Point, error) {
+	var commits []byte
+	for _, op := range o.data.reshare.OldOperators {
+		e := o.ReshareExchanges[op.ID]
+		if commits == nil {
+			commits = e.Commits
+			continue
+		}
+		if !bytes.Equal(commits, e.Commits) {
+			return nil, fmt.Errorf("old operators sent different public commitments")
+		}
+	}
+	pointLen := o.suite.G1().PointLen()
+	if len(commits) == 0 || len(commits)%pointLen != 0 {
+		return nil, fmt.Errorf("wrong public commitments length %d", len(commits))
+	}
+	if uint64(len(commits)/pointLen) != o.data.reshare.OldT {
+		return nil, fmt.Errorf("public commitments don't match old threshold %d", o.data.reshare.OldT)
+	}
+	if !bytes.Equal(commits[:pointLen], o.data.reshare.ValidatorPubKey) {
+		return nil, fmt.Errorf("public commitments don't match validator public key")
+	}
+	coeffs := make([]kyber.Point, 0, len(commits)/pointLen)
+	for i := 0; i < len(commits); i += pointLen {
+		p := o.suite.G1().Point()
+		if err := p.UnmarshalBinary(commits[i : i+pointLen]); err != nil {
+			return nil, err
+		}
+		coeffs = append(coeffs, p)
+	}
+	return coeffs, nil
+}
+
+// postReshareLeave reports the end of the resharing by an old operator which doesn't receive a new share.
+// Kyber ends the protocol with an error for such nodes, as they don't process responses.
+func (o *LocalOwner) postReshareLeave(res *dkg.OptionResult) error {
+	if res.Error != nil {
+		o.Logger.Debug("Leaving operator finished resharing", zap.Error(res.Error))
+	}
+	o.Logger.Info("Resharing finished, operator is not a part of the new operators set")
+	out := Result{
+		RequestID:       o.data.ReqID,
+		ValidatorPubKey: o.data.reshare.ValidatorPubKey,
+		PubKeyRSA:       o.RSAPub,
+		OperatorID:      o.ID,
+	}
+	encodedOutput, err := out.Encode()
+	if err != nil {
+		o.broadcastError(err)
+		return err
+	}
+	o.setResult(out.ValidatorPubKey)
+	o.Broadcast(&wire.Transport{
+		Type:       wire.OutputMessageType,
+		Identifier: o.data.ReqID,
+		Data:       encodedOutput,
+	})
+	close(o.done)
+	return nil
+}
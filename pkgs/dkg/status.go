@@ -0,0 +1,124 @@
+package dkg
+
+import (
+	"time"
+
+	"github.com/drand/kyber/share/dkg"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/metrics"
+)
+
+// Phase is the current phase of a ceremony
+type Phase string
+
+const (
+	// PhaseExchange operators exchange their DKG session keys
+	PhaseExchange Phase = "exchange"
+	// PhaseDeal dealers send shares to the share holders
+	PhaseDeal Phase = "deal"
+	// PhaseResponse share holders complain about invalid shares
+	PhaseResponse Phase = "response"
+	// PhaseJustification dealers justify the shares complained about
+	PhaseJustification Phase = "justification"
+	// PhaseOutput the ceremony is over with a result or an error
+	PhaseOutput Phase = "output"
+)
+
+// Status is a snapshot of the progress of a ceremony
+type Status struct {
+	Phase Phase
+	// Operators IDs of all operators of the ceremony
+	Operators []uint64
+	// Threshold of the created key shares
+	Threshold uint64
+	// ValidatorPubKey is set when the ceremony finished successfully
+	ValidatorPubKey []byte
+	// Error is set when the ceremony failed
+	Error string
+	// RejectedMessages is the number of messages of other operators the instance rejected, a rejected message
+	// doesn't fail the ceremony
+	RejectedMessages uint64
+	// LastRejection is the reason the last message was rejected
+	LastRejection string
+}
+
+// Status returns the progress of the ceremony
+func (o *LocalOwner) Status() Status {
+	o.statusMtx.Lock()
+	defer o.statusMtx.Unlock()
+	status := o.status
+	if o.data != nil {
+		switch {
+		case o.data.reshare != nil:
+			status.Threshold = o.data.reshare.NewT
+			for _, op := range ReshareOperators(o.data.reshare) {
+				status.Operators = append(status.Operators, op.ID)
+			}
+		case o.data.init != nil:
+			status.Threshold = o.data.init.T
+			for _, op := range o.data.init.Operators {
+				status.Operators = append(status.Operators, op.ID)
+			}
+		}
+	}
+	return status
+}
+
+func (o *LocalOwner) setPhase(phase dkg.Phase) {
+	o.statusMtx.Lock()
+	defer o.statusMtx.Unlock()
+	// the result of the ceremony may be ready before the phaser signals the finish phase
+	if o.status.Phase == PhaseOutput {
+		return
+	}
+	switch phase {
+	case dkg.DealPhase:
+		o.moveTo(PhaseDeal)
+	case dkg.ResponsePhase:
+		o.moveTo(PhaseResponse)
+	case dkg.JustifPhase:
+		o.moveTo(PhaseJustification)
+	}
+}
+
+func (o *LocalOwner) setResult(validatorPubKey []byte) {
+	o.statusMtx.Lock()
+	defer o.statusMtx.Unlock()
+	if o.status.Phase == PhaseOutput {
+		return
+	}
+	o.moveTo(PhaseOutput)
+	o.status.ValidatorPubKey = validatorPubKey
+	metrics.CeremoniesCompleted.Inc()
+}
+
+func (o *LocalOwner) setError(reason string, err error) {
+	o.statusMtx.Lock()
+	defer o.statusMtx.Unlock()
+	if o.status.Phase == PhaseOutput {
+		return
+	}
+	o.moveTo(PhaseOutput)
+	o.status.Error = err.Error()
+	metrics.CeremoniesFailed.WithLabelValues(reason).Inc()
+}
+
+// rejectMessage records a message of another operator which failed to process, the ceremony goes on without it
+func (o *LocalOwner) rejectMessage(err error) {
+	o.statusMtx.Lock()
+	defer o.statusMtx.Unlock()
+	o.status.RejectedMessages++
+	o.status.LastRejection = err.Error()
+	metrics.RejectedMessages.Inc()
+}
+
+// moveTo records the duration of the current phase and moves to the next one, the status lock should be held
+func (o *LocalOwner) moveTo(phase Phase) {
+	if o.status.Phase == phase {
+		return
+	}
+	now := time.Now()
+	metrics.PhaseDuration.WithLabelValues(string(o.status.Phase)).Observe(now.Sub(o.phaseStart).Seconds())
+	o.status.Phase = phase
+	o.phaseStart = now
+}
@@ -0,0 +1,52 @@
+package dkg
+
+import (
+	"github.com/drand/kyber"
+	"github.com/drand/kyber/share"
+	"github.com/drand/kyber/share/dkg"
+	"github.com/herumi/bls-eth-go-binary/bls"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/store"
+)
+
+// storeShare saves the share created by the ceremony, it holds everything needed to later reshare the validator key
+func (o *LocalOwner) storeShare(key *dkg.DistKeyShare, secretKeyBLS *bls.SecretKey, validatorPubKey []byte, operators []uint64, threshold uint64, initiatorPubKey []byte) error {
+	commits := make([][]byte, 0, len(key.Commits))
+	for _, c := range key.Commits {
+		byts, err := c.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		commits = append(commits, byts)
+	}
+	return o.Store.Save(&store.Share{
+		RequestID:          o.data.ReqID,
+		ValidatorPubKey:    validatorPubKey,
+		OperatorID:         o.ID,
+		SecretKey:          secretKeyBLS,
+		Commits:            commits,
+		Operators:          operators,
+		Threshold:          threshold,
+		InitiatorPublicKey: initiatorPubKey,
+	})
+}
+
+// distKeyShare returns the kyber share to be used as an old node when resharing
+func distKeyShare(s *store.Share, suite dkg.Suite) (*dkg.DistKeyShare, error) {
+	v := suite.Scalar()
+	if err := v.UnmarshalBinary(s.SecretKey.Serialize()); err != nil {
+		return nil, err
+	}
+	commits := make([]kyber.Point, 0, len(s.Commits))
+	for _, c := range s.Commits {
+		p := suite.Point()
+		if err := p.UnmarshalBinary(c); err != nil {
+			return nil, err
+		}
+		commits = append(commits, p)
+	}
+	return &dkg.DistKeyShare{
+		Commits: commits,
+		Share:   &share.PriShare{I: int(s.OperatorID - 1), V: v},
+	}, nil
+}
@@ -0,0 +1,233 @@
+package initiator
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/consts"
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+)
+
+// DefaultBatchSize is the number of ceremonies run in parallel in a batch by default
+const DefaultBatchSize = MaxBatchSize
+
+// MaxBatchSize is the max number of ceremonies sent to operators in a single batch,
+// operators don't accept init batches larger than their init rate limit
+const MaxBatchSize = 5
+
+// BulkKeyShares is a keyshares file of multiple validators used for bulk registration at SSV
+type BulkKeyShares struct {
+	Version   string          `json:"version"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Shares    []KeySharesItem `json:"shares"`
+}
+
+// KeySharesItem is keyshares data and payload of a single validator at a bulk keyshares file
+type KeySharesItem struct {
+	Data    Data    `json:"data"`
+	Payload Payload `json:"payload"`
+}
+
+// BulkResult is the result of a single ceremony started by StartBulkDKG
+type BulkResult struct {
+	ID          [24]byte
+	Nonce       uint64
+	DepositData *DepositDataJson
+	KeyShares   *KeyShares
+}
+
+// StartBulkDKG creates multiple validators for the same operators, running a ceremony per validator.
+// Ceremonies use increasing owner nonces starting from the given one. They run in sequential batches of batchSize
+// ceremonies, the ceremonies of a batch run in parallel and their messages are sent to the operators in a single request
+// per operator at every phase. Results are ordered by nonce. If a batch fails, the results of the earlier batches are
+// returned with the error: their validators exist at the operators. The ceremonies of the failed batch are cancelled.
+func (c *Initiator) StartBulkDKG(ctx context.Context, withdraw []byte, ids []uint64, fork [4]byte, forkName string, owner common.Address, nonce uint64, validators, batchSize int) ([]*BulkResult, error) {
+	c = c.ceremonyCopy()
+	if c.TolerateOffline {
+		return nil, ErrOfflineNotSupported
+	}
+	if validators <= 0 {
+		return nil, fmt.Errorf("wrong number of validators %d", validators)
+	}
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+	if batchSize > MaxBatchSize {
+		return nil, fmt.Errorf("max %d ceremonies fit in a batch", MaxBatchSize)
+	}
+	ops, err := validatedOperatorData(ids, c.Operators, c.Policy)
+	if err != nil {
+		return nil, err
+	}
+	// Add messages verification coming form operators
+	verify, err := c.CreateVerifyFunc(ops)
+	if err != nil {
+		return nil, err
+	}
+	c.VerifyFunc = verify
+	if err := c.negotiateVersion(ctx, ops); err != nil {
+		return nil, err
+	}
+
+	results := make([]*BulkResult, 0, validators)
+	for start := 0; start < validators; start += batchSize {
+		size := batchSize
+		if validators-start < size {
+			size = validators - start
+		}
+		inits := make([]*wire.Init, 0, size)
+		reqIDs := make([][24]byte, 0, size)
+		for i := 0; i < size; i++ {
+			init, err := c.newInit(ops, withdraw, fork, owner, nonce+uint64(start+i))
+			if err != nil {
+				return results, err
+			}
+			inits = append(inits, init)
+			reqIDs = append(reqIDs, crypto.NewID())
+		}
+		c.Logger.Info("🚀 Starting batch of dkg ceremonies", zap.Uint64s("operator_ids", ids), zap.Uint64("from_nonce", inits[0].Nonce), zap.Uint64("to_nonce", inits[size-1].Nonce))
+		dkgResults, err := c.batchMessageFlowHandling(ctx, inits, reqIDs, ops)
+		if err != nil {
+			c.abortBatch(reqIDs, ops)
+			return results, err
+		}
+		for i, dkgResult := range dkgResults {
+			depositData, keyShares, err := c.processDKGResult(dkgResult, reqIDs[i], inits[i], forkName)
+			if err != nil {
+				c.abortBatch(reqIDs, ops)
+				return results, fmt.Errorf("ceremony with nonce %d failed: %w", inits[i].Nonce, err)
+			}
+			results = append(results, &BulkResult{
+				ID:          reqIDs[i],
+				Nonce:       inits[i].Nonce,
+				DepositData: depositData,
+				KeyShares:   keyShares,
+			})
+		}
+	}
+	return results, nil
+}
+
+// abortBatch cancels every ceremony of a failed batch at the operators, so their instances don't hold the operators'
+// capacity until they expire
+func (c *Initiator) abortBatch(reqIDs [][24]byte, operators []*wire.Operator) {
+	ctx, cancel := c.abortContext()
+	defer cancel()
+	for _, id := range reqIDs {
+		if err := c.Abort(ctx, id, operators); err != nil {
+			c.Logger.Debug("not all operators aborted the ceremony", zap.String("instance_id", hex.EncodeToString(id[:])), zap.Error(err))
+		}
+	}
+}
+
+// batchMessageFlowHandling runs the ceremonies of all init messages, sending messages of all ceremonies
+// in a single request per operator at every phase
+func (c *Initiator) batchMessageFlowHandling(ctx context.Context, inits []*wire.Init, reqIDs [][24]byte, operators []*wire.Operator) ([][][]byte, error) {
+	c.Logger.Info("phase 1: sending batch of init messages to operators")
+	batch := &wire.BatchSignedTransports{Messages: make([]*wire.SignedTransport, 0, len(inits))}
+	for i, init := range inits {
+		sszInit, err := init.MarshalSSZ()
+		if err != nil {
+			return nil, err
+		}
+		signedInitMsg, err := c.signMessage(wire.InitMessageType, sszInit, reqIDs[i])
+		if err != nil {
+			return nil, err
+		}
+		batch.Messages = append(batch.Messages, signedInitMsg)
+	}
+	batchBytes, err := batch.MarshalSSZ()
+	if err != nil {
+		return nil, err
+	}
+	results, err := c.sendBatch(ctx, consts.API_INIT_BATCH_URL, batchBytes, reqIDs, inits)
+	if err != nil {
+		return nil, err
+	}
+	c.Logger.Info("phase 1: ✅ verified operator init responses signatures")
+
+	c.Logger.Info("phase 2: ➡️ sending batch of operator data (exchange messages) required for dkg")
+	results, err = c.sendBatchMultiple(ctx, results, reqIDs, inits)
+	if err != nil {
+		return nil, err
+	}
+	c.Logger.Info("phase 2: ✅ verified operator responses (deal messages) signatures")
+
+	c.Logger.Info("phase 3: ➡️ sending batch of deal dkg data to all operators")
+	results, err = c.sendBatchMultiple(ctx, results, reqIDs, inits)
+	if err != nil {
+		return nil, err
+	}
+	c.Logger.Info("phase 3: ✅ verified operator dkg results signatures")
+	return results, nil
+}
+
+// sendBatchMultiple combines responses of the operators for every ceremony and sends them to the operators as a batch
+func (c *Initiator) sendBatchMultiple(ctx context.Context, responses [][][]byte, reqIDs [][24]byte, inits []*wire.Init) ([][][]byte, error) {
+	batch := &wire.BatchMultipleSignedTransports{Messages: make([]*wire.MultipleSignedTransports, 0, len(responses))}
+	for i, res := range responses {
+		mltpl, err := c.MakeMultiple(reqIDs[i], res)
+		if err != nil {
+			return nil, fmt.Errorf("ceremony with nonce %d failed: %w", inits[i].Nonce, err)
+		}
+		batch.Messages = append(batch.Messages, mltpl)
+	}
+	batchBytes, err := batch.MarshalSSZ()
+	if err != nil {
+		return nil, err
+	}
+	return c.sendBatch(ctx, consts.API_DKG_BATCH_URL, batchBytes, reqIDs, inits)
+}
+
+// sendBatch sends the batch to all operators and splits their responses by ceremony, verifying signatures of every response
+func (c *Initiator) sendBatch(ctx context.Context, method string, batch []byte, reqIDs [][24]byte, inits []*wire.Init) ([][][]byte, error) {
+	opResponses, err := c.SendToAll(ctx, method, batch, inits[0].Operators)
+	if err != nil {
+		return nil, err
+	}
+	results := make([][][]byte, len(reqIDs))
+	for _, opResponse := range opResponses {
+		resp := &wire.BatchResponse{}
+		if err := resp.UnmarshalSSZ(opResponse); err != nil {
+			errmsg, parseErr := parseAsError(opResponse)
+			if parseErr == nil {
+				return nil, fmt.Errorf("operator returned err: %v", errmsg)
+			}
+			return nil, err
+		}
+		if len(resp.Responses) != len(reqIDs) {
+			return nil, fmt.Errorf("operator returned %d responses to a batch of %d messages", len(resp.Responses), len(reqIDs))
+		}
+		for i, res := range resp.Responses {
+			results[i] = append(results[i], res)
+		}
+	}
+	for i := range results {
+		if err := c.VerifyAll(reqIDs[i], results[i]); err != nil {
+			return nil, fmt.Errorf("ceremony with nonce %d failed: %w", inits[i].Nonce, err)
+		}
+	}
+	return results, nil
+}
+
+// GenerateBulkKeyShares combines keyshares of multiple validators into a single file
+func GenerateBulkKeyShares(results []*BulkResult) *BulkKeyShares {
+	bulk := &BulkKeyShares{
+		Version:   "v4",
+		CreatedAt: time.Now().UTC(),
+		Shares:    make([]KeySharesItem, 0, len(results)),
+	}
+	for _, res := range results {
+		bulk.Shares = append(bulk.Shares, KeySharesItem{
+			Data:    res.KeyShares.Data,
+			Payload: res.KeyShares.Payload,
+		})
+	}
+	return bulk
+}
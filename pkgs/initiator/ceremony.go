@@ -0,0 +1,331 @@
+package initiator
+
+import (
+	"context"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/network"
+	"github.com/bloxapp/ssv-dkg/pkgs/threshold"
+)
+
+var (
+	// ErrInvalidRequest is returned by Run for a ceremony request which can't be run
+	ErrInvalidRequest = errors.New("invalid ceremony request")
+	// ErrInvalidOption is returned by NewInitiator for options which can't be applied
+	ErrInvalidOption = errors.New("invalid initiator option")
+)
+
+// CeremonyError is returned by Run for a ceremony which failed after it started at the operators
+type CeremonyError struct {
+	// ID of the ceremony creating a single validator, zero for multiple validators
+	ID [24]byte
+	// StatePath is set if the state of the ceremony is saved, the ceremony can be resumed or aborted from it
+	StatePath string
+	// Transcript of the failed ceremony signed by the initiator, nil if it isn't recorded
+	Transcript *Transcript
+	// Result of the validators created before the ceremonies of multiple validators failed, nil if there are none
+	Result *CeremonyResult
+	Err    error
+}
+
+func (e *CeremonyError) Error() string {
+	if e.ID == ([24]byte{}) {
+		return fmt.Sprintf("ceremony failed: %v", e.Err)
+	}
+	return fmt.Sprintf("ceremony %x failed: %v", e.ID, e.Err)
+}
+
+func (e *CeremonyError) Unwrap() error {
+	return e.Err
+}
+
+// CeremonyRequest describes the validators created by a ceremony
+type CeremonyRequest struct {
+	// ID of a ceremony creating a single validator, a new one if not set.
+	// Every ceremony creating multiple validators gets a new ID.
+	ID          [24]byte
+	OperatorIDs []uint64
+	// WithdrawalAddress of the withdrawal credentials of the validators
+	WithdrawalAddress common.Address
+	// Owner of the validators at SSV
+	Owner common.Address
+	// Nonce of the owner at SSV of the first validator, following validators use increasing nonces
+	Nonce uint64
+	// Network name of the deposit data, i.e. mainnet
+	Network string
+	// Validators is the number of created validators, 1 if not set
+	Validators int
+}
+
+// CeremonyResult holds the results of a ceremony, ordered by nonce
+type CeremonyResult struct {
+	DepositData []*DepositDataJson
+	KeyShares   []*KeyShares
+	// Transcript of the ceremony signed by the initiator, nil if it isn't recorded
+	Transcript *Transcript
+}
+
+// BulkKeyShares returns a keyshares file of all created validators for bulk registration at SSV
+func (r *CeremonyResult) BulkKeyShares() *BulkKeyShares {
+	results := make([]*BulkResult, 0, len(r.KeyShares))
+	for _, ks := range r.KeyShares {
+		results = append(results, &BulkResult{KeyShares: ks})
+	}
+	return GenerateBulkKeyShares(results)
+}
+
+// Proofs returns the operator proofs of all created validators
+func (r *CeremonyResult) Proofs() []*SignedProof {
+	var proofs []*SignedProof
+	for _, ks := range r.KeyShares {
+		proofs = append(proofs, ks.Proofs...)
+	}
+	return proofs
+}
+
+// Option configures an initiator created by NewInitiator
+type Option func(c *Initiator) error
+
+// WithLogger sets the logger of the initiator, nothing is logged by default
+func WithLogger(logger *zap.Logger) Option {
+	return func(c *Initiator) error {
+		c.Logger = logger
+		return nil
+	}
+}
+
+// WithTransport sets the transport delivering messages to the operators, HTTP by default
+func WithTransport(t Transport) Option {
+	return func(c *Initiator) error {
+		c.Transport = t
+		return nil
+	}
+}
+
+// WithTLS configures TLS of requests to operators over HTTP
+func WithTLS(opts TLSOptions) Option {
+	return func(c *Initiator) error {
+		return c.SetTLS(opts)
+	}
+}
+
+// WithRequestTimeout bounds every request to an operator, DefaultRequestTimeout by default.
+// Zero leaves requests bounded only by the context of the ceremony.
+func WithRequestTimeout(timeout time.Duration) Option {
+	return func(c *Initiator) error {
+		if timeout < 0 {
+			return fmt.Errorf("request timeout should be positive")
+		}
+		c.RequestTimeout = timeout
+		return nil
+	}
+}
+
+// WithThreshold sets the threshold of created key shares, 3f+1 of the operators by default
+func WithThreshold(t uint64) Option {
+	return func(c *Initiator) error {
+		c.Threshold = t
+		return nil
+	}
+}
+
+// WithPolicy limits the number of operators of a ceremony, threshold.DefaultPolicy by default
+func WithPolicy(p threshold.Policy) Option {
+	return func(c *Initiator) error {
+		c.Policy = p
+		return nil
+	}
+}
+
+// WithDepositAmount sets the amount of deposit data, 32 ETH by default
+func WithDepositAmount(amount phase0.Gwei) Option {
+	return func(c *Initiator) error {
+		c.DepositAmount = amount
+		return nil
+	}
+}
+
+// WithCompounding creates validators with compounding withdrawal credentials
+func WithCompounding() Option {
+	return func(c *Initiator) error {
+		c.WithdrawalPrefix = crypto.CompoundingWithdrawalPrefixByte
+		return nil
+	}
+}
+
+// WithTolerateOffline continues a ceremony creating a single validator without offline operators
+func WithTolerateOffline() Option {
+	return func(c *Initiator) error {
+		c.TolerateOffline = true
+		return nil
+	}
+}
+
+// WithTranscript records the requests to operators and their responses, every Run records a new transcript.
+// The signed transcript is returned with the results, or with the CeremonyError of a failed ceremony.
+func WithTranscript() Option {
+	return func(c *Initiator) error {
+		c.Transcript = NewTranscript()
+		return nil
+	}
+}
+
+// WithStateDir saves the state of a ceremony creating a single validator at the directory, as ceremony-<request id>.json
+func WithStateDir(dir string) Option {
+	return func(c *Initiator) error {
+		c.stateDir = dir
+		return nil
+	}
+}
+
+// WithBatchSize sets the number of ceremonies run in parallel in a batch when creating multiple validators,
+// batches run one after another. DefaultBatchSize by default
+func WithBatchSize(batchSize int) Option {
+	return func(c *Initiator) error {
+		if batchSize < 0 {
+			return fmt.Errorf("batch size should be positive")
+		}
+		c.batchSize = batchSize
+		return nil
+	}
+}
+
+// NewInitiator creates an initiator configured by the options
+func NewInitiator(privKey *rsa.PrivateKey, operators Operators, opts ...Option) (*Initiator, error) {
+	if privKey == nil {
+		return nil, fmt.Errorf("%w: initiator key is required", ErrInvalidOption)
+	}
+	c := New(privKey, operators, zap.NewNop())
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidOption, err)
+		}
+	}
+	if err := crypto.ValidateDepositAmount(c.WithdrawalPrefix, c.DepositAmount); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidOption, err)
+	}
+	return c, nil
+}
+
+// Run runs a ceremony creating the validators of the request. If the context is done before the ceremony finishes,
+// requests to the operators stop, the ceremony fails with the context error and a ceremony of a single validator
+// which can't be resumed is cancelled at the operators. Every Run has its own transcript, state and logger fields,
+// an initiator can run ceremonies one after another or in parallel.
+func (c *Initiator) Run(ctx context.Context, req CeremonyRequest) (*CeremonyResult, error) {
+	n, err := c.validateRequest(&req)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if req.Validators == 1 && req.ID == ([24]byte{}) {
+		req.ID = crypto.NewID()
+	}
+	rc := c.ceremonyCopy()
+	rc.StatePath = ""
+	if c.Transcript != nil {
+		rc.Transcript = NewTranscript()
+	}
+	result, err := rc.ceremony(ctx, req, n)
+	if rc.Transcript != nil {
+		var cerr *CeremonyError
+		if signErr := rc.SignTranscript(); signErr != nil {
+			rc.Logger.Warn("Failed signing transcript", zap.Error(signErr))
+		} else if result != nil {
+			result.Transcript = rc.Transcript
+		} else if errors.As(err, &cerr) {
+			cerr.Transcript = rc.Transcript
+		}
+	}
+	return result, err
+}
+
+// validateRequest checks the request and sets its defaults, the network of the request is returned
+func (c *Initiator) validateRequest(req *CeremonyRequest) (network.Network, error) {
+	if req.Validators == 0 {
+		req.Validators = 1
+	}
+	if req.Validators < 0 {
+		return network.Network{}, fmt.Errorf("%w: number of validators should be at least 1", ErrInvalidRequest)
+	}
+	if req.Validators > 1 && c.TolerateOffline {
+		return network.Network{}, fmt.Errorf("%w: %v", ErrInvalidRequest, ErrOfflineNotSupported)
+	}
+	if req.Validators > 1 && req.ID != ([24]byte{}) {
+		return network.Network{}, fmt.Errorf("%w: ID can be set only for a single validator", ErrInvalidRequest)
+	}
+	if len(req.OperatorIDs) == 0 {
+		return network.Network{}, fmt.Errorf("%w: operator IDs are required", ErrInvalidRequest)
+	}
+	if _, err := validatedOperatorData(req.OperatorIDs, c.Operators, c.Policy); err != nil {
+		return network.Network{}, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+	}
+	if req.WithdrawalAddress == (common.Address{}) {
+		return network.Network{}, fmt.Errorf("%w: withdrawal address is required", ErrInvalidRequest)
+	}
+	if req.Owner == (common.Address{}) {
+		return network.Network{}, fmt.Errorf("%w: owner address is required", ErrInvalidRequest)
+	}
+	n, err := network.ByName(req.Network)
+	if err != nil {
+		return network.Network{}, fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+	}
+	return n, nil
+}
+
+func (c *Initiator) ceremony(ctx context.Context, req CeremonyRequest, n network.Network) (*CeremonyResult, error) {
+	if req.Validators == 1 {
+		if c.stateDir != "" {
+			c.StatePath = filepath.Join(c.stateDir, fmt.Sprintf("ceremony-%x.json", req.ID))
+		}
+		depositData, keyShares, err := c.StartDKG(ctx, req.ID, req.WithdrawalAddress.Bytes(), req.OperatorIDs, n.ForkVersion, req.Network, req.Owner, req.Nonce)
+		if err != nil {
+			cerr := &CeremonyError{ID: req.ID, Err: ceremonyErr(ctx, err)}
+			if c.resumable() {
+				cerr.StatePath = c.StatePath
+			}
+			return nil, cerr
+		}
+		return &CeremonyResult{DepositData: []*DepositDataJson{depositData}, KeyShares: []*KeyShares{keyShares}}, nil
+	}
+	results, err := c.StartBulkDKG(ctx, req.WithdrawalAddress.Bytes(), req.OperatorIDs, n.ForkVersion, req.Network, req.Owner, req.Nonce, req.Validators, c.batchSize)
+	if err != nil {
+		cerr := &CeremonyError{Err: ceremonyErr(ctx, err)}
+		if len(results) > 0 {
+			cerr.Result = bulkResult(results)
+		}
+		return nil, cerr
+	}
+	return bulkResult(results), nil
+}
+
+// bulkResult collects results of the ceremonies creating multiple validators
+func bulkResult(results []*BulkResult) *CeremonyResult {
+	result := &CeremonyResult{
+		DepositData: make([]*DepositDataJson, 0, len(results)),
+		KeyShares:   make([]*KeyShares, 0, len(results)),
+	}
+	for _, res := range results {
+		result.DepositData = append(result.DepositData, res.DepositData)
+		result.KeyShares = append(result.KeyShares, res.KeyShares)
+	}
+	return result
+}
+
+// ceremonyErr tells a ceremony failed because its context is done, errors of requests don't always wrap the context error
+func ceremonyErr(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil && !errors.Is(err, ctxErr) {
+		return fmt.Errorf("%w: %v", ctxErr, err)
+	}
+	return err
+}
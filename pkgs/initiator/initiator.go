@@ -2,6 +2,7 @@ package initiator
 
 import (
 	"bytes"
+	"context"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/base64"
@@ -10,13 +11,11 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
-	"io"
 	"net/url"
 	"sort"
 	"time"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
-	eth2_key_manager_core "github.com/bloxapp/eth2-key-manager/core"
 	ssvspec_types "github.com/bloxapp/ssv-spec/types"
 	"github.com/ethereum/go-ethereum/common"
 	eth_crypto "github.com/ethereum/go-ethereum/crypto"
@@ -27,6 +26,8 @@ import (
 	"github.com/bloxapp/ssv-dkg/pkgs/consts"
 	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
 	"github.com/bloxapp/ssv-dkg/pkgs/dkg"
+	"github.com/bloxapp/ssv-dkg/pkgs/network"
+	"github.com/bloxapp/ssv-dkg/pkgs/threshold"
 	"github.com/bloxapp/ssv-dkg/pkgs/wire"
 )
 
@@ -34,15 +35,12 @@ import (
 const encryptedKeyLength = 256
 
 const (
-	// MaxEffectiveBalanceInGwei is the max effective balance
-	MaxEffectiveBalanceInGwei phase0.Gwei = 32000000000
+	// MaxEffectiveBalanceInGwei is the max effective balance, the default deposit amount
+	MaxEffectiveBalanceInGwei = crypto.MaxEffectiveBalanceInGwei
+	// DefaultRequestTimeout bounds a request to an operator by default
+	DefaultRequestTimeout = 30 * time.Second
 )
 
-// IsSupportedDepositNetwork returns true if the given network is supported
-var IsSupportedDepositNetwork = func(network eth2_key_manager_core.Network) bool {
-	return network == eth2_key_manager_core.PyrmontNetwork || network == eth2_key_manager_core.PraterNetwork || network == eth2_key_manager_core.MainNetwork
-}
-
 type Operator struct {
 	Addr   string
 	ID     uint64
@@ -58,23 +56,55 @@ type OperatorDataJson struct {
 type Operators map[uint64]Operator
 
 type MockInitiator interface {
-	SendAndCollect(op Operator, method string, data []byte) ([]byte, error)
-	SendToAll(method string, msg []byte) ([][]byte, error)
-	PakeMultiple(id [24]byte, allmsgs [][]byte) (*wire.MultipleSignedTransports, error)
-	StartDKG(withdraw []byte, ids []uint64, threshold uint64, fork [4]byte, forkName string, owner common.Address, nonce uint64) (*DepositDataJson, *KeyShares, error)
+	SendAndCollect(ctx context.Context, op Operator, method string, data []byte) ([]byte, error)
+	SendToAll(ctx context.Context, method string, msg []byte, operatorsIDs []*wire.Operator) ([][]byte, error)
+	MakeMultiple(id [24]byte, allmsgs [][]byte) (*wire.MultipleSignedTransports, error)
+	Run(ctx context.Context, req CeremonyRequest) (*CeremonyResult, error)
+	StartDKG(ctx context.Context, id [24]byte, withdraw []byte, ids []uint64, fork [4]byte, forkName string, owner common.Address, nonce uint64) (*DepositDataJson, *KeyShares, error)
 	CreateVerifyFunc(ops []*wire.Operator) (func(id uint64, msg []byte, sig []byte) error, error)
 	ProcessDKGResultResponse(responseResult [][]byte, id [24]byte) ([]dkg.Result, *bls.PublicKey, map[ssvspec_types.OperatorID]*bls.PublicKey, map[ssvspec_types.OperatorID]*bls.Sign, map[ssvspec_types.OperatorID]*bls.Sign, error)
-	SendKyberMsgs(kyberDeals [][]byte, id [24]byte) ([][]byte, error)
-	SendExchangeMsgs(exchangeMsgs [][]byte, id [24]byte) ([][]byte, error)
-	SendInitMsg(init *wire.Init, id [24]byte) ([][]byte, error)
+	SendKyberMsgs(ctx context.Context, kyberDeals [][]byte, id [24]byte, operators []*wire.Operator) ([][]byte, error)
+	SendExchangeMsgs(ctx context.Context, exchangeMsgs [][]byte, id [24]byte, operators []*wire.Operator) ([][]byte, error)
+	SendInitMsg(ctx context.Context, init *wire.Init, id [24]byte, operators []*wire.Operator) ([][]byte, error)
 }
 
+// the initiator implements the interface, so it doesn't drift from the methods it mocks
+var _ MockInitiator = (*Initiator)(nil)
+
 type Initiator struct {
-	Logger     *zap.Logger
-	Client     *req.Client
-	Operators  Operators
-	VerifyFunc func(id uint64, msg, sig []byte) error
-	PrivateKey *rsa.PrivateKey
+	Logger *zap.Logger
+	// Client of the HTTP transport, TLS of requests to operators is configured on it
+	Client *req.Client
+	// Transport delivers messages to the operators, posting them over HTTP with the client by default
+	Transport Transport
+	// RequestTimeout bounds every request to an operator, only the context of the ceremony bounds requests if zero
+	RequestTimeout time.Duration
+	Operators      Operators
+	VerifyFunc     func(id uint64, msg, sig []byte) error
+	PrivateKey     *rsa.PrivateKey
+	// DepositAmount is the amount of deposit data created for validators, 32 ETH by default
+	DepositAmount phase0.Gwei
+	// WithdrawalPrefix is the type of withdrawal credentials of validators, 0x01 by default or 0x02 for compounding
+	WithdrawalPrefix byte
+	// Threshold of created key shares, 3f+1 of the operators if not set
+	Threshold uint64
+	// Policy limits the number of operators of a ceremony
+	Policy threshold.Policy
+	// TolerateOffline continues a ceremony without the operators which don't respond to the init message,
+	// as long as the threshold can be met. The key shares of the validator miss shares of the offline operators.
+	TolerateOffline bool
+	// Version of the wire protocol of the current ceremony, negotiated with the operators, wire.ProtocolVersion by default
+	Version uint64
+	// Transcript records the requests to operators and their responses if set
+	Transcript *Transcript
+	// StatePath is the file the state of a ceremony is saved to after every completed phase, to resume it if it's
+	// interrupted. The file is removed when the ceremony finishes successfully.
+	StatePath string
+	state     *CeremonyState
+	// stateDir is the directory of ceremony states of Run
+	stateDir string
+	// batchSize is the number of ceremonies Run runs in parallel in a batch when creating multiple validators
+	batchSize int
 }
 
 type DepositDataJson struct {
@@ -94,6 +124,10 @@ type KeyShares struct {
 	CreatedAt time.Time `json:"createdAt"`
 	Data      Data      `json:"data"`
 	Payload   Payload   `json:"payload"`
+	// MissingShares are IDs of the operators of the ceremony which were offline, data and payload have no shares for them
+	MissingShares []uint64 `json:"missingShares,omitempty"`
+	// Proofs of the operators that they created the shares, saved to a separate file
+	Proofs []*SignedProof `json:"-"`
 }
 
 type Data struct {
@@ -176,61 +210,70 @@ func GeneratePayload(result []dkg.Result, sigOwnerNonce []byte) (*KeyShares, err
 
 func New(privKey *rsa.PrivateKey, operatorMap Operators, logger *zap.Logger) *Initiator {
 	client := req.C()
-	// Set timeout for operator responses
-	client.SetTimeout(30 * time.Second)
 	c := &Initiator{
-		Logger:     logger,
-		Client:     client,
-		Operators:  operatorMap,
-		PrivateKey: privKey,
+		Logger:           logger,
+		Client:           client,
+		Transport:        NewHTTPTransport(client),
+		RequestTimeout:   DefaultRequestTimeout,
+		Operators:        operatorMap,
+		PrivateKey:       privKey,
+		DepositAmount:    MaxEffectiveBalanceInGwei,
+		WithdrawalPrefix: crypto.ETH1WithdrawalPrefixByte,
+		Policy:           threshold.DefaultPolicy,
+		Version:          wire.ProtocolVersion,
 	}
 	return c
 }
 
-type opReqResult struct {
-	operatorID uint64
-	err        error
-	result     []byte
-}
-
-func (c *Initiator) SendAndCollect(op Operator, method string, data []byte) ([]byte, error) {
-	r := c.Client.R()
-	r.SetBodyBytes(data)
-	res, err := r.Post(fmt.Sprintf("%v/%v", op.Addr, method))
+func (c *Initiator) SendAndCollect(ctx context.Context, op Operator, method string, data []byte) ([]byte, error) {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+	resdata, err := c.Transport.Send(ctx, op, method, data)
+	c.collect(op.ID, method, data, resdata, err)
 	if err != nil {
 		return nil, err
 	}
-	resdata, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, err
-	}
-	c.Logger.Debug("operator responded", zap.Uint64("operator", op.ID), zap.String("method", method))
 	return resdata, nil
 }
 
-func (c *Initiator) SendToAll(method string, msg []byte, operatorsIDs []*wire.Operator) ([][]byte, error) {
-	resc := make(chan opReqResult, len(operatorsIDs))
-	for _, op := range operatorsIDs {
-		go func(operator Operator) {
-			res, err := c.SendAndCollect(operator, method, msg)
-			resc <- opReqResult{
-				operatorID: operator.ID,
-				err:        err,
-				result:     res,
-			}
-		}(c.Operators[op.ID])
+// ceremonyCopy returns a copy of the initiator for a single ceremony. The ceremony sets the operator verification,
+// protocol version, state and logger fields of the copy, so ceremonies of the initiator don't share them.
+// The transcript and the state path are shared with the initiator.
+func (c *Initiator) ceremonyCopy() *Initiator {
+	cc := *c
+	cc.state = nil
+	return &cc
+}
+
+// requestContext bounds a request to operators by the request timeout
+func (c *Initiator) requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.RequestTimeout > 0 {
+		return context.WithTimeout(ctx, c.RequestTimeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// collect records the response of an operator
+func (c *Initiator) collect(operatorID uint64, method string, data, resdata []byte, err error) {
+	if err != nil {
+		c.record(operatorID, method, data, nil, err)
+		return
 	}
+	c.record(operatorID, method, data, resdata, nil)
+	c.Logger.Debug("operator responded", zap.Uint64("operator", operatorID), zap.String("method", method))
+}
+
+func (c *Initiator) SendToAll(ctx context.Context, method string, msg []byte, operatorsIDs []*wire.Operator) ([][]byte, error) {
 	final := make([][]byte, 0, len(operatorsIDs))
 
 	errarr := make([]error, 0)
 
-	for i := 0; i < len(operatorsIDs); i++ {
-		res := <-resc
-		if res.err != nil {
-			errarr = append(errarr, res.err)
+	for _, res := range c.sendToAll(ctx, method, msg, operatorsIDs) {
+		if res.Err != nil {
+			errarr = append(errarr, res.Err)
 			continue
 		}
-		final = append(final, res.result)
+		final = append(final, res.Result)
 	}
 
 	finalerr := error(nil)
@@ -242,6 +285,21 @@ func (c *Initiator) SendToAll(method string, msg []byte, operatorsIDs []*wire.Op
 	return final, finalerr
 }
 
+// sendToAll sends the message to all operators in parallel, results are in the order of responses
+func (c *Initiator) sendToAll(ctx context.Context, method string, msg []byte, operatorsIDs []*wire.Operator) []Response {
+	ops := make([]Operator, 0, len(operatorsIDs))
+	for _, op := range operatorsIDs {
+		ops = append(ops, c.Operators[op.ID])
+	}
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+	results := c.Transport.SendToAll(ctx, ops, method, msg)
+	for _, res := range results {
+		c.collect(res.OperatorID, method, msg, res.Result, res.Err)
+	}
+	return results
+}
+
 func parseAsError(msg []byte) (error, error) {
 	sszerr := &wire.ErrSSZ{}
 	err := sszerr.UnmarshalSSZ(msg)
@@ -271,6 +329,9 @@ func (c *Initiator) VerifyAll(id [24]byte, allmsgs [][]byte) error {
 		if !bytes.Equal(id[:], tsp.Message.Identifier[:]) {
 			return fmt.Errorf("incoming message has wrong ID, aborting... operator %d, msg ID %x", tsp.Signer, tsp.Message.Identifier[:])
 		}
+		if err := c.checkVersion(tsp); err != nil {
+			return err
+		}
 		// Verification operator signatures
 		if err := c.VerifyFunc(tsp.Signer, signedBytes, tsp.Signature); err != nil {
 			return err
@@ -300,6 +361,9 @@ func (c *Initiator) MakeMultiple(id [24]byte, allmsgs [][]byte) (*wire.MultipleS
 		if !bytes.Equal(id[:], tsp.Message.Identifier[:]) {
 			return nil, fmt.Errorf("incoming message has wrong ID, aborting... operator %d, msg ID %x", tsp.Signer, tsp.Message.Identifier[:])
 		}
+		if err := c.checkVersion(tsp); err != nil {
+			return nil, err
+		}
 		final.Messages[i] = tsp
 		allMsgsBytes = append(allMsgsBytes, msg...)
 	}
@@ -313,13 +377,9 @@ func (c *Initiator) MakeMultiple(id [24]byte, allmsgs [][]byte) (*wire.MultipleS
 	return final, nil
 }
 
-func validatedOperatorData(ids []uint64, operators Operators) ([]*wire.Operator, error) {
-	if len(ids) < 4 {
-		return nil, fmt.Errorf("minimum supported amount of operators is 4")
-	}
-	// limit amount of operators
-	if len(ids) > 13 {
-		return nil, fmt.Errorf("maximum supported amount of operators is 13")
+func validatedOperatorData(ids []uint64, operators Operators, policy threshold.Policy) ([]*wire.Operator, error) {
+	if err := policy.ValidateOperators(len(ids)); err != nil {
+		return nil, err
 	}
 
 	ops := make([]*wire.Operator, 0)
@@ -348,9 +408,9 @@ func validatedOperatorData(ids []uint64, operators Operators) ([]*wire.Operator,
 	return ops, nil
 }
 
-func (c *Initiator) messageFlowHandling(init *wire.Init, id [24]byte, operators []*wire.Operator) ([][]byte, error) {
+func (c *Initiator) messageFlowHandling(ctx context.Context, init *wire.Init, id [24]byte, operators []*wire.Operator) ([][]byte, error) {
 	c.Logger.Info("phase 1: sending init message to operators")
-	results, err := c.SendInitMsg(init, id, operators)
+	results, operators, err := c.sendInit(ctx, init, id, operators)
 	if err != nil {
 		return nil, err
 	}
@@ -359,32 +419,61 @@ func (c *Initiator) messageFlowHandling(init *wire.Init, id [24]byte, operators
 		return nil, err
 	}
 	c.Logger.Info("phase 1: ✅ verified operator init responses signatures")
+	c.completePhase(PhaseInitCompleted, operators, results)
+	return c.dkgPhases(ctx, id, operators, PhaseInitCompleted, results, false)
+}
 
-	c.Logger.Info("phase 2: ➡️ sending operator data (exchange messages) required for dkg")
-	results, err = c.SendExchangeMsgs(results, id, operators)
+// dkgPhases runs the phases of the ceremony following the given completed one, starting with the operator responses to it
+func (c *Initiator) dkgPhases(ctx context.Context, id [24]byte, operators []*wire.Operator, phase int, results [][]byte, reshare bool) ([][]byte, error) {
+	var err error
+	if phase == PhaseInitCompleted {
+		c.Logger.Info("phase 2: ➡️ sending operator data (exchange messages) required for dkg")
+		results, err = c.SendExchangeMsgs(ctx, results, id, operators)
+		if err != nil {
+			return nil, err
+		}
+		err = c.VerifyAll(id, results)
+		if err != nil {
+			return nil, err
+		}
+		c.Logger.Info("phase 2: ✅ verified operator responses (deal messages) signatures")
+		if reshare {
+			// only old operators create deals, new operators just report they are ready
+			results, err = filterKyberMsgs(results)
+			if err != nil {
+				return nil, err
+			}
+		}
+		c.completePhase(PhaseExchangeCompleted, operators, results)
+	}
+	c.Logger.Info("phase 3: ➡️ sending deal dkg data to all operators")
+	dkgResult, err := c.SendKyberMsgs(ctx, results, id, operators)
 	if err != nil {
 		return nil, err
 	}
-	err = c.VerifyAll(id, results)
+	err = c.VerifyAll(id, dkgResult)
 	if err != nil {
 		return nil, err
 	}
-	c.Logger.Info("phase 2: ✅ verified operator responses (deal messages) signatures")
-	c.Logger.Info("phase 3: ➡️ sending deal dkg data to all operators")
-	dkgResult, err := c.SendKyberMsgs(results, id, operators)
+	c.Logger.Info("phase 3: ✅ verified operator dkg results signatures")
+	return dkgResult, nil
+}
+
+func (c *Initiator) reconstructAndVerifyDepositData(init *wire.Init, validatorPubKey *bls.PublicKey, forkName string, sigDepositShares map[uint64]*bls.Sign, sharePks map[uint64]*bls.PublicKey) (*DepositDataJson, error) {
+	fork := init.Fork
+	n, err := network.ByFork(fork)
 	if err != nil {
 		return nil, err
 	}
-	err = c.VerifyAll(id, results)
+	amount := phase0.Gwei(init.Amount)
+	if err := crypto.ValidateDepositAmount(init.WithdrawalPrefix, amount); err != nil {
+		return nil, err
+	}
+	withdrawCredentials, err := crypto.WithdrawalCredentials(init.WithdrawalPrefix, init.WithdrawalCredentials)
 	if err != nil {
 		return nil, err
 	}
-	c.Logger.Info("phase 2: ✅ verified operator dkg results signatures")
-	return dkgResult, nil
-}
-
-func (c *Initiator) reconstructAndVerifyDepositData(withdrawCredentials []byte, validatorPubKey *bls.PublicKey, fork [4]byte, forkName string, sigDepositShares map[uint64]*bls.Sign, sharePks map[uint64]*bls.PublicKey) (*DepositDataJson, error) {
-	shareRoot, err := crypto.DepositDataRoot(withdrawCredentials, validatorPubKey, getNetworkByFork(fork), MaxEffectiveBalanceInGwei)
+	shareRoot, err := crypto.DepositDataRoot(withdrawCredentials, validatorPubKey, n, amount)
 	if err != nil {
 		return nil, err
 	}
@@ -413,12 +502,12 @@ func (c *Initiator) reconstructAndVerifyDepositData(withdrawCredentials []byte,
 		return nil, fmt.Errorf("deposit root signature recovered from shares is invalid")
 	}
 
-	depositData, root, err := crypto.DepositData(reconstructedDepositMasterSig.Serialize(), withdrawCredentials, validatorPubKey.Serialize(), getNetworkByFork(fork), MaxEffectiveBalanceInGwei)
+	depositData, root, err := crypto.DepositData(reconstructedDepositMasterSig.Serialize(), withdrawCredentials, validatorPubKey.Serialize(), n, amount)
 	if err != nil {
 		return nil, err
 	}
 	// Verify deposit data
-	depositVerRes, err := crypto.VerifyDepositData(depositData, getNetworkByFork(fork))
+	depositVerRes, err := crypto.VerifyDepositData(depositData, n)
 	if err != nil {
 		return nil, err
 	}
@@ -427,7 +516,7 @@ func (c *Initiator) reconstructAndVerifyDepositData(withdrawCredentials []byte,
 	}
 	depositMsg := &phase0.DepositMessage{
 		WithdrawalCredentials: depositData.WithdrawalCredentials,
-		Amount:                MaxEffectiveBalanceInGwei,
+		Amount:                amount,
 	}
 	copy(depositMsg.PublicKey[:], depositData.PublicKey[:])
 	depositMsgRoot, _ := depositMsg.HashTreeRoot()
@@ -435,17 +524,17 @@ func (c *Initiator) reconstructAndVerifyDepositData(withdrawCredentials []byte,
 	if !bytes.Equal(depositData.PublicKey[:], validatorRecoveredPK.Serialize()) {
 		return nil, fmt.Errorf("deposit data is invalid. Wrong validator public key %x", depositData.PublicKey[:])
 	}
-	if !bytes.Equal(depositData.WithdrawalCredentials, crypto.ETH1WithdrawalCredentialsHash(withdrawCredentials)) {
+	if !bytes.Equal(depositData.WithdrawalCredentials, withdrawCredentials) {
 		return nil, fmt.Errorf("deposit data is invalid. Wrong withdrawal address %x", depositData.WithdrawalCredentials)
 	}
-	if !(MaxEffectiveBalanceInGwei == depositData.Amount) {
+	if !(amount == depositData.Amount) {
 		return nil, fmt.Errorf("deposit data is invalid. Wrong amount %d", depositData.Amount)
 	}
 
 	depositDataJson := &DepositDataJson{
 		PubKey:                hex.EncodeToString(validatorPubKey.Serialize()),
 		WithdrawalCredentials: hex.EncodeToString(depositData.WithdrawalCredentials),
-		Amount:                MaxEffectiveBalanceInGwei,
+		Amount:                amount,
 		Signature:             hex.EncodeToString(reconstructedDepositMasterSig.Serialize()),
 		DepositMessageRoot:    hex.EncodeToString(depositMsgRoot[:]),
 		DepositDataRoot:       hex.EncodeToString(root[:]),
@@ -457,9 +546,10 @@ func (c *Initiator) reconstructAndVerifyDepositData(withdrawCredentials []byte,
 	return depositDataJson, nil
 }
 
-func (c *Initiator) StartDKG(id [24]byte, withdraw []byte, ids []uint64, fork [4]byte, forkName string, owner common.Address, nonce uint64) (*DepositDataJson, *KeyShares, error) {
+func (c *Initiator) StartDKG(ctx context.Context, id [24]byte, withdraw []byte, ids []uint64, fork [4]byte, forkName string, owner common.Address, nonce uint64) (*DepositDataJson, *KeyShares, error) {
+	c = c.ceremonyCopy()
 
-	ops, err := validatedOperatorData(ids, c.Operators)
+	ops, err := validatedOperatorData(ids, c.Operators, c.Policy)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -470,41 +560,86 @@ func (c *Initiator) StartDKG(id [24]byte, withdraw []byte, ids []uint64, fork [4
 		return nil, nil, err
 	}
 	c.VerifyFunc = verify
+	if err := c.negotiateVersion(ctx, ops); err != nil {
+		return nil, nil, err
+	}
 
-	pkBytes, err := crypto.EncodePublicKey(&c.PrivateKey.PublicKey)
+	init, err := c.newInit(ops, withdraw, fork, owner, nonce)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sszInit, err := init.MarshalSSZ()
 	if err != nil {
 		return nil, nil, err
 	}
+	c.state = &CeremonyState{
+		RequestID: hex.EncodeToString(id[:]),
+		Version:   c.Version,
+		Init:      hex.EncodeToString(sszInit),
+		ForkName:  forkName,
+	}
 
 	instanceIDField := zap.String("instance_id", hex.EncodeToString(id[:]))
-	c.Logger.Info("🚀 Starting dkg ceremony", zap.String("initiator_id", string(pkBytes)), zap.Uint64s("operator_ids", ids), instanceIDField)
+	c.Logger.Info("🚀 Starting dkg ceremony", zap.String("initiator_id", string(init.InitiatorPublicKey)), zap.Uint64s("operator_ids", ids), instanceIDField)
+	c.Logger = c.Logger.With(instanceIDField)
 
-	// compute threshold (3f+1)
-	threshold := len(ids) - ((len(ids) - 1) / 3)
-	// make init message
-	init := &wire.Init{
+	dkgResult, err := c.messageFlowHandling(ctx, init, id, ops)
+	if err != nil {
+		c.endCeremony(id, ops, err)
+		return nil, nil, err
+	}
+	depositData, keyShares, err := c.processDKGResult(dkgResult, id, init, forkName)
+	c.endCeremony(id, ops, err)
+	return depositData, keyShares, err
+}
+
+// newInit creates the init message of a ceremony
+func (c *Initiator) newInit(ops []*wire.Operator, withdraw []byte, fork [4]byte, owner common.Address, nonce uint64) (*wire.Init, error) {
+	if _, err := network.ByFork(fork); err != nil {
+		return nil, err
+	}
+	if _, err := crypto.WithdrawalCredentials(c.WithdrawalPrefix, withdraw); err != nil {
+		return nil, err
+	}
+	if err := crypto.ValidateDepositAmount(c.WithdrawalPrefix, c.DepositAmount); err != nil {
+		return nil, err
+	}
+	pkBytes, err := crypto.EncodePublicKey(&c.PrivateKey.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	t, err := c.threshold(len(ops))
+	if err != nil {
+		return nil, err
+	}
+	// operators reject the message after it expires, a captured message can't start a ceremony later
+	timestamp, expiry := wire.InitTime(time.Now(), wire.DefaultInitTTL)
+	return &wire.Init{
 		Operators:             ops,
-		T:                     uint64(threshold),
+		T:                     t,
 		WithdrawalCredentials: withdraw,
 		Fork:                  fork,
 		Owner:                 owner,
 		Nonce:                 nonce,
 		InitiatorPublicKey:    pkBytes,
-	}
-	c.Logger = c.Logger.With(instanceIDField)
-
-	dkgResult, err := c.messageFlowHandling(init, id, ops)
-	if err != nil {
-		return nil, nil, err
-	}
+		Amount:                uint64(c.DepositAmount),
+		WithdrawalPrefix:      c.WithdrawalPrefix,
+		Timestamp:             timestamp,
+		Expiry:                expiry,
+		TolerateOffline:       c.TolerateOffline,
+	}, nil
+}
 
+// processDKGResult verifies results of the operators and creates deposit data and keyshares of the validator
+func (c *Initiator) processDKGResult(dkgResult [][]byte, id [24]byte, init *wire.Init, forkName string) (*DepositDataJson, *KeyShares, error) {
 	dkgResults, validatorPubKey, sharePks, sigDepositShares, ssvContractOwnerNonceSigShares, err := c.ProcessDKGResultResponse(dkgResult, id)
 	if err != nil {
 		return nil, nil, err
 	}
 	c.Logger.Info("🏁 DKG completed, verifying deposit data and ssv payload")
 
-	depositDataJson, err := c.reconstructAndVerifyDepositData(init.WithdrawalCredentials, validatorPubKey, fork, forkName, sigDepositShares, sharePks)
+	depositDataJson, err := c.reconstructAndVerifyDepositData(init, validatorPubKey, forkName, sigDepositShares, sharePks)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -534,25 +669,19 @@ func (c *Initiator) StartDKG(id [24]byte, withdraw []byte, ids []uint64, fork [4
 	if err != nil {
 		return nil, nil, err
 	}
+	keyshares.Proofs, err = c.verifyProofs(dkgResults, id, init.Owner, init.Nonce)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.Logger.Info("✅ verified proofs of operators")
+	keyshares.MissingShares = missingShares(init.Operators, dkgResults)
+	if len(keyshares.MissingShares) > 0 {
+		c.Logger.Warn("⚠️ key shares miss shares of offline operators", zap.Uint64s("operators", keyshares.MissingShares))
+	}
 	c.Logger.Info("✅ verified master signature for ssv contract data")
 	return depositDataJson, keyshares, nil
 }
 
-type KeySign struct {
-	ValidatorPK ssvspec_types.ValidatorPK
-	SigningRoot []byte
-}
-
-// Encode returns a msg encoded bytes or error
-func (msg *KeySign) Encode() ([]byte, error) {
-	return json.Marshal(msg)
-}
-
-// Decode returns error if decoding failed
-func (msg *KeySign) Decode(data []byte) error {
-	return json.Unmarshal(data, msg)
-}
-
 func (c *Initiator) CreateVerifyFunc(ops []*wire.Operator) (func(id uint64, msg []byte, sig []byte) error, error) {
 	inst_ops := make(map[uint64]*rsa.PublicKey)
 	for _, op := range ops {
@@ -571,17 +700,6 @@ func (c *Initiator) CreateVerifyFunc(ops []*wire.Operator) (func(id uint64, msg
 	}, nil
 }
 
-func getNetworkByFork(fork [4]byte) eth2_key_manager_core.Network {
-	switch fork {
-	case [4]byte{0x00, 0x00, 0x10, 0x20}:
-		return eth2_key_manager_core.PraterNetwork
-	case [4]byte{0, 0, 0, 0}:
-		return eth2_key_manager_core.MainNetwork
-	default:
-		return eth2_key_manager_core.MainNetwork
-	}
-}
-
 func (c *Initiator) ProcessDKGResultResponse(responseResult [][]byte, id [24]byte) ([]dkg.Result, *bls.PublicKey, map[ssvspec_types.OperatorID]*bls.PublicKey, map[ssvspec_types.OperatorID]*bls.Sign, map[ssvspec_types.OperatorID]*bls.Sign, error) {
 	dkgResults := make([]dkg.Result, 0)
 	validatorPubKey := bls.PublicKey{}
@@ -614,6 +732,10 @@ func (c *Initiator) ProcessDKGResultResponse(responseResult [][]byte, id [24]byt
 		if !bytes.Equal(result.RequestID[:], id[:]) {
 			return nil, nil, nil, nil, nil, fmt.Errorf("DKG result has wrong ID")
 		}
+		// an operator can't return a result in the name of another one
+		if result.OperatorID != tsp.Signer {
+			return nil, nil, nil, nil, nil, fmt.Errorf("DKG result of operator %d signed by operator %d", result.OperatorID, tsp.Signer)
+		}
 		dkgResults = append(dkgResults, *result)
 		if err := validatorPubKey.Deserialize(result.ValidatorPubKey); err != nil {
 			return nil, nil, nil, nil, nil, err
@@ -638,15 +760,38 @@ func (c *Initiator) ProcessDKGResultResponse(responseResult [][]byte, id [24]byt
 	return dkgResults, &validatorPubKey, sharePks, sigDepositShares, ssvContractOwnerNonceSigShares, nil
 }
 
-func (c *Initiator) SendInitMsg(init *wire.Init, id [24]byte, operators []*wire.Operator) ([][]byte, error) {
+func (c *Initiator) SendInitMsg(ctx context.Context, init *wire.Init, id [24]byte, operators []*wire.Operator) ([][]byte, error) {
 	sszInit, err := init.MarshalSSZ()
 	if err != nil {
 		return nil, err
 	}
+	return c.sendInitMessage(ctx, wire.InitMessageType, sszInit, id, operators)
+}
+
+// sendInitMessage signs the message starting a new instance and sends it to the operators
+func (c *Initiator) sendInitMessage(ctx context.Context, msgType wire.TransportType, data []byte, id [24]byte, operators []*wire.Operator) ([][]byte, error) {
+	signedInitMsg, err := c.signMessage(msgType, data, id)
+	if err != nil {
+		return nil, err
+	}
+	signedInitMsgBts, err := signedInitMsg.MarshalSSZ()
+	if err != nil {
+		return nil, err
+	}
+	results, err := c.SendToAll(ctx, consts.API_INIT_URL, signedInitMsgBts, operators)
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// signMessage signs a message of the initiator to the operators
+func (c *Initiator) signMessage(msgType wire.TransportType, data []byte, id [24]byte) (*wire.SignedTransport, error) {
 	initMessage := &wire.Transport{
-		Type:       wire.InitMessageType,
+		Version:    c.Version,
+		Type:       msgType,
 		Identifier: id,
-		Data:       sszInit,
+		Data:       data,
 	}
 	tsssz, err := initMessage.MarshalSSZ()
 	if err != nil {
@@ -657,23 +802,14 @@ func (c *Initiator) SendInitMsg(init *wire.Init, id [24]byte, operators []*wire.
 		return nil, err
 	}
 	// Create signed init message
-	signedInitMsg := &wire.SignedTransport{
+	return &wire.SignedTransport{
 		Message:   initMessage,
 		Signer:    0,
 		Signature: sig,
-	}
-	signedInitMsgBts, err := signedInitMsg.MarshalSSZ()
-	if err != nil {
-		return nil, err
-	}
-	results, err := c.SendToAll(consts.API_INIT_URL, signedInitMsgBts, operators)
-	if err != nil {
-		return nil, err
-	}
-	return results, nil
+	}, nil
 }
 
-func (c *Initiator) SendExchangeMsgs(exchangeMsgs [][]byte, id [24]byte, operators []*wire.Operator) ([][]byte, error) {
+func (c *Initiator) SendExchangeMsgs(ctx context.Context, exchangeMsgs [][]byte, id [24]byte, operators []*wire.Operator) ([][]byte, error) {
 	mltpl, err := c.MakeMultiple(id, exchangeMsgs)
 	if err != nil {
 		return nil, err
@@ -682,14 +818,14 @@ func (c *Initiator) SendExchangeMsgs(exchangeMsgs [][]byte, id [24]byte, operato
 	if err != nil {
 		return nil, err
 	}
-	results, err := c.SendToAll(consts.API_DKG_URL, mltplbyts, operators)
+	results, err := c.SendToAll(ctx, consts.API_DKG_URL, mltplbyts, operators)
 	if err != nil {
 		return nil, err
 	}
 	return results, nil
 }
 
-func (c *Initiator) SendKyberMsgs(kyberDeals [][]byte, id [24]byte, operators []*wire.Operator) ([][]byte, error) {
+func (c *Initiator) SendKyberMsgs(ctx context.Context, kyberDeals [][]byte, id [24]byte, operators []*wire.Operator) ([][]byte, error) {
 	mltpl2, err := c.MakeMultiple(id, kyberDeals)
 	if err != nil {
 		return nil, err
@@ -699,7 +835,7 @@ func (c *Initiator) SendKyberMsgs(kyberDeals [][]byte, id [24]byte, operators []
 	if err != nil {
 		return nil, err
 	}
-	responseResult, err := c.SendToAll(consts.API_DKG_URL, mltpl2byts, operators)
+	responseResult, err := c.SendToAll(ctx, consts.API_DKG_URL, mltpl2byts, operators)
 	if err != nil {
 		return nil, err
 	}
@@ -741,13 +877,21 @@ func LoadOperatorsJson(operatorsMetaData []byte) (Operators, error) {
 }
 
 func (c *Initiator) GetThreshold(ids []uint64) (int, error) {
-	if len(ids) < 4 {
-		return 0, fmt.Errorf("minimum supported amount of operators is 4")
+	t, err := c.threshold(len(ids))
+	if err != nil {
+		return 0, err
+	}
+	return int(t), nil
+}
+
+// threshold returns the threshold of a ceremony with n operators, validated by the policy
+func (c *Initiator) threshold(n int) (uint64, error) {
+	t := c.Threshold
+	if t == 0 {
+		t = threshold.Default(n)
 	}
-	// limit amount of operators
-	if len(ids) > 13 {
-		return 0, fmt.Errorf("maximum supported amount of operators is 13")
+	if err := c.Policy.Validate(n, t); err != nil {
+		return 0, err
 	}
-	threshold := len(ids) - ((len(ids) - 1) / 3)
-	return threshold, nil
+	return t, nil
 }
@@ -2,6 +2,7 @@ package initiator
 
 import (
 	"bytes"
+	"context"
 	"crypto/rsa"
 	"encoding/hex"
 	"math/big"
@@ -18,8 +19,11 @@ import (
 
 	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
 	ourcrypto "github.com/bloxapp/ssv-dkg/pkgs/crypto"
-	ourdkg "github.com/bloxapp/ssv-dkg/pkgs/dkg"
+	"github.com/bloxapp/ssv-dkg/pkgs/dkg"
+	"github.com/bloxapp/ssv-dkg/pkgs/network"
 	"github.com/bloxapp/ssv-dkg/pkgs/operator"
+	"github.com/bloxapp/ssv-dkg/pkgs/threshold"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
 )
 
 var jsonStr = []byte(`[
@@ -70,7 +74,7 @@ func TestStartDKG(t *testing.T) {
 	t.Run("happy flow", func(t *testing.T) {
 		initiator := New(priv, ops, logger)
 		id := crypto.NewID()
-		depositData, keyshares, err := initiator.StartDKG(id, withdraw.Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
+		depositData, keyshares, err := initiator.StartDKG(context.Background(), id, withdraw.Bytes(), []uint64{1, 2, 3, 4}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
 		require.NoError(t, err)
 		VerifySharesData(t, ops, []*rsa.PrivateKey{srv1.PrivKey, srv2.PrivKey, srv3.PrivKey, srv4.PrivKey}, keyshares, owner, 0)
 		VerifyDepositData(t, depositData, withdraw.Bytes(), owner, 0)
@@ -78,19 +82,19 @@ func TestStartDKG(t *testing.T) {
 	t.Run("test wrong amount of opeators < 4", func(t *testing.T) {
 		initiator := New(priv, ops, logger)
 		id := crypto.NewID()
-		_, _, err = initiator.StartDKG(id, withdraw.Bytes(), []uint64{1, 2, 3}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
+		_, _, err = initiator.StartDKG(context.Background(), id, withdraw.Bytes(), []uint64{1, 2, 3}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
 		require.ErrorContains(t, err, "minimum supported amount of operators is 4")
 	})
 	t.Run("test wrong amount of opeators > 13", func(t *testing.T) {
 		initiator := New(priv, ops, logger)
 		id := crypto.NewID()
-		_, _, err = initiator.StartDKG(id, withdraw.Bytes(), []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
+		_, _, err = initiator.StartDKG(context.Background(), id, withdraw.Bytes(), []uint64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
 		require.ErrorContains(t, err, "maximum supported amount of operators is 13")
 	})
 	t.Run("test opeators not unique", func(t *testing.T) {
 		initiator := New(priv, ops, logger)
 		id := crypto.NewID()
-		_, _, err = initiator.StartDKG(id, withdraw.Bytes(), []uint64{1, 2, 3, 4, 5, 6, 7, 7, 9, 10, 11, 12, 12}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
+		_, _, err = initiator.StartDKG(context.Background(), id, withdraw.Bytes(), []uint64{1, 2, 3, 4, 5, 6, 7, 7, 9, 10, 11, 12, 12}, [4]byte{0, 0, 0, 0}, "mainnnet", owner, 0)
 		require.ErrorContains(t, err, "operator is not in given operator data list")
 	})
 
@@ -110,13 +114,15 @@ func VerifyDepositData(t *testing.T, depsitDataJson *DepositDataJson, withdrawCr
 	// Check root
 	var fork [4]byte
 	copy(fork[:], hexutil.MustDecode("0x"+depsitDataJson.ForkVersion))
-	depositDataRoot, err := ourcrypto.DepositDataRoot(withdrawCred, valdatorPubKey, ourdkg.GetNetworkByFork(fork), MaxEffectiveBalanceInGwei)
+	n, err := network.ByFork(fork)
+	require.NoError(t, err)
+	depositDataRoot, err := ourcrypto.DepositDataRoot(ourcrypto.ETH1WithdrawalCredentialsHash(withdrawCred), valdatorPubKey, n, MaxEffectiveBalanceInGwei)
 	require.NoError(t, err)
 	res := masterSig.VerifyByte(valdatorPubKey, depositDataRoot[:])
 	require.True(t, res)
-	depositData, _, err := ourcrypto.DepositData(masterSig.Serialize(), withdrawCred, valdatorPubKey.Serialize(), ourdkg.GetNetworkByFork(fork), MaxEffectiveBalanceInGwei)
+	depositData, _, err := ourcrypto.DepositData(masterSig.Serialize(), ourcrypto.ETH1WithdrawalCredentialsHash(withdrawCred), valdatorPubKey.Serialize(), n, MaxEffectiveBalanceInGwei)
 	require.NoError(t, err)
-	res, err = ourcrypto.VerifyDepositData(depositData, ourdkg.GetNetworkByFork(fork))
+	res, err = ourcrypto.VerifyDepositData(depositData, n)
 	require.NoError(t, err)
 	require.True(t, res)
 	depositMsg := &phase0.DepositMessage{
@@ -270,7 +276,7 @@ func TestValidateDKGParams(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			res, err := validatedOperatorData(tt.ids, tt.ops)
+			res, err := validatedOperatorData(tt.ids, tt.ops, threshold.DefaultPolicy)
 			if tt.wantErr {
 				if err == nil {
 					t.Errorf("expected error but got none")
@@ -298,3 +304,31 @@ func TestValidateDKGParams(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessDKGResultResponse(t *testing.T) {
+	_, pv, err := rsaencryption.GenerateKeys()
+	require.NoError(t, err)
+	priv, err := rsaencryption.ConvertPemToPrivateKey(string(pv))
+	require.NoError(t, err)
+	c := New(priv, nil, zap.L())
+	id := crypto.NewID()
+	t.Run("test result in the name of another operator", func(t *testing.T) {
+		result := &dkg.Result{OperatorID: 2, RequestID: id}
+		data, err := result.Encode()
+		require.NoError(t, err)
+		tsp := &wire.SignedTransport{
+			Message: &wire.Transport{
+				Version:    wire.ProtocolVersion,
+				Type:       wire.OutputMessageType,
+				Identifier: id,
+				Data:       data,
+			},
+			Signer:    1,
+			Signature: make([]byte, 256),
+		}
+		res, err := tsp.MarshalSSZ()
+		require.NoError(t, err)
+		_, _, _, _, _, err = c.ProcessDKGResultResponse([][]byte{res}, id)
+		require.EqualError(t, err, "DKG result of operator 2 signed by operator 1")
+	})
+}
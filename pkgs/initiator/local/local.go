@@ -0,0 +1,139 @@
+// Package local delivers messages of an initiator to operators running in the same process,
+// calling their switches directly instead of posting to their HTTP routes
+package local
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/consts"
+	"github.com/bloxapp/ssv-dkg/pkgs/initiator"
+	"github.com/bloxapp/ssv-dkg/pkgs/operator"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+)
+
+// Transport calls the switches of operators by their IDs. Operators reject messages the same way the HTTP routes do,
+// responding with an SSZ encoded wire.ErrSSZ.
+type Transport struct {
+	mtx      sync.RWMutex
+	switches map[uint64]*operator.Switch
+	// Fault is called before a message is delivered to an operator or a route of the operator is fetched, if set.
+	// A returned error is returned instead of delivering the message, as if the operator were unreachable.
+	Fault func(operatorID uint64, method string, payload []byte) error
+}
+
+// NewTransport creates a transport delivering messages to the switches of operators by their IDs
+func NewTransport(switches map[uint64]*operator.Switch) *Transport {
+	t := &Transport{switches: make(map[uint64]*operator.Switch, len(switches))}
+	for id, s := range switches {
+		t.switches[id] = s
+	}
+	return t
+}
+
+// Add adds the switch of an operator to the transport
+func (t *Transport) Add(id uint64, s *operator.Switch) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.switches[id] = s
+}
+
+// Remove removes the switch of an operator, messages to it fail as if it were offline
+func (t *Transport) Remove(id uint64) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	delete(t.switches, id)
+}
+
+func (t *Transport) Send(ctx context.Context, op initiator.Operator, method string, payload []byte) ([]byte, error) {
+	s, err := t.reach(ctx, op, method, payload)
+	if err != nil {
+		return nil, err
+	}
+	res, err := deliver(ctx, s, method, payload)
+	if err != nil {
+		return wire.MakeErr(err), nil
+	}
+	return res, nil
+}
+
+func (t *Transport) SendToAll(ctx context.Context, ops []initiator.Operator, method string, payload []byte) []initiator.Response {
+	return initiator.SendConcurrently(ctx, t, ops, method, payload)
+}
+
+// Get serves the health and identity routes from the switch, Fault is called with the route and a nil payload
+func (t *Transport) Get(ctx context.Context, op initiator.Operator, route string) ([]byte, error) {
+	s, err := t.reach(ctx, op, route, nil)
+	if err != nil {
+		return nil, err
+	}
+	switch route {
+	case consts.API_HEALTH_URL:
+		return json.Marshal(map[string]string{"status": "ok"})
+	case consts.API_IDENTITY_URL:
+		identity, err := s.Identity()
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(identity)
+	default:
+		return nil, fmt.Errorf("route %s isn't served in process", route)
+	}
+}
+
+// reach returns the switch of the operator, failing as an unreachable operator would
+func (t *Transport) reach(ctx context.Context, op initiator.Operator, method string, payload []byte) (*operator.Switch, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	t.mtx.RLock()
+	s, ok := t.switches[op.ID]
+	t.mtx.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("operator %d is not running in this process", op.ID)
+	}
+	if t.Fault != nil {
+		if err := t.Fault(op.ID, method, payload); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// deliver calls the switch like the operator route of the method does
+func deliver(ctx context.Context, s *operator.Switch, method string, payload []byte) ([]byte, error) {
+	switch method {
+	case consts.API_INIT_URL:
+		signedInitMsg := &wire.SignedTransport{}
+		if err := signedInitMsg.UnmarshalSSZ(payload); err != nil {
+			return nil, err
+		}
+		if signedInitMsg.Message.Type != wire.InitMessageType && signedInitMsg.Message.Type != wire.InitReshareMessageType {
+			return nil, errors.New("not init message to init route")
+		}
+		return s.InitInstance(signedInitMsg.Message.Identifier, signedInitMsg.Message, signedInitMsg.Signature)
+	case consts.API_INIT_BATCH_URL:
+		return s.InitInstances(payload)
+	case consts.API_DKG_URL:
+		return s.ProcessMessage(ctx, payload)
+	case consts.API_DKG_BATCH_URL:
+		return s.ProcessBatchMessage(ctx, payload)
+	case consts.API_CANCEL_URL:
+		signedCancelMsg := &wire.SignedTransport{}
+		if err := signedCancelMsg.UnmarshalSSZ(payload); err != nil {
+			return nil, err
+		}
+		return s.CancelInstance(signedCancelMsg.Message, signedCancelMsg.Signature)
+	case consts.API_SIGN_URL:
+		signedSignMsg := &wire.SignedTransport{}
+		if err := signedSignMsg.UnmarshalSSZ(payload); err != nil {
+			return nil, err
+		}
+		return s.ProcessSignRequest(signedSignMsg.Message, signedSignMsg.Signature)
+	default:
+		return nil, fmt.Errorf("unknown method %s", method)
+	}
+}
@@ -0,0 +1,12 @@
+package initiator
+
+import (
+	"github.com/prometheus/client_golang/prometheus/push"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/metrics"
+)
+
+// PushMetrics pushes the latency and errors of requests to every operator to a Prometheus Pushgateway
+func PushMetrics(url string) error {
+	return push.New(url, "ssv_dkg_initiator").Gatherer(metrics.NewInitiatorRegistry()).Push()
+}
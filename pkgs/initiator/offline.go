@@ -0,0 +1,73 @@
+package initiator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/consts"
+	"github.com/bloxapp/ssv-dkg/pkgs/dkg"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+)
+
+// ErrOfflineNotSupported is returned by ceremonies which need all operators online if offline operators are tolerated
+var ErrOfflineNotSupported = errors.New("offline operators are tolerated only by a ceremony creating a single validator")
+
+// sendInit sends the init message to the operators. If offline operators are tolerated, the ceremony goes on with the
+// operators which responded as long as they meet the threshold. The operators of the ceremony are returned.
+func (c *Initiator) sendInit(ctx context.Context, init *wire.Init, id [24]byte, operators []*wire.Operator) ([][]byte, []*wire.Operator, error) {
+	if !c.TolerateOffline {
+		results, err := c.SendInitMsg(ctx, init, id, operators)
+		return results, operators, err
+	}
+	sszInit, err := init.MarshalSSZ()
+	if err != nil {
+		return nil, nil, err
+	}
+	signedInitMsg, err := c.signMessage(wire.InitMessageType, sszInit, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	signedInitMsgBts, err := signedInitMsg.MarshalSSZ()
+	if err != nil {
+		return nil, nil, err
+	}
+	results := make([][]byte, 0, len(operators))
+	online := make(map[uint64]bool)
+	// operators responding with an error aren't offline, their responses fail the ceremony
+	for _, res := range c.sendToAll(ctx, consts.API_INIT_URL, signedInitMsgBts, operators) {
+		if res.Err != nil {
+			c.Logger.Warn("⚠️ operator is offline, going on without it", zap.Uint64("operator", res.OperatorID), zap.Error(res.Err))
+			continue
+		}
+		online[res.OperatorID] = true
+		results = append(results, res.Result)
+	}
+	onlineOps := make([]*wire.Operator, 0, len(online))
+	for _, op := range operators {
+		if online[op.ID] {
+			onlineOps = append(onlineOps, op)
+		}
+	}
+	if uint64(len(onlineOps)) < init.T {
+		return nil, nil, fmt.Errorf("%d of %d operators are online, threshold %d can't be met", len(onlineOps), len(operators), init.T)
+	}
+	return results, onlineOps, nil
+}
+
+// missingShares returns IDs of the operators of the ceremony without a result
+func missingShares(ops []*wire.Operator, results []dkg.Result) []uint64 {
+	withResult := make(map[uint64]bool, len(results))
+	for _, res := range results {
+		withResult[res.OperatorID] = true
+	}
+	var missing []uint64
+	for _, op := range ops {
+		if !withResult[op.ID] {
+			missing = append(missing, op.ID)
+		}
+	}
+	return missing
+}
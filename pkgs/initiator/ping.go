@@ -0,0 +1,88 @@
+package initiator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/consts"
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+)
+
+// PingResult is the outcome of checking an operator before a ceremony
+type PingResult struct {
+	ID   uint64
+	Addr string
+	// Identity served by the operator, nil if it couldn't be fetched
+	Identity *wire.Identity
+	// Err is set if the operator is unreachable, unhealthy or its identity doesn't match operators info
+	Err error
+}
+
+// Ping checks health and identity of every known operator, results are sorted by operator ID
+func (c *Initiator) Ping(ctx context.Context) []PingResult {
+	ids := make([]uint64, 0, len(c.Operators))
+	for id := range c.Operators {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	results := make([]PingResult, len(ids))
+	done := make(chan struct{}, len(ids))
+	for i, id := range ids {
+		go func(i int, op Operator) {
+			identity, err := c.ping(ctx, op)
+			results[i] = PingResult{ID: op.ID, Addr: op.Addr, Identity: identity, Err: err}
+			done <- struct{}{}
+		}(i, c.Operators[id])
+	}
+	for range ids {
+		<-done
+	}
+	return results
+}
+
+func (c *Initiator) ping(ctx context.Context, op Operator) (*wire.Identity, error) {
+	if _, err := c.get(ctx, op, consts.API_HEALTH_URL); err != nil {
+		return nil, fmt.Errorf("operator %d is not healthy: %w", op.ID, err)
+	}
+	identity, err := c.identity(ctx, op)
+	if err != nil {
+		return nil, err
+	}
+	return identity, checkIdentity(op, identity)
+}
+
+func (c *Initiator) identity(ctx context.Context, op Operator) (*wire.Identity, error) {
+	body, err := c.get(ctx, op, consts.API_IDENTITY_URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get identity of operator %d: %w", op.ID, err)
+	}
+	identity := &wire.Identity{}
+	if err := json.Unmarshal(body, identity); err != nil {
+		return nil, fmt.Errorf("failed to parse identity of operator %d: %w", op.ID, err)
+	}
+	return identity, nil
+}
+
+func (c *Initiator) get(ctx context.Context, op Operator, route string) ([]byte, error) {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+	return c.Transport.Get(ctx, op, route)
+}
+
+// checkIdentity verifies the operator serves the public key of operators info and supports a protocol version of the initiator
+func checkIdentity(op Operator, identity *wire.Identity) error {
+	pubKey, err := crypto.ParseRSAPubkey([]byte(identity.PubKey))
+	if err != nil {
+		return fmt.Errorf("failed to parse public key of operator %d: %w", op.ID, err)
+	}
+	if !pubKey.Equal(op.PubKey) {
+		return fmt.Errorf("public key mismatch: operator %d at %s serves a key different from operators info", op.ID, op.Addr)
+	}
+	if _, err := wire.NegotiateVersion(identity.ProtocolVersions); err != nil {
+		return fmt.Errorf("operator %d supports protocol versions %v: %w", op.ID, identity.ProtocolVersions, err)
+	}
+	return nil
+}
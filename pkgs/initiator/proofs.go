@@ -0,0 +1,154 @@
+package initiator
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/dkg"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+)
+
+// SignedProof is a proof of an operator that it created a key share of the validator at a ceremony,
+// signed by the RSA key of the operator
+type SignedProof struct {
+	OperatorID      uint64 `json:"operatorId"`
+	SharePubKey     string `json:"sharePubKey"`
+	ValidatorPubKey string `json:"validatorPubKey"`
+	Owner           string `json:"owner"`
+	Nonce           uint64 `json:"nonce"`
+	RequestID       string `json:"requestId"`
+	Signature       string `json:"signature"`
+}
+
+// Verify verifies the signature of the proof by the RSA key of the operator
+func (p *SignedProof) Verify(operatorKey *rsa.PublicKey) error {
+	proof, sig, err := p.decode()
+	if err != nil {
+		return err
+	}
+	byts, err := proof.MarshalSSZ()
+	if err != nil {
+		return err
+	}
+	if err := crypto.VerifyRSA(operatorKey, byts, sig); err != nil {
+		return fmt.Errorf("proof signature of operator %d isn't valid: %w", p.OperatorID, err)
+	}
+	return nil
+}
+
+func (p *SignedProof) decode() (*wire.Proof, []byte, error) {
+	sharePubKey, err := hex.DecodeString(strings.TrimPrefix(p.SharePubKey, "0x"))
+	if err != nil {
+		return nil, nil, err
+	}
+	validatorPubKey, err := hex.DecodeString(strings.TrimPrefix(p.ValidatorPubKey, "0x"))
+	if err != nil {
+		return nil, nil, err
+	}
+	if !common.IsHexAddress(p.Owner) {
+		return nil, nil, fmt.Errorf("wrong owner address %s", p.Owner)
+	}
+	reqID, err := hex.DecodeString(strings.TrimPrefix(p.RequestID, "0x"))
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(reqID) != 24 {
+		return nil, nil, fmt.Errorf("wrong request ID length %d", len(reqID))
+	}
+	sig, err := hex.DecodeString(strings.TrimPrefix(p.Signature, "0x"))
+	if err != nil {
+		return nil, nil, err
+	}
+	proof := &wire.Proof{
+		OperatorID:      p.OperatorID,
+		SharePubKey:     sharePubKey,
+		ValidatorPubKey: validatorPubKey,
+		Owner:           common.HexToAddress(p.Owner),
+		Nonce:           p.Nonce,
+	}
+	copy(proof.RequestID[:], reqID)
+	return proof, sig, nil
+}
+
+func encodeProof(sp *wire.SignedProof) *SignedProof {
+	return &SignedProof{
+		OperatorID:      sp.Proof.OperatorID,
+		SharePubKey:     "0x" + hex.EncodeToString(sp.Proof.SharePubKey),
+		ValidatorPubKey: "0x" + hex.EncodeToString(sp.Proof.ValidatorPubKey),
+		Owner:           common.Address(sp.Proof.Owner).String(),
+		Nonce:           sp.Proof.Nonce,
+		RequestID:       "0x" + hex.EncodeToString(sp.Proof.RequestID[:]),
+		Signature:       "0x" + hex.EncodeToString(sp.Signature),
+	}
+}
+
+// verifyProofs checks that every result has a proof of the operator which binds its share to the ceremony
+func (c *Initiator) verifyProofs(results []dkg.Result, id [24]byte, owner common.Address, nonce uint64) ([]*SignedProof, error) {
+	proofs := make([]*SignedProof, 0, len(results))
+	for _, result := range results {
+		sp := result.Proof
+		if sp == nil || sp.Proof == nil {
+			return nil, fmt.Errorf("operator %d returned no proof", result.OperatorID)
+		}
+		p := sp.Proof
+		if p.OperatorID != result.OperatorID || !bytes.Equal(p.SharePubKey, result.SharePubKey) || !bytes.Equal(p.ValidatorPubKey, result.ValidatorPubKey) ||
+			p.Owner != owner || p.Nonce != nonce || p.RequestID != id {
+			return nil, fmt.Errorf("proof of operator %d doesn't match the ceremony", result.OperatorID)
+		}
+		op, ok := c.Operators[result.OperatorID]
+		if !ok {
+			return nil, fmt.Errorf("operator %d is not in operators info", result.OperatorID)
+		}
+		proof := encodeProof(sp)
+		if err := proof.Verify(op.PubKey); err != nil {
+			return nil, err
+		}
+		proofs = append(proofs, proof)
+	}
+	return proofs, nil
+}
+
+// ValidateProofs checks that the proofs are signed by the operators of the key shares and bind their shares
+// to the validator, owner and nonce
+func ValidateProofs(proofs []*SignedProof, data Data, payload Payload, owner common.Address, nonce uint64) error {
+	sharesData, err := hex.DecodeString(strings.TrimPrefix(payload.SharesData, "0x"))
+	if err != nil {
+		return err
+	}
+	// proofs of several validators may be given
+	byID := make(map[uint64]*SignedProof, len(proofs))
+	for _, p := range proofs {
+		if strings.EqualFold(p.ValidatorPubKey, payload.PublicKey) {
+			byID[p.OperatorID] = p
+		}
+	}
+	for i, op := range data.Operators {
+		p, ok := byID[op.ID]
+		if !ok {
+			return fmt.Errorf("no proof of operator %d", op.ID)
+		}
+		operatorKey, err := crypto.ParseRSAPubkey([]byte(op.OperatorKey))
+		if err != nil {
+			return fmt.Errorf("wrong public key of operator %d: %w", op.ID, err)
+		}
+		if err := p.Verify(operatorKey); err != nil {
+			return err
+		}
+		start := phase0.SignatureLength + i*phase0.PublicKeyLength
+		if len(sharesData) < start+phase0.PublicKeyLength {
+			return fmt.Errorf("malformed ssv share data")
+		}
+		if !strings.EqualFold(p.SharePubKey, "0x"+hex.EncodeToString(sharesData[start:start+phase0.PublicKeyLength])) ||
+			!strings.EqualFold(p.Owner, owner.String()) || p.Nonce != nonce {
+			return fmt.Errorf("proof of operator %d doesn't match the key shares", op.ID)
+		}
+	}
+	return nil
+}
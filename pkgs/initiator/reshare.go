@@ -0,0 +1,238 @@
+package initiator
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	eth_crypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/dkg"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+)
+
+// StartReshare reshares the key of an existing validator from the old operators to the new ones.
+// The validator public key stays the same, the resulting key shares should be registered with the new operators.
+func (c *Initiator) StartReshare(ctx context.Context, id [24]byte, oldIDs, newIDs []uint64, validatorPK []byte, oldThreshold uint64, owner common.Address, nonce uint64) (*KeyShares, error) {
+	c = c.ceremonyCopy()
+	if c.TolerateOffline {
+		return nil, ErrOfflineNotSupported
+	}
+	if len(validatorPK) != 48 {
+		return nil, fmt.Errorf("wrong validator public key length %d", len(validatorPK))
+	}
+	oldOps, err := validatedOperatorData(oldIDs, c.Operators, c.Policy)
+	if err != nil {
+		return nil, fmt.Errorf("old operators: %w", err)
+	}
+	newOps, err := validatedOperatorData(newIDs, c.Operators, c.Policy)
+	if err != nil {
+		return nil, fmt.Errorf("new operators: %w", err)
+	}
+	if oldThreshold == 0 || oldThreshold > uint64(len(oldOps)) {
+		return nil, fmt.Errorf("wrong old threshold %d for %d old operators", oldThreshold, len(oldOps))
+	}
+	pkBytes, err := crypto.EncodePublicKey(&c.PrivateKey.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	newThreshold, err := c.threshold(len(newOps))
+	if err != nil {
+		return nil, fmt.Errorf("new operators: %w", err)
+	}
+	timestamp, expiry := wire.InitTime(time.Now(), wire.DefaultInitTTL)
+	reshare := &wire.Reshare{
+		ValidatorPubKey:    validatorPK,
+		OldOperators:       oldOps,
+		NewOperators:       newOps,
+		OldT:               oldThreshold,
+		NewT:               newThreshold,
+		Owner:              owner,
+		Nonce:              nonce,
+		InitiatorPublicKey: pkBytes,
+		Timestamp:          timestamp,
+		Expiry:             expiry,
+	}
+	ops := dkg.ReshareOperators(reshare)
+
+	// Add messages verification coming form operators
+	verify, err := c.CreateVerifyFunc(ops)
+	if err != nil {
+		return nil, err
+	}
+	c.VerifyFunc = verify
+	if err := c.negotiateVersion(ctx, ops); err != nil {
+		return nil, err
+	}
+
+	sszReshare, err := reshare.MarshalSSZ()
+	if err != nil {
+		return nil, err
+	}
+	c.state = &CeremonyState{
+		RequestID: hex.EncodeToString(id[:]),
+		Version:   c.Version,
+		Reshare:   hex.EncodeToString(sszReshare),
+	}
+
+	instanceIDField := zap.String("instance_id", hex.EncodeToString(id[:]))
+	c.Logger.Info("🚀 Starting resharing ceremony", zap.String("initiator_id", string(pkBytes)), zap.Uint64s("old_operator_ids", oldIDs), zap.Uint64s("new_operator_ids", newIDs), instanceIDField)
+	c.Logger = c.Logger.With(instanceIDField)
+
+	dkgResult, err := c.reshareMessageFlowHandling(ctx, reshare, id, ops)
+	if err != nil {
+		c.endCeremony(id, ops, err)
+		return nil, err
+	}
+	keyShares, err := c.finishReshare(dkgResult, id, reshare)
+	c.endCeremony(id, ops, err)
+	return keyShares, err
+}
+
+// finishReshare verifies results of the new operators and creates keyshares of the validator
+func (c *Initiator) finishReshare(dkgResult [][]byte, id [24]byte, reshare *wire.Reshare) (*KeyShares, error) {
+	validatorPK, owner, nonce := reshare.ValidatorPubKey, common.Address(reshare.Owner), reshare.Nonce
+	results, sharePks, ownerNonceSigShares, err := c.processReshareResultResponse(dkgResult, id, reshare)
+	if err != nil {
+		return nil, err
+	}
+	c.Logger.Info("🏁 Resharing completed, verifying ssv payload")
+
+	validatorRecoveredPK, err := crypto.RecoverValidatorPublicKey(sharePks)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(validatorRecoveredPK.Serialize(), validatorPK) {
+		return nil, fmt.Errorf("validator pub key recovered from new shares is not equal to the reshared one: want %x, got %x", validatorPK, validatorRecoveredPK.Serialize())
+	}
+	data := []byte(fmt.Sprintf("%s:%d", owner.String(), nonce))
+	hash := eth_crypto.Keccak256([]byte(data))
+	if err := crypto.VerifyPartialSigs(ownerNonceSigShares, sharePks, hash); err != nil {
+		return nil, err
+	}
+	reconstructedOwnerNonceMasterSig, err := crypto.RecoverMasterSig(ownerNonceSigShares)
+	if err != nil {
+		return nil, err
+	}
+	if err := crypto.VerifyOwnerNoceSignature(reconstructedOwnerNonceMasterSig.Serialize(), owner, validatorPK, uint16(nonce)); err != nil {
+		return nil, err
+	}
+	c.Logger.Info("✅ verified owner and nonce master signature")
+	keyshares, err := GeneratePayload(results, reconstructedOwnerNonceMasterSig.Serialize())
+	if err != nil {
+		return nil, err
+	}
+	keyshares.Proofs, err = c.verifyProofs(results, id, owner, nonce)
+	if err != nil {
+		return nil, err
+	}
+	c.Logger.Info("✅ verified proofs of operators")
+	return keyshares, nil
+}
+
+// SendReshareMsg sends the initial resharing message to all old and new operators
+func (c *Initiator) SendReshareMsg(ctx context.Context, reshare *wire.Reshare, id [24]byte, operators []*wire.Operator) ([][]byte, error) {
+	sszReshare, err := reshare.MarshalSSZ()
+	if err != nil {
+		return nil, err
+	}
+	return c.sendInitMessage(ctx, wire.InitReshareMessageType, sszReshare, id, operators)
+}
+
+func (c *Initiator) reshareMessageFlowHandling(ctx context.Context, reshare *wire.Reshare, id [24]byte, operators []*wire.Operator) ([][]byte, error) {
+	c.Logger.Info("phase 1: sending reshare message to operators")
+	results, err := c.SendReshareMsg(ctx, reshare, id, operators)
+	if err != nil {
+		return nil, err
+	}
+	err = c.VerifyAll(id, results)
+	if err != nil {
+		return nil, err
+	}
+	c.Logger.Info("phase 1: ✅ verified operator reshare responses signatures")
+
+	c.completePhase(PhaseInitCompleted, operators, results)
+	return c.dkgPhases(ctx, id, operators, PhaseInitCompleted, results, true)
+}
+
+func filterKyberMsgs(msgs [][]byte) ([][]byte, error) {
+	deals := make([][]byte, 0, len(msgs))
+	for _, msg := range msgs {
+		tsp := &wire.SignedTransport{}
+		if err := tsp.UnmarshalSSZ(msg); err != nil {
+			return nil, err
+		}
+		if tsp.Message.Type == wire.KyberMessageType {
+			deals = append(deals, msg)
+		}
+	}
+	return deals, nil
+}
+
+// processReshareResultResponse collects results of the new operators. Old operators leaving the cluster
+// only confirm the end of the resharing.
+func (c *Initiator) processReshareResultResponse(responseResult [][]byte, id [24]byte, reshare *wire.Reshare) ([]dkg.Result, map[uint64]*bls.PublicKey, map[uint64]*bls.Sign, error) {
+	newOps := make(map[uint64]struct{}, len(reshare.NewOperators))
+	for _, op := range reshare.NewOperators {
+		newOps[op.ID] = struct{}{}
+	}
+	results := make([]dkg.Result, 0, len(reshare.NewOperators))
+	sharePks := make(map[uint64]*bls.PublicKey)
+	ownerNonceSigShares := make(map[uint64]*bls.Sign)
+	for _, msg := range responseResult {
+		tsp := &wire.SignedTransport{}
+		if err := tsp.UnmarshalSSZ(msg); err != nil {
+			return nil, nil, nil, err
+		}
+		if tsp.Message.Type == wire.ErrorMessageType {
+			var msgErr string
+			if err := json.Unmarshal(tsp.Message.Data, &msgErr); err != nil {
+				return nil, nil, nil, err
+			}
+			return nil, nil, nil, fmt.Errorf("operator %d returned err: %s", tsp.Signer, msgErr)
+		}
+		if tsp.Message.Type != wire.OutputMessageType {
+			return nil, nil, nil, fmt.Errorf("wrong resharing result message type")
+		}
+		result := &dkg.Result{}
+		if err := result.Decode(tsp.Message.Data); err != nil {
+			return nil, nil, nil, err
+		}
+		if !bytes.Equal(result.RequestID[:], id[:]) {
+			return nil, nil, nil, fmt.Errorf("resharing result has wrong ID")
+		}
+		if result.OperatorID != tsp.Signer {
+			return nil, nil, nil, fmt.Errorf("resharing result of operator %d signed by operator %d", result.OperatorID, tsp.Signer)
+		}
+		if _, ok := newOps[result.OperatorID]; !ok {
+			c.Logger.Debug("Old operator finished resharing", zap.Uint64("ID", result.OperatorID))
+			continue
+		}
+		if !bytes.Equal(result.ValidatorPubKey, reshare.ValidatorPubKey) {
+			return nil, nil, nil, fmt.Errorf("operator %d returned wrong validator public key %x", result.OperatorID, result.ValidatorPubKey)
+		}
+		sharePubKey := &bls.PublicKey{}
+		if err := sharePubKey.Deserialize(result.SharePubKey); err != nil {
+			return nil, nil, nil, err
+		}
+		ownerNonceShareSig := &bls.Sign{}
+		if err := ownerNonceShareSig.Deserialize(result.OwnerNoncePartialSignature); err != nil {
+			return nil, nil, nil, err
+		}
+		sharePks[result.OperatorID] = sharePubKey
+		ownerNonceSigShares[result.OperatorID] = ownerNonceShareSig
+		results = append(results, *result)
+		c.Logger.Debug("Received resharing result from operator", zap.Uint64("ID", result.OperatorID))
+	}
+	if len(results) != len(reshare.NewOperators) {
+		return nil, nil, nil, fmt.Errorf("expected results from %d new operators, got %d", len(reshare.NewOperators), len(results))
+	}
+	return results, sharePks, ownerNonceSigShares, nil
+}
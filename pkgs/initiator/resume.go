@@ -0,0 +1,292 @@
+package initiator
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/consts"
+	"github.com/bloxapp/ssv-dkg/pkgs/dkg"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+)
+
+// Phases of a ceremony completed by the initiator
+const (
+	// PhaseInitCompleted the operators responded to the init message with their exchange messages
+	PhaseInitCompleted = 1
+	// PhaseExchangeCompleted the operators responded to the exchange messages with their deals
+	PhaseExchangeCompleted = 2
+)
+
+// CeremonyState is the state of a ceremony saved by the initiator after every completed phase,
+// an interrupted ceremony is resumed from it
+type CeremonyState struct {
+	RequestID string `json:"requestId"`
+	// Version of the wire protocol negotiated with the operators
+	Version uint64 `json:"version"`
+	// Init is the SSZ encoded init message of a ceremony creating a validator
+	Init string `json:"init,omitempty"`
+	// ForkName is the network name of the deposit data of a ceremony creating a validator
+	ForkName string `json:"forkName,omitempty"`
+	// Reshare is the SSZ encoded message of a resharing ceremony
+	Reshare string `json:"reshare,omitempty"`
+	// Operators of the ceremony, operators offline at init are left out if they are tolerated
+	Operators []uint64 `json:"operators"`
+	// Phase is the last completed phase
+	Phase int `json:"phase"`
+	// Responses of the operators to the last completed phase
+	Responses []string `json:"responses"`
+}
+
+// LoadCeremonyState reads the state of a ceremony saved by the initiator
+func LoadCeremonyState(path string) (*CeremonyState, error) {
+	byts, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	st := &CeremonyState{}
+	if err := json.Unmarshal(byts, st); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+// ID returns the request ID of the ceremony
+func (st *CeremonyState) ID() ([24]byte, error) {
+	var id [24]byte
+	reqID, err := hex.DecodeString(st.RequestID)
+	if err != nil {
+		return id, err
+	}
+	if len(reqID) != len(id) {
+		return id, fmt.Errorf("wrong request ID length %d", len(reqID))
+	}
+	copy(id[:], reqID)
+	return id, nil
+}
+
+// completePhase saves the state of the ceremony after a completed phase if a state path is set
+func (c *Initiator) completePhase(phase int, operators []*wire.Operator, responses [][]byte) {
+	if c.state == nil || c.StatePath == "" {
+		return
+	}
+	c.state.Phase = phase
+	c.state.Operators = make([]uint64, 0, len(operators))
+	for _, op := range operators {
+		c.state.Operators = append(c.state.Operators, op.ID)
+	}
+	c.state.Responses = make([]string, 0, len(responses))
+	for _, resp := range responses {
+		c.state.Responses = append(c.state.Responses, hex.EncodeToString(resp))
+	}
+	byts, err := json.MarshalIndent(c.state, "", "  ")
+	if err == nil {
+		err = os.WriteFile(c.StatePath, byts, 0o600)
+	}
+	if err != nil {
+		c.Logger.Warn("Failed saving ceremony state, the ceremony can't be resumed", zap.String("path", c.StatePath), zap.Error(err))
+		return
+	}
+	c.Logger.Debug("saved ceremony state", zap.String("path", c.StatePath), zap.Int("phase", phase))
+}
+
+// resumable tells if the state of the ceremony is saved after a completed phase
+func (c *Initiator) resumable() bool {
+	if c.state == nil || c.state.Phase == 0 || c.StatePath == "" {
+		return false
+	}
+	_, err := os.Stat(c.StatePath)
+	return err == nil
+}
+
+// endCeremony removes the state of a ceremony which finished successfully. A failed ceremony which can't be resumed
+// is aborted, so the operators don't hold its instances until they expire. The ceremony is aborted even if its
+// context is done.
+func (c *Initiator) endCeremony(id [24]byte, operators []*wire.Operator, err error) {
+	if err == nil {
+		if c.resumable() {
+			if err := os.Remove(c.StatePath); err != nil {
+				c.Logger.Warn("Failed removing ceremony state", zap.String("path", c.StatePath), zap.Error(err))
+			}
+		}
+		return
+	}
+	if c.resumable() {
+		c.Logger.Info("⏸️ ceremony state is saved, the ceremony can be resumed or aborted", zap.String("path", c.StatePath), zap.Int("phase", c.state.Phase))
+		return
+	}
+	ctx, cancel := c.abortContext()
+	defer cancel()
+	if err := c.Abort(ctx, id, operators); err != nil {
+		c.Logger.Debug("not all operators aborted the ceremony", zap.Error(err))
+	}
+}
+
+// abortContext bounds aborting a ceremony by the request timeout, or DefaultRequestTimeout if it isn't set
+func (c *Initiator) abortContext() (context.Context, context.CancelFunc) {
+	if c.RequestTimeout > 0 {
+		return context.WithTimeout(context.Background(), c.RequestTimeout)
+	}
+	return context.WithTimeout(context.Background(), DefaultRequestTimeout)
+}
+
+// Abort sends the cancel message of the ceremony to the operators, they drop their instances right away
+// instead of holding them until they expire
+func (c *Initiator) Abort(ctx context.Context, id [24]byte, operators []*wire.Operator) error {
+	signedCancel, err := c.signMessage(wire.CancelMessageType, nil, id)
+	if err != nil {
+		return err
+	}
+	signedCancelBts, err := signedCancel.MarshalSSZ()
+	if err != nil {
+		return err
+	}
+	c.Logger.Info("🛑 cancelling the ceremony at operators")
+	var errs []error
+	for _, res := range c.sendToAll(ctx, consts.API_CANCEL_URL, signedCancelBts, operators) {
+		if res.Err != nil {
+			errs = append(errs, fmt.Errorf("operator %d: %w", res.OperatorID, res.Err))
+			continue
+		}
+		if len(res.Result) > 0 {
+			if msgErr, parseErr := parseAsError(res.Result); parseErr == nil {
+				errs = append(errs, fmt.Errorf("operator %d: %w", res.OperatorID, msgErr))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Resume continues an interrupted ceremony from the last completed phase of its state. Operators respond again
+// with their responses to the messages they processed before the interruption. Deposit data is returned only
+// by a ceremony creating a validator.
+func (c *Initiator) Resume(ctx context.Context, st *CeremonyState) (*DepositDataJson, *KeyShares, error) {
+	c = c.ceremonyCopy()
+	id, ops, err := c.loadState(st)
+	if err != nil {
+		return nil, nil, err
+	}
+	if st.Phase != PhaseInitCompleted && st.Phase != PhaseExchangeCompleted {
+		return nil, nil, fmt.Errorf("ceremony can't be resumed after phase %d", st.Phase)
+	}
+	init, reshare, err := st.messages()
+	if err != nil {
+		return nil, nil, err
+	}
+	results := make([][]byte, 0, len(st.Responses))
+	for _, resp := range st.Responses {
+		byts, err := hex.DecodeString(resp)
+		if err != nil {
+			return nil, nil, fmt.Errorf("wrong response at ceremony state: %w", err)
+		}
+		results = append(results, byts)
+	}
+	instanceIDField := zap.String("instance_id", st.RequestID)
+	c.Logger.Info("⏯️ Resuming ceremony", zap.Int("completed_phase", st.Phase), zap.Uint64s("operator_ids", st.Operators), instanceIDField)
+	c.Logger = c.Logger.With(instanceIDField)
+
+	dkgResult, err := c.dkgPhases(ctx, id, ops, st.Phase, results, reshare != nil)
+	if err != nil {
+		c.endCeremony(id, ops, err)
+		return nil, nil, err
+	}
+	if reshare != nil {
+		keyShares, err := c.finishReshare(dkgResult, id, reshare)
+		c.endCeremony(id, ops, err)
+		return nil, keyShares, err
+	}
+	depositData, keyShares, err := c.processDKGResult(dkgResult, id, init, st.ForkName)
+	c.endCeremony(id, ops, err)
+	return depositData, keyShares, err
+}
+
+// AbortState aborts the ceremony of the state at its operators and removes the state.
+// The state is removed even if some operators fail to abort, their instances expire anyway.
+func (c *Initiator) AbortState(ctx context.Context, st *CeremonyState) error {
+	c = c.ceremonyCopy()
+	id, ops, err := c.loadState(st)
+	if err != nil {
+		return err
+	}
+	err = c.Abort(ctx, id, ops)
+	if c.StatePath != "" {
+		if rmErr := os.Remove(c.StatePath); rmErr != nil {
+			err = errors.Join(err, rmErr)
+		}
+	}
+	return err
+}
+
+// messages decodes the init message of a ceremony creating a validator or the message of a resharing ceremony
+func (st *CeremonyState) messages() (*wire.Init, *wire.Reshare, error) {
+	switch {
+	case st.Init != "" && st.Reshare == "":
+		byts, err := hex.DecodeString(st.Init)
+		if err != nil {
+			return nil, nil, fmt.Errorf("wrong init message at ceremony state: %w", err)
+		}
+		init := &wire.Init{}
+		if err := init.UnmarshalSSZ(byts); err != nil {
+			return nil, nil, fmt.Errorf("wrong init message at ceremony state: %w", err)
+		}
+		return init, nil, nil
+	case st.Reshare != "" && st.Init == "":
+		byts, err := hex.DecodeString(st.Reshare)
+		if err != nil {
+			return nil, nil, fmt.Errorf("wrong reshare message at ceremony state: %w", err)
+		}
+		reshare := &wire.Reshare{}
+		if err := reshare.UnmarshalSSZ(byts); err != nil {
+			return nil, nil, fmt.Errorf("wrong reshare message at ceremony state: %w", err)
+		}
+		return nil, reshare, nil
+	default:
+		return nil, nil, fmt.Errorf("ceremony state should have either an init or a reshare message")
+	}
+}
+
+// loadState restores the initiator state of the ceremony and returns its request ID and operators
+func (c *Initiator) loadState(st *CeremonyState) ([24]byte, []*wire.Operator, error) {
+	id, err := st.ID()
+	if err != nil {
+		return id, nil, err
+	}
+	init, reshare, err := st.messages()
+	if err != nil {
+		return id, nil, err
+	}
+	var ceremonyOps []*wire.Operator
+	if reshare != nil {
+		ceremonyOps = dkg.ReshareOperators(reshare)
+	} else {
+		ceremonyOps = init.Operators
+	}
+	byID := make(map[uint64]*wire.Operator, len(ceremonyOps))
+	for _, op := range ceremonyOps {
+		byID[op.ID] = op
+	}
+	ops := make([]*wire.Operator, 0, len(st.Operators))
+	for _, opID := range st.Operators {
+		op, ok := byID[opID]
+		if !ok {
+			return id, nil, fmt.Errorf("operator %d is not part of the ceremony", opID)
+		}
+		if _, ok := c.Operators[opID]; !ok {
+			return id, nil, fmt.Errorf("operator %d is not in operators info", opID)
+		}
+		ops = append(ops, op)
+	}
+	verify, err := c.CreateVerifyFunc(ops)
+	if err != nil {
+		return id, nil, err
+	}
+	c.VerifyFunc = verify
+	c.Version = st.Version
+	c.state = st
+	return id, ops, nil
+}
@@ -0,0 +1,196 @@
+package initiator
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/consts"
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+)
+
+// ThresholdSign requests partial signatures of the signing root from the operators holding the key shares of the
+// validator with threshold t and recovers the validator signature, the key itself is never reconstructed.
+// Partial signatures are verified against the share public keys of the key shares, invalid ones are dropped.
+// Only the initiator who created the validator can request signatures.
+func (c *Initiator) ThresholdSign(ctx context.Context, id [24]byte, ks *KeyShares, t uint64, signingRoot []byte) (*bls.Sign, error) {
+	c = c.ceremonyCopy()
+	ids := ks.Payload.OperatorIDs
+	ops, err := validatedOperatorData(ids, c.Operators, c.Policy)
+	if err != nil {
+		return nil, err
+	}
+	if t == 0 || t > uint64(len(ops)) {
+		return nil, fmt.Errorf("wrong threshold %d for %d operators", t, len(ops))
+	}
+	validatorPK, err := hex.DecodeString(strings.TrimPrefix(ks.Payload.PublicKey, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("wrong validator public key: %w", err)
+	}
+	validatorPubKey := &bls.PublicKey{}
+	if err := validatorPubKey.Deserialize(validatorPK); err != nil {
+		return nil, fmt.Errorf("wrong validator public key: %w", err)
+	}
+	sharePKs, err := sharePubKeys(ks.Payload)
+	if err != nil {
+		return nil, err
+	}
+	verify, err := c.CreateVerifyFunc(ops)
+	if err != nil {
+		return nil, err
+	}
+	c.VerifyFunc = verify
+	if err := c.negotiateVersion(ctx, ops); err != nil {
+		return nil, err
+	}
+	timestamp, expiry := wire.InitTime(time.Now(), wire.DefaultInitTTL)
+	keySign := &wire.KeySign{
+		ValidatorPK: validatorPK,
+		SigningRoot: signingRoot,
+		Operators:   ids,
+		T:           t,
+		Timestamp:   timestamp,
+		Expiry:      expiry,
+	}
+	data, err := keySign.MarshalSSZ()
+	if err != nil {
+		return nil, err
+	}
+	signMessage := &wire.Transport{
+		Version:    c.Version,
+		Type:       wire.BlsSignRequestType,
+		Identifier: id,
+		Data:       data,
+	}
+	signMessageBts, err := signMessage.MarshalSSZ()
+	if err != nil {
+		return nil, err
+	}
+	sig, err := crypto.SignRSA(c.PrivateKey, signMessageBts)
+	if err != nil {
+		return nil, err
+	}
+	signedSignMessage := &wire.SignedTransport{
+		Message:   signMessage,
+		Signer:    0,
+		Signature: sig,
+	}
+	signedSignMessageBts, err := signedSignMessage.MarshalSSZ()
+	if err != nil {
+		return nil, err
+	}
+	c.Logger.Info("➡️ sending signing request to operators", zap.Uint64s("operator_ids", ids))
+	// signing can succeed without some of the operators, as long as the threshold holds
+	results, sendErr := c.SendToAll(ctx, consts.API_SIGN_URL, signedSignMessageBts, ops)
+	errs := make([]error, 0)
+	if sendErr != nil {
+		errs = append(errs, sendErr)
+	}
+	partialSigs := make(map[uint64]*bls.Sign)
+	for _, res := range results {
+		operatorID, partialSig, err := c.processPartialSignature(res, id, keySign, sharePKs)
+		if err != nil {
+			c.Logger.Warn("dropping partial signature", zap.Error(err))
+			errs = append(errs, err)
+			continue
+		}
+		partialSigs[operatorID] = partialSig
+	}
+	if uint64(len(partialSigs)) < t {
+		return nil, fmt.Errorf("%d valid partial signatures received, threshold %d: %w", len(partialSigs), t, errors.Join(errs...))
+	}
+	// every partial signature is verified by its share, any t of them recover the validator signature
+	signers := make([]uint64, 0, len(partialSigs))
+	for operatorID := range partialSigs {
+		signers = append(signers, operatorID)
+	}
+	sort.Slice(signers, func(i, j int) bool { return signers[i] < signers[j] })
+	thresholdSigs := make(map[uint64]*bls.Sign, t)
+	for _, operatorID := range signers[:t] {
+		thresholdSigs[operatorID] = partialSigs[operatorID]
+	}
+	masterSig, err := crypto.RecoverMasterSig(thresholdSigs)
+	if err != nil {
+		return nil, err
+	}
+	if !masterSig.VerifyByte(validatorPubKey, signingRoot) {
+		return nil, fmt.Errorf("signature recovered from %d partial signatures is invalid, the share public keys don't match the validator", len(thresholdSigs))
+	}
+	c.Logger.Info("✅ successfully reconstructed validator signature from partial signatures", zap.Int("partial_signatures", len(partialSigs)))
+	return masterSig, nil
+}
+
+func (c *Initiator) processPartialSignature(res []byte, id [24]byte, keySign *wire.KeySign, sharePKs map[uint64]*bls.PublicKey) (uint64, *bls.Sign, error) {
+	tsp := &wire.SignedTransport{}
+	if err := tsp.UnmarshalSSZ(res); err != nil {
+		errmsg, parseErr := parseAsError(res)
+		if parseErr == nil {
+			return 0, nil, fmt.Errorf("operator returned err: %v", errmsg)
+		}
+		return 0, nil, err
+	}
+	signedBytes, err := tsp.Message.MarshalSSZ()
+	if err != nil {
+		return 0, nil, err
+	}
+	if err := c.VerifyFunc(tsp.Signer, signedBytes, tsp.Signature); err != nil {
+		return 0, nil, fmt.Errorf("operator %d: %w", tsp.Signer, err)
+	}
+	if tsp.Message.Type != wire.BlsSignResponseType || !bytes.Equal(tsp.Message.Identifier[:], id[:]) {
+		return 0, nil, fmt.Errorf("operator %d: wrong response to signing request", tsp.Signer)
+	}
+	if err := c.checkVersion(tsp); err != nil {
+		return 0, nil, err
+	}
+	partialSig := &wire.PartialSignature{}
+	if err := partialSig.UnmarshalSSZ(tsp.Message.Data); err != nil {
+		return 0, nil, err
+	}
+	if !bytes.Equal(partialSig.ValidatorPK, keySign.ValidatorPK) || !bytes.Equal(partialSig.SigningRoot, keySign.SigningRoot) {
+		return 0, nil, fmt.Errorf("operator %d: signed wrong data", tsp.Signer)
+	}
+	// the share public key of the response is chosen by the operator, the one of the key shares is verified against
+	sharePK, ok := sharePKs[tsp.Signer]
+	if !ok {
+		return 0, nil, fmt.Errorf("operator %d: no share at the key shares", tsp.Signer)
+	}
+	sig := &bls.Sign{}
+	if err := sig.Deserialize(partialSig.Signature); err != nil {
+		return 0, nil, err
+	}
+	if !sig.VerifyByte(sharePK, keySign.SigningRoot) {
+		return 0, nil, fmt.Errorf("operator %d: partial signature is invalid", tsp.Signer)
+	}
+	return tsp.Signer, sig, nil
+}
+
+// sharePubKeys returns the share public keys of the key shares payload by operator ID
+func sharePubKeys(payload Payload) (map[uint64]*bls.PublicKey, error) {
+	sharesData, err := hex.DecodeString(strings.TrimPrefix(payload.SharesData, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	if len(sharesData) < phase0.SignatureLength+phase0.PublicKeyLength*len(payload.OperatorIDs) {
+		return nil, fmt.Errorf("malformed ssv share data")
+	}
+	sharePKs := make(map[uint64]*bls.PublicKey, len(payload.OperatorIDs))
+	for i, id := range payload.OperatorIDs {
+		start := phase0.SignatureLength + i*phase0.PublicKeyLength
+		sharePK := &bls.PublicKey{}
+		if err := sharePK.Deserialize(sharesData[start : start+phase0.PublicKeyLength]); err != nil {
+			return nil, fmt.Errorf("wrong share public key of operator %d: %w", id, err)
+		}
+		sharePKs[id] = sharePK
+	}
+	return sharePKs, nil
+}
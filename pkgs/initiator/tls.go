@@ -0,0 +1,92 @@
+package initiator
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+)
+
+// TLSOptions configure TLS of requests to operators
+type TLSOptions struct {
+	// CAPath are PEM encoded certificate authorities of operator certificates, system authorities are used if not set
+	CAPath string
+	// Pinned verifies operator certificates are issued by the operator RSA keys of operators info
+	// instead of certificate authorities, operators serve them with pinned certificates
+	Pinned bool
+	// CertPath and KeyPath are the PEM encoded client certificate and key of the initiator, used by operators
+	// requiring mTLS
+	CertPath string
+	KeyPath  string
+}
+
+// SetTLS configures TLS of requests to operators, operators addresses should be https
+func (c *Initiator) SetTLS(opts TLSOptions) error {
+	config := c.Client.GetTLSClientConfig()
+	config.MinVersion = tls.VersionTLS12
+	if opts.CAPath != "" {
+		rootCAs, err := crypto.LoadCertPool(opts.CAPath)
+		if err != nil {
+			return fmt.Errorf("failed to load certificate authorities: %w", err)
+		}
+		config.RootCAs = rootCAs
+	}
+	if opts.CertPath != "" || opts.KeyPath != "" {
+		cert, err := tls.LoadX509KeyPair(opts.CertPath, opts.KeyPath)
+		if err != nil {
+			return fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	if !opts.Pinned {
+		return nil
+	}
+	if opts.CAPath != "" {
+		return fmt.Errorf("either pinned certificates or certificate authorities should be used, not both")
+	}
+	pins := make(map[string]*rsa.PublicKey, len(c.Operators))
+	for id, op := range c.Operators {
+		addr, err := tlsAddr(op.Addr)
+		if err != nil {
+			return fmt.Errorf("operator %d: %w", id, err)
+		}
+		pins[addr] = op.PubKey
+	}
+	c.Client.SetDialTLS(func(ctx context.Context, network, addr string) (net.Conn, error) {
+		pk, ok := pins[addr]
+		if !ok {
+			return nil, fmt.Errorf("no operator at %s to verify its certificate", addr)
+		}
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		pinned := config.Clone()
+		pinned.ServerName = host
+		// the certificate is verified by the operator key instead of certificate authorities
+		pinned.InsecureSkipVerify = true
+		pinned.VerifyPeerCertificate = crypto.VerifyPinnedCertificate(pk)
+		dialer := &tls.Dialer{Config: pinned}
+		return dialer.DialContext(ctx, network, addr)
+	})
+	return nil
+}
+
+// tlsAddr returns the host and port the initiator dials to reach an https operator address
+func tlsAddr(opAddr string) (string, error) {
+	u, err := url.Parse(opAddr)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "https" {
+		return "", fmt.Errorf("address %s isn't https", opAddr)
+	}
+	if u.Port() == "" {
+		return net.JoinHostPort(u.Hostname(), "443"), nil
+	}
+	return u.Host, nil
+}
@@ -0,0 +1,137 @@
+package initiator
+
+import (
+	"crypto/rsa"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+)
+
+// Transcript records the requests of the initiator to operators and their responses, as SSZ encoded
+// signed transports. It is signed by the initiator when the ceremonies are over.
+type Transcript struct {
+	// InitiatorPublicKey is the RSA public key of the initiator, a base64 encoded PEM
+	InitiatorPublicKey string `json:"initiatorPublicKey"`
+	// Operators which received requests
+	Operators []OperatorData      `json:"operators"`
+	Messages  []TranscriptMessage `json:"messages"`
+	CreatedAt time.Time           `json:"createdAt"`
+	// Signature of the initiator of the JSON encoded transcript without the signature, hex encoded
+	Signature string `json:"signature"`
+
+	mtx sync.Mutex
+}
+
+// TranscriptMessage is a request to an operator and its response, hex encoded
+type TranscriptMessage struct {
+	Operator uint64 `json:"operator"`
+	Route    string `json:"route"`
+	Request  string `json:"request"`
+	Response string `json:"response,omitempty"`
+	// Error is set if the operator didn't respond
+	Error string `json:"error,omitempty"`
+}
+
+// NewTranscript creates an empty transcript, the initiator records its requests to operators at it
+func NewTranscript() *Transcript {
+	return &Transcript{Messages: []TranscriptMessage{}}
+}
+
+func (t *Transcript) add(operatorID uint64, route string, request, response []byte, err error) {
+	msg := TranscriptMessage{
+		Operator: operatorID,
+		Route:    route,
+		Request:  hex.EncodeToString(request),
+		Response: hex.EncodeToString(response),
+	}
+	if err != nil {
+		msg.Error = err.Error()
+	}
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.Messages = append(t.Messages, msg)
+}
+
+// SignTranscript adds the initiator public key and the operators which received requests to the transcript and signs it
+func (c *Initiator) SignTranscript() error {
+	if c.Transcript == nil {
+		return fmt.Errorf("transcript isn't recorded")
+	}
+	t := c.Transcript
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	pubKey, err := crypto.EncodePublicKey(&c.PrivateKey.PublicKey)
+	if err != nil {
+		return err
+	}
+	t.InitiatorPublicKey = string(pubKey)
+	t.Operators = nil
+	seen := make(map[uint64]bool)
+	for _, msg := range t.Messages {
+		if seen[msg.Operator] {
+			continue
+		}
+		seen[msg.Operator] = true
+		op, ok := c.Operators[msg.Operator]
+		if !ok {
+			return fmt.Errorf("operator %d is not in operators info", msg.Operator)
+		}
+		opKey, err := crypto.EncodePublicKey(op.PubKey)
+		if err != nil {
+			return err
+		}
+		t.Operators = append(t.Operators, OperatorData{ID: op.ID, OperatorKey: string(opKey)})
+	}
+	sort.Slice(t.Operators, func(i, j int) bool { return t.Operators[i].ID < t.Operators[j].ID })
+	t.CreatedAt = time.Now().UTC()
+	t.Signature = ""
+	data, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	sig, err := crypto.SignRSA(c.PrivateKey, data)
+	if err != nil {
+		return err
+	}
+	t.Signature = hex.EncodeToString(sig)
+	return nil
+}
+
+// verifySignature verifies the signature of the transcript by the initiator
+func (t *Transcript) verifySignature() (*rsa.PublicKey, error) {
+	initiatorPubKey, err := crypto.ParseRSAPubkey([]byte(t.InitiatorPublicKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse initiator public key: %w", err)
+	}
+	sig, err := hex.DecodeString(t.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode transcript signature: %w", err)
+	}
+	unsigned := &Transcript{
+		InitiatorPublicKey: t.InitiatorPublicKey,
+		Operators:          t.Operators,
+		Messages:           t.Messages,
+		CreatedAt:          t.CreatedAt,
+	}
+	data, err := json.Marshal(unsigned)
+	if err != nil {
+		return nil, err
+	}
+	if err := crypto.VerifyRSA(initiatorPubKey, data, sig); err != nil {
+		return nil, fmt.Errorf("transcript signature isn't valid: %w", err)
+	}
+	return initiatorPubKey, nil
+}
+
+// record adds the request to the transcript if it's recorded
+func (c *Initiator) record(operatorID uint64, route string, request, response []byte, err error) {
+	if c.Transcript == nil {
+		return
+	}
+	c.Transcript.add(operatorID, route, request, response, err)
+}
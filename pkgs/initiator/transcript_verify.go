@@ -0,0 +1,661 @@
+package initiator
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/drand/kyber"
+	bls3 "github.com/drand/kyber-bls12381"
+	"github.com/drand/kyber/share"
+	kyber_dkg "github.com/drand/kyber/share/dkg"
+	drand_bls "github.com/drand/kyber/sign/bls"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/consts"
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/dkg"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+)
+
+// TranscriptReport is the outcome of the verification of a transcript
+type TranscriptReport struct {
+	// Signatures is the number of verified RSA signatures
+	Signatures int
+	// Bundles is the number of verified kyber deal, response and justification bundles
+	Bundles int
+	// Operators are the RSA public keys of the operators by ID, pinned to the operators info
+	Operators  map[uint64]*rsa.PublicKey
+	Ceremonies []*CeremonyReport
+	// OperatorErrors are the error responses of operators to requests
+	OperatorErrors []string
+}
+
+// CeremonyReport is the outcome of a DKG or resharing ceremony as follows from the transcript
+type CeremonyReport struct {
+	RequestID [24]byte
+	Reshare   bool
+	// ValidatorPubKey is computed from the public polynomials of the dealers, nil if the ceremony didn't deal
+	ValidatorPubKey []byte
+	// SharePubKeys are computed from the public polynomials of the dealers, by operator ID
+	SharePubKeys map[uint64][]byte
+	// Outputs are the IDs of the operators whose results match the computed keys
+	Outputs []uint64
+	// Errors reported by operators during the ceremony
+	Errors []string
+	// Cancelled is set if the initiator cancelled the ceremony at the operators
+	Cancelled bool
+}
+
+type transcriptCeremony struct {
+	id        [24]byte
+	init      *wire.Init
+	reshare   *wire.Reshare
+	exchanges map[uint64][]byte
+	kyber     []*signedKyberMessage
+	outputs   map[uint64]*dkg.Result
+	errors    []string
+	cancelled bool
+	// messages by signer and type, to detect conflicting messages
+	messages map[string][]byte
+}
+
+type signedKyberMessage struct {
+	signer uint64
+	msg    *wire.KyberMessage
+}
+
+type transcriptVerifier struct {
+	initiator  *rsa.PublicKey
+	operators  map[uint64]*rsa.PublicKey
+	ceremonies map[[24]byte]*transcriptCeremony
+	order      [][24]byte
+	report     *TranscriptReport
+}
+
+// VerifyTranscript verifies the signature of the initiator of the transcript, the RSA signature of every message
+// and every kyber bundle at it, and computes the keys resulting from every ceremony. Results of operators
+// have to match the computed keys. The operator keys of the transcript are chosen by its initiator, they have to match
+// the keys of the operators, i.e. from the operators info, which the transcript is verified against.
+func VerifyTranscript(t *Transcript, operators Operators) (*TranscriptReport, error) {
+	if len(operators) == 0 {
+		return nil, fmt.Errorf("operators to verify the transcript against are required")
+	}
+	initiatorPubKey, err := t.verifySignature()
+	if err != nil {
+		return nil, err
+	}
+	v := &transcriptVerifier{
+		initiator:  initiatorPubKey,
+		operators:  make(map[uint64]*rsa.PublicKey),
+		ceremonies: make(map[[24]byte]*transcriptCeremony),
+		report:     &TranscriptReport{Signatures: 1},
+	}
+	for _, op := range t.Operators {
+		pubKey, err := crypto.ParseRSAPubkey([]byte(op.OperatorKey))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key of operator %d: %w", op.ID, err)
+		}
+		pinned, ok := operators[op.ID]
+		if !ok {
+			return nil, fmt.Errorf("operator %d is not in operators info", op.ID)
+		}
+		if pinned.PubKey == nil || !pinned.PubKey.Equal(pubKey) {
+			return nil, fmt.Errorf("public key of operator %d doesn't match operators info", op.ID)
+		}
+		v.operators[op.ID] = pubKey
+	}
+	v.report.Operators = v.operators
+	for i, msg := range t.Messages {
+		if err := v.message(msg); err != nil {
+			return nil, fmt.Errorf("message %d to operator %d at %s: %w", i, msg.Operator, msg.Route, err)
+		}
+	}
+	for _, id := range v.order {
+		c, err := v.ceremony(v.ceremonies[id])
+		if err != nil {
+			return nil, fmt.Errorf("ceremony %x: %w", id, err)
+		}
+		if c != nil {
+			v.report.Ceremonies = append(v.report.Ceremonies, c)
+		}
+	}
+	return v.report, nil
+}
+
+func (v *transcriptVerifier) message(msg TranscriptMessage) error {
+	if _, ok := v.operators[msg.Operator]; !ok {
+		return fmt.Errorf("operator %d is not in transcript operators", msg.Operator)
+	}
+	request, err := hex.DecodeString(msg.Request)
+	if err != nil {
+		return err
+	}
+	if err := v.request(msg.Route, request); err != nil {
+		return fmt.Errorf("request: %w", err)
+	}
+	if msg.Error != "" {
+		return nil
+	}
+	response, err := hex.DecodeString(msg.Response)
+	if err != nil {
+		return err
+	}
+	if err := v.response(msg.Operator, msg.Route, response); err != nil {
+		return fmt.Errorf("response: %w", err)
+	}
+	return nil
+}
+
+func (v *transcriptVerifier) request(route string, data []byte) error {
+	switch route {
+	case consts.API_INIT_URL, consts.API_SIGN_URL:
+		st := &wire.SignedTransport{}
+		if err := st.UnmarshalSSZ(data); err != nil {
+			return err
+		}
+		return v.initiatorMessage(st)
+	case consts.API_CANCEL_URL:
+		st := &wire.SignedTransport{}
+		if err := st.UnmarshalSSZ(data); err != nil {
+			return err
+		}
+		if st.Message == nil || st.Message.Type != wire.CancelMessageType {
+			return fmt.Errorf("not a cancel message to the cancel route")
+		}
+		return v.initiatorMessage(st)
+	case consts.API_INIT_BATCH_URL:
+		batch := &wire.BatchSignedTransports{}
+		if err := batch.UnmarshalSSZ(data); err != nil {
+			return err
+		}
+		for _, st := range batch.Messages {
+			if err := v.initiatorMessage(st); err != nil {
+				return err
+			}
+		}
+		return nil
+	case consts.API_DKG_URL:
+		multiple := &wire.MultipleSignedTransports{}
+		if err := multiple.UnmarshalSSZ(data); err != nil {
+			return err
+		}
+		return v.multiple(multiple)
+	case consts.API_DKG_BATCH_URL:
+		batch := &wire.BatchMultipleSignedTransports{}
+		if err := batch.UnmarshalSSZ(data); err != nil {
+			return err
+		}
+		for _, multiple := range batch.Messages {
+			if err := v.multiple(multiple); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown route %s", route)
+	}
+}
+
+func (v *transcriptVerifier) initiatorMessage(st *wire.SignedTransport) error {
+	if st.Signer != 0 {
+		return fmt.Errorf("message of operator %d where initiator message is expected", st.Signer)
+	}
+	return v.signed(st)
+}
+
+// multiple verifies the signature of the initiator of the relayed messages and the messages themselves
+func (v *transcriptVerifier) multiple(multiple *wire.MultipleSignedTransports) error {
+	var allMsgsBytes []byte
+	for _, st := range multiple.Messages {
+		if st.Signer == 0 {
+			return fmt.Errorf("initiator message relayed as an operator message")
+		}
+		if !bytes.Equal(st.Message.Identifier[:], multiple.Identifier[:]) {
+			return fmt.Errorf("relayed message of operator %d has wrong ID %x", st.Signer, st.Message.Identifier[:])
+		}
+		byts, err := st.MarshalSSZ()
+		if err != nil {
+			return err
+		}
+		allMsgsBytes = append(allMsgsBytes, byts...)
+	}
+	if err := crypto.VerifyRSA(v.initiator, allMsgsBytes, multiple.Signature); err != nil {
+		return fmt.Errorf("initiator signature of relayed messages isn't valid: %w", err)
+	}
+	v.report.Signatures++
+	for _, st := range multiple.Messages {
+		if err := v.signed(st); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *transcriptVerifier) response(operatorID uint64, route string, data []byte) error {
+	if route == consts.API_CANCEL_URL {
+		// operators acknowledge a cancel with an empty response
+		if len(data) > 0 {
+			v.operatorError(operatorID, data)
+		}
+		return nil
+	}
+	if route == consts.API_INIT_BATCH_URL || route == consts.API_DKG_BATCH_URL {
+		batch := &wire.BatchResponse{}
+		if err := batch.UnmarshalSSZ(data); err != nil {
+			v.operatorError(operatorID, data)
+			return nil
+		}
+		for _, res := range batch.Responses {
+			if err := v.operatorMessage(operatorID, res); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return v.operatorMessage(operatorID, data)
+}
+
+func (v *transcriptVerifier) operatorMessage(operatorID uint64, data []byte) error {
+	st := &wire.SignedTransport{}
+	if err := st.UnmarshalSSZ(data); err != nil {
+		v.operatorError(operatorID, data)
+		return nil
+	}
+	if st.Signer != operatorID {
+		return fmt.Errorf("response of operator %d is signed by %d", operatorID, st.Signer)
+	}
+	return v.signed(st)
+}
+
+// operatorError records a response which isn't a signed message, an error of the operator
+func (v *transcriptVerifier) operatorError(operatorID uint64, data []byte) {
+	errmsg, err := parseAsError(data)
+	if err != nil {
+		errmsg = fmt.Errorf("%s", strings.TrimSpace(string(data)))
+	}
+	v.report.OperatorErrors = append(v.report.OperatorErrors, fmt.Sprintf("operator %d: %v", operatorID, errmsg))
+}
+
+// signed verifies the RSA signature of the message and adds it to its ceremony
+func (v *transcriptVerifier) signed(st *wire.SignedTransport) error {
+	if st.Message == nil {
+		return fmt.Errorf("empty message")
+	}
+	pubKey := v.initiator
+	if st.Signer != 0 {
+		var ok bool
+		pubKey, ok = v.operators[st.Signer]
+		if !ok {
+			return fmt.Errorf("message signed by operator %d which is not in transcript operators", st.Signer)
+		}
+	}
+	msgBytes, err := st.Message.MarshalSSZ()
+	if err != nil {
+		return err
+	}
+	if err := crypto.VerifyRSA(pubKey, msgBytes, st.Signature); err != nil {
+		return fmt.Errorf("signature of %s by %d isn't valid: %w", st.Message.Type, st.Signer, err)
+	}
+	v.report.Signatures++
+
+	id := st.Message.Identifier
+	c, ok := v.ceremonies[id]
+	if !ok {
+		c = &transcriptCeremony{
+			id:        id,
+			exchanges: make(map[uint64][]byte),
+			outputs:   make(map[uint64]*dkg.Result),
+			messages:  make(map[string][]byte),
+		}
+		v.ceremonies[id] = c
+		v.order = append(v.order, id)
+	}
+	return c.add(st.Signer, st.Message, msgBytes)
+}
+
+func (c *transcriptCeremony) add(signer uint64, t *wire.Transport, msgBytes []byte) error {
+	key := fmt.Sprintf("%d/%s", signer, t.Type)
+	var kyberMsg *wire.KyberMessage
+	if t.Type == wire.KyberMessageType {
+		kyberMsg = &wire.KyberMessage{}
+		if err := kyberMsg.UnmarshalSSZ(t.Data); err != nil {
+			return err
+		}
+		key += "/" + kyberMsg.Type.String()
+	}
+	if prev, ok := c.messages[key]; ok {
+		if !bytes.Equal(prev, msgBytes) {
+			return fmt.Errorf("conflicting %s messages of %d", t.Type, signer)
+		}
+		return nil
+	}
+	c.messages[key] = msgBytes
+
+	switch t.Type {
+	case wire.InitMessageType:
+		c.init = &wire.Init{}
+		return c.init.UnmarshalSSZ(t.Data)
+	case wire.InitReshareMessageType:
+		c.reshare = &wire.Reshare{}
+		return c.reshare.UnmarshalSSZ(t.Data)
+	case wire.CancelMessageType:
+		c.cancelled = true
+	case wire.ExchangeMessageType:
+		exch := &wire.Exchange{}
+		if err := exch.UnmarshalSSZ(t.Data); err != nil {
+			return err
+		}
+		c.exchanges[signer] = exch.PK
+	case wire.ReshareExchangeMessageType:
+		exch := &wire.ReshareExchange{}
+		if err := exch.UnmarshalSSZ(t.Data); err != nil {
+			return err
+		}
+		c.exchanges[signer] = exch.PK
+	case wire.KyberMessageType:
+		c.kyber = append(c.kyber, &signedKyberMessage{signer: signer, msg: kyberMsg})
+	case wire.OutputMessageType:
+		res := &dkg.Result{}
+		if err := res.Decode(t.Data); err != nil {
+			return err
+		}
+		if res.OperatorID != signer || !bytes.Equal(res.RequestID[:], c.id[:]) {
+			return fmt.Errorf("result of operator %d doesn't match its message", signer)
+		}
+		c.outputs[signer] = res
+	case wire.ErrorMessageType:
+		var errmsg string
+		if err := json.Unmarshal(t.Data, &errmsg); err != nil {
+			errmsg = string(t.Data)
+		}
+		c.errors = append(c.errors, fmt.Sprintf("operator %d: %s", signer, errmsg))
+	}
+	return nil
+}
+
+// ceremony verifies the kyber bundles of a ceremony and computes the resulting keys, nil if it's not a DKG or resharing
+func (v *transcriptVerifier) ceremony(c *transcriptCeremony) (*CeremonyReport, error) {
+	if c.init == nil && c.reshare == nil {
+		return nil, nil
+	}
+	report := &CeremonyReport{
+		RequestID:    c.id,
+		Reshare:      c.reshare != nil,
+		SharePubKeys: make(map[uint64][]byte),
+		Errors:       c.errors,
+		Cancelled:    c.cancelled,
+	}
+	suite := bls3.NewBLS12381Suite()
+	g := suite.G1().(kyber_dkg.Suite)
+
+	var newOps, oldOps []*wire.Operator
+	var t int
+	if c.reshare != nil {
+		newOps, oldOps, t = c.reshare.NewOperators, c.reshare.OldOperators, int(c.reshare.NewT)
+	} else {
+		newOps, t = c.init.Operators, int(c.init.T)
+	}
+	newNodes, err := transcriptNodes(g, newOps, c.exchanges)
+	if err != nil {
+		return nil, err
+	}
+	config := &kyber_dkg.Config{NewNodes: newNodes, Auth: drand_bls.NewSchemeOnG2(suite)}
+	if c.reshare != nil {
+		if config.OldNodes, err = transcriptNodes(g, oldOps, c.exchanges); err != nil {
+			return nil, err
+		}
+	}
+
+	nonce := wire.GetNonce(c.id[:])
+	deals := make(map[uint32]*kyber_dkg.DealBundle)
+	complaints := make(map[uint32]bool)
+	justified := make(map[uint32]bool)
+	for _, km := range c.kyber {
+		var packet kyber_dkg.Packet
+		var index uint32
+		var sessionID []byte
+		switch km.msg.Type {
+		case wire.KyberDealBundleMessageType:
+			b, err := wire.DecodeDealBundle(km.msg.Data, g)
+			if err != nil {
+				return nil, err
+			}
+			if len(b.Public) != t {
+				return nil, fmt.Errorf("deal bundle of operator %d has %d commitments, threshold is %d", km.signer, len(b.Public), t)
+			}
+			packet, index, sessionID = b, b.DealerIndex, b.SessionID
+			deals[b.DealerIndex] = b
+		case wire.KyberResponseBundleMessageType:
+			b, err := wire.DecodeResponseBundle(km.msg.Data)
+			if err != nil {
+				return nil, err
+			}
+			packet, index, sessionID = b, b.ShareIndex, b.SessionID
+			for _, r := range b.Responses {
+				if r.Status == kyber_dkg.Complaint {
+					complaints[r.DealerIndex] = true
+				}
+			}
+		case wire.KyberJustificationBundleMessageType:
+			b, err := wire.DecodeJustificationBundle(km.msg.Data, g)
+			if err != nil {
+				return nil, err
+			}
+			packet, index, sessionID = b, b.DealerIndex, b.SessionID
+			justified[b.DealerIndex] = true
+		default:
+			return nil, fmt.Errorf("unknown kyber message type %s", km.msg.Type)
+		}
+		if uint64(index) != km.signer-1 {
+			return nil, fmt.Errorf("%s of operator %d has index %d", km.msg.Type, km.signer, index)
+		}
+		if !bytes.Equal(sessionID, nonce) {
+			return nil, fmt.Errorf("%s of operator %d has wrong session ID", km.msg.Type, km.signer)
+		}
+		if err := kyber_dkg.VerifyPacketSignature(config, packet); err != nil {
+			return nil, fmt.Errorf("%s of operator %d: %w", km.msg.Type, km.signer, err)
+		}
+		v.report.Bundles++
+	}
+
+	// dealers which were complained against and didn't justify are disqualified
+	qual := make([]uint32, 0, len(deals))
+	for index := range deals {
+		if complaints[index] && !justified[index] {
+			continue
+		}
+		qual = append(qual, index)
+	}
+	sort.Slice(qual, func(i, j int) bool { return qual[i] < qual[j] })
+	if len(qual) == 0 {
+		if len(c.outputs) > 0 {
+			return nil, fmt.Errorf("operators output results of a ceremony without deals")
+		}
+		return report, nil
+	}
+
+	if c.reshare != nil {
+		err = reshareKeys(g, c.reshare, qual, deals, newNodes, report)
+	} else {
+		err = dkgKeys(g, qual, deals, newNodes, report)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range sortedKeys(c.outputs) {
+		res := c.outputs[id]
+		if !bytes.Equal(res.ValidatorPubKey, report.ValidatorPubKey) {
+			return nil, fmt.Errorf("validator public key of operator %d doesn't follow from the transcript", id)
+		}
+		if len(res.SharePubKey) == 0 && c.reshare != nil && !containsOperatorID(c.reshare.NewOperators, id) {
+			// old operator which left the validator
+			report.Outputs = append(report.Outputs, id)
+			continue
+		}
+		if !bytes.Equal(res.SharePubKey, report.SharePubKeys[id]) {
+			return nil, fmt.Errorf("share public key of operator %d doesn't follow from the transcript", id)
+		}
+		report.Outputs = append(report.Outputs, id)
+	}
+	return report, nil
+}
+
+// dkgKeys computes the validator and share public keys of a new validator, the public polynomial is the sum of
+// polynomials of the qualified dealers
+func dkgKeys(g kyber.Group, qual []uint32, deals map[uint32]*kyber_dkg.DealBundle, nodes []kyber_dkg.Node, report *CeremonyReport) error {
+	var poly *share.PubPoly
+	for _, index := range qual {
+		p := share.NewPubPoly(g, nil, deals[index].Public)
+		if poly == nil {
+			poly = p
+			continue
+		}
+		var err error
+		if poly, err = poly.Add(p); err != nil {
+			return err
+		}
+	}
+	validatorPK, err := poly.Commit().MarshalBinary()
+	if err != nil {
+		return err
+	}
+	report.ValidatorPubKey = validatorPK
+	for _, n := range nodes {
+		sharePK, err := poly.Eval(int(n.Index)).V.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		report.SharePubKeys[uint64(n.Index)+1] = sharePK
+	}
+	return nil
+}
+
+// reshareKeys computes the validator and share public keys of a reshared validator, the public keys are
+// interpolated from the polynomials of the qualified old operators
+func reshareKeys(g kyber.Group, reshare *wire.Reshare, qual []uint32, deals map[uint32]*kyber_dkg.DealBundle, nodes []kyber_dkg.Node, report *CeremonyReport) error {
+	oldT := int(reshare.OldT)
+	if len(qual) < oldT {
+		return fmt.Errorf("%d qualified dealers, old threshold %d can't be met", len(qual), oldT)
+	}
+	recover := func(eval func(*share.PubPoly) kyber.Point) ([]byte, error) {
+		shares := make([]*share.PubShare, 0, len(qual))
+		for _, index := range qual {
+			p := share.NewPubPoly(g, nil, deals[index].Public)
+			shares = append(shares, &share.PubShare{I: int(index), V: eval(p)})
+		}
+		commit, err := share.RecoverCommit(g, shares, oldT, len(reshare.OldOperators))
+		if err != nil {
+			return nil, err
+		}
+		return commit.MarshalBinary()
+	}
+	validatorPK, err := recover(func(p *share.PubPoly) kyber.Point { return p.Commit() })
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(validatorPK, reshare.ValidatorPubKey) {
+		return fmt.Errorf("resharing results in a different validator public key %x", validatorPK)
+	}
+	report.ValidatorPubKey = validatorPK
+	for _, n := range nodes {
+		index := int(n.Index)
+		sharePK, err := recover(func(p *share.PubPoly) kyber.Point { return p.Eval(index).V })
+		if err != nil {
+			return err
+		}
+		report.SharePubKeys[uint64(n.Index)+1] = sharePK
+	}
+	return nil
+}
+
+// transcriptNodes returns the kyber nodes of operators which sent exchange messages
+func transcriptNodes(g kyber.Group, ops []*wire.Operator, exchanges map[uint64][]byte) ([]kyber_dkg.Node, error) {
+	nodes := make([]kyber_dkg.Node, 0, len(ops))
+	for _, op := range ops {
+		pk, ok := exchanges[op.ID]
+		if !ok {
+			continue
+		}
+		p := g.Point()
+		if err := p.UnmarshalBinary(pk); err != nil {
+			return nil, fmt.Errorf("exchange of operator %d: %w", op.ID, err)
+		}
+		nodes = append(nodes, kyber_dkg.Node{Index: kyber_dkg.Index(op.ID - 1), Public: p})
+	}
+	return nodes, nil
+}
+
+func containsOperatorID(ops []*wire.Operator, id uint64) bool {
+	for _, op := range ops {
+		if op.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(outputs map[uint64]*dkg.Result) []uint64 {
+	ids := make([]uint64, 0, len(outputs))
+	for id := range outputs {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// CheckKeyShares checks that the validator and share public keys of the key shares follow from the transcript
+func (r *TranscriptReport) CheckKeyShares(data Data, payload Payload) error {
+	validatorPK, err := hex.DecodeString(strings.TrimPrefix(payload.PublicKey, "0x"))
+	if err != nil {
+		return err
+	}
+	var c *CeremonyReport
+	for _, cr := range r.Ceremonies {
+		if bytes.Equal(cr.ValidatorPubKey, validatorPK) {
+			c = cr
+		}
+	}
+	if c == nil {
+		return fmt.Errorf("validator %x is not a result of the transcript", validatorPK)
+	}
+	if data.PublicKey != payload.PublicKey {
+		return fmt.Errorf("validator public keys of data and payload differ")
+	}
+	if len(data.Operators) != len(payload.OperatorIDs) {
+		return fmt.Errorf("operators of data and payload differ")
+	}
+	for i, op := range data.Operators {
+		if op.ID != payload.OperatorIDs[i] {
+			return fmt.Errorf("operators of data and payload differ")
+		}
+		opKey, err := crypto.ParseRSAPubkey([]byte(op.OperatorKey))
+		if err != nil {
+			return fmt.Errorf("failed to parse public key of operator %d: %w", op.ID, err)
+		}
+		if transcriptKey, ok := r.Operators[op.ID]; !ok || !transcriptKey.Equal(opKey) {
+			return fmt.Errorf("public key of operator %d doesn't follow from the transcript", op.ID)
+		}
+	}
+	sharesData, err := hex.DecodeString(strings.TrimPrefix(payload.SharesData, "0x"))
+	if err != nil {
+		return err
+	}
+	n := len(payload.OperatorIDs)
+	pubKeysOffset := phase0.SignatureLength + phase0.PublicKeyLength*n
+	if len(sharesData) < pubKeysOffset {
+		return fmt.Errorf("malformed ssv share data")
+	}
+	for i, id := range payload.OperatorIDs {
+		sharePK := sharesData[phase0.SignatureLength+i*phase0.PublicKeyLength : phase0.SignatureLength+(i+1)*phase0.PublicKeyLength]
+		if !bytes.Equal(sharePK, c.SharePubKeys[id]) {
+			return fmt.Errorf("share public key of operator %d doesn't follow from the transcript", id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package initiator
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/imroc/req/v3"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/metrics"
+)
+
+// Transport delivers messages of the initiator to the operators. Method is the operator route of the message,
+// such as consts.API_INIT_URL. An operator rejecting a message responds with an SSZ encoded wire.ErrSSZ,
+// the error of Send is set only if the message couldn't be delivered. Delivery stops when the context is done.
+type Transport interface {
+	// Send delivers the payload to the operator and returns its response
+	Send(ctx context.Context, op Operator, method string, payload []byte) ([]byte, error)
+	// SendToAll delivers the payload to all operators, responses are in the order they are received
+	SendToAll(ctx context.Context, ops []Operator, method string, payload []byte) []Response
+	// Get fetches a read only route of the operator, such as consts.API_IDENTITY_URL. The error is set if the
+	// operator can't be reached or fails to serve the route.
+	Get(ctx context.Context, op Operator, route string) ([]byte, error)
+}
+
+// Response is the response of an operator to a message delivered by a transport
+type Response struct {
+	OperatorID uint64
+	Result     []byte
+	Err        error
+}
+
+// SendConcurrently delivers the payload to all operators in parallel with Send of the transport,
+// responses are in the order they are received
+func SendConcurrently(ctx context.Context, t Transport, ops []Operator, method string, payload []byte) []Response {
+	resc := make(chan Response, len(ops))
+	for _, op := range ops {
+		go func(op Operator) {
+			res, err := t.Send(ctx, op, method, payload)
+			resc <- Response{OperatorID: op.ID, Result: res, Err: err}
+		}(op)
+	}
+	results := make([]Response, 0, len(ops))
+	for range ops {
+		results = append(results, <-resc)
+	}
+	return results
+}
+
+// HTTPTransport posts messages to the operator routes at their addresses
+type HTTPTransport struct {
+	Client *req.Client
+}
+
+// NewHTTPTransport creates a transport posting messages with the client
+func NewHTTPTransport(client *req.Client) *HTTPTransport {
+	return &HTTPTransport{Client: client}
+}
+
+func (t *HTTPTransport) Send(ctx context.Context, op Operator, method string, payload []byte) ([]byte, error) {
+	r := t.Client.R()
+	r.SetContext(ctx)
+	r.SetBodyBytes(payload)
+	operatorID := strconv.FormatUint(op.ID, 10)
+	start := time.Now()
+	res, err := r.Post(fmt.Sprintf("%v/%v", op.Addr, method))
+	metrics.OperatorRequestDuration.WithLabelValues(operatorID, method).Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.OperatorRequestErrors.WithLabelValues(operatorID, method).Inc()
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		metrics.OperatorRequestErrors.WithLabelValues(operatorID, method).Inc()
+	}
+	return io.ReadAll(res.Body)
+}
+
+func (t *HTTPTransport) SendToAll(ctx context.Context, ops []Operator, method string, payload []byte) []Response {
+	return SendConcurrently(ctx, t, ops, method, payload)
+}
+
+func (t *HTTPTransport) Get(ctx context.Context, op Operator, route string) ([]byte, error) {
+	res, err := t.Client.R().SetContext(ctx).Get(fmt.Sprintf("%v/%v", op.Addr, route))
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %d: %s", res.StatusCode, body)
+	}
+	return body, nil
+}
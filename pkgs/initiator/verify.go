@@ -0,0 +1,143 @@
+package initiator
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/herumi/bls-eth-go-binary/bls"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/network"
+)
+
+// ValidateDepositData checks the deposit data of a validator offline: withdrawal credentials of the withdrawal
+// address, the amount, the network and the deposit signature of the validator key
+func ValidateDepositData(d *DepositDataJson, withdrawAddress common.Address, n network.Network) error {
+	pubKey, err := decodeHex(d.PubKey, phase0.PublicKeyLength)
+	if err != nil {
+		return fmt.Errorf("wrong validator public key: %w", err)
+	}
+	withdrawCredentials, err := decodeHex(d.WithdrawalCredentials, 32)
+	if err != nil {
+		return fmt.Errorf("wrong withdrawal credentials: %w", err)
+	}
+	prefix := withdrawCredentials[0]
+	expectedCredentials, err := crypto.WithdrawalCredentials(prefix, withdrawAddress.Bytes())
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(withdrawCredentials, expectedCredentials) {
+		return fmt.Errorf("withdrawal credentials %x don't match withdrawal address %s", withdrawCredentials, withdrawAddress.String())
+	}
+	if err := crypto.ValidateDepositAmount(prefix, d.Amount); err != nil {
+		return err
+	}
+	if d.NetworkName != n.Name {
+		return fmt.Errorf("deposit data is for network %s, expected %s", d.NetworkName, n.Name)
+	}
+	if d.ForkVersion != hex.EncodeToString(n.ForkVersion[:]) {
+		return fmt.Errorf("deposit data fork version %s doesn't match network %s", d.ForkVersion, n.Name)
+	}
+	sig, err := decodeHex(d.Signature, phase0.SignatureLength)
+	if err != nil {
+		return fmt.Errorf("wrong deposit signature: %w", err)
+	}
+	depositData, root, err := crypto.DepositData(sig, withdrawCredentials, pubKey, n, d.Amount)
+	if err != nil {
+		return err
+	}
+	valid, err := crypto.VerifyDepositData(depositData, n)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return fmt.Errorf("deposit data signature is invalid")
+	}
+	depositMsg := &phase0.DepositMessage{
+		WithdrawalCredentials: withdrawCredentials,
+		Amount:                d.Amount,
+	}
+	copy(depositMsg.PublicKey[:], pubKey)
+	depositMsgRoot, err := depositMsg.HashTreeRoot()
+	if err != nil {
+		return err
+	}
+	if d.DepositMessageRoot != hex.EncodeToString(depositMsgRoot[:]) {
+		return fmt.Errorf("deposit message root %s is wrong", d.DepositMessageRoot)
+	}
+	if d.DepositDataRoot != hex.EncodeToString(root[:]) {
+		return fmt.Errorf("deposit data root %s is wrong", d.DepositDataRoot)
+	}
+	return nil
+}
+
+// ValidateKeyShares checks the key shares of a validator offline: the shares data layout, the owner and nonce
+// signature of the validator key and the validator key recovered from the share public keys
+func ValidateKeyShares(data Data, payload Payload, owner common.Address, nonce uint64) error {
+	if !strings.EqualFold(data.PublicKey, payload.PublicKey) {
+		return fmt.Errorf("validator public keys of data and payload differ")
+	}
+	validatorPK, err := decodeHex(payload.PublicKey, phase0.PublicKeyLength)
+	if err != nil {
+		return fmt.Errorf("wrong validator public key: %w", err)
+	}
+	if len(data.Operators) != len(payload.OperatorIDs) {
+		return fmt.Errorf("operators of data and payload differ")
+	}
+	for i, op := range data.Operators {
+		if op.ID != payload.OperatorIDs[i] {
+			return fmt.Errorf("operators of data and payload differ")
+		}
+		if i > 0 && op.ID <= data.Operators[i-1].ID {
+			return fmt.Errorf("operator IDs should be unique and sorted")
+		}
+		if _, err := crypto.ParseRSAPubkey([]byte(op.OperatorKey)); err != nil {
+			return fmt.Errorf("wrong public key of operator %d: %w", op.ID, err)
+		}
+	}
+	sharesDataSigned, err := hex.DecodeString(strings.TrimPrefix(payload.SharesData, "0x"))
+	if err != nil {
+		return err
+	}
+	operatorCount := len(payload.OperatorIDs)
+	signatureOffset := phase0.SignatureLength
+	pubKeysOffset := phase0.PublicKeyLength*operatorCount + signatureOffset
+	sharesExpectedLength := encryptedKeyLength*operatorCount + pubKeysOffset
+	if sharesExpectedLength != len(sharesDataSigned) {
+		return fmt.Errorf("malformed ssv share data")
+	}
+	if err := crypto.VerifyOwnerNoceSignature(sharesDataSigned[:signatureOffset], owner, validatorPK, uint16(nonce)); err != nil {
+		return fmt.Errorf("owner and nonce signature: %w", err)
+	}
+	sharePks := make(map[uint64]*bls.PublicKey, operatorCount)
+	for i, id := range payload.OperatorIDs {
+		pk := &bls.PublicKey{}
+		if err := pk.Deserialize(sharesDataSigned[signatureOffset+i*phase0.PublicKeyLength : signatureOffset+(i+1)*phase0.PublicKeyLength]); err != nil {
+			return fmt.Errorf("wrong share public key of operator %d: %w", id, err)
+		}
+		sharePks[id] = pk
+	}
+	recoveredPK, err := crypto.RecoverValidatorPublicKey(sharePks)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(recoveredPK.Serialize(), validatorPK) {
+		return fmt.Errorf("validator public key is not equal to the key recovered from shares %x", recoveredPK.Serialize())
+	}
+	return nil
+}
+
+func decodeHex(s string, length int) ([]byte, error) {
+	byts, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	if len(byts) != length {
+		return nil, fmt.Errorf("expected %d bytes, got %d", length, len(byts))
+	}
+	return byts, nil
+}
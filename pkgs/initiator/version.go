@@ -0,0 +1,51 @@
+package initiator
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+)
+
+// negotiateVersion sets the protocol version of the ceremony to the highest one supported by the initiator and all reachable operators
+func (c *Initiator) negotiateVersion(ctx context.Context, ops []*wire.Operator) error {
+	type identityResult struct {
+		id       uint64
+		identity *wire.Identity
+		err      error
+	}
+	resc := make(chan identityResult, len(ops))
+	for _, op := range ops {
+		go func(op Operator) {
+			identity, err := c.identity(ctx, op)
+			resc <- identityResult{id: op.ID, identity: identity, err: err}
+		}(c.Operators[op.ID])
+	}
+	versions := make([][]uint64, 0, len(ops))
+	for range ops {
+		res := <-resc
+		// unreachable operators fail the requests of the ceremony, the ones which don't need all operators go on without them
+		if res.err != nil {
+			c.Logger.Warn("failed to get supported protocol versions", zap.Uint64("operator", res.id), zap.Error(res.err))
+			continue
+		}
+		versions = append(versions, res.identity.ProtocolVersions)
+	}
+	version, err := wire.NegotiateVersion(versions...)
+	if err != nil {
+		return err
+	}
+	c.Logger.Debug("negotiated protocol version", zap.Uint64("version", version))
+	c.Version = version
+	return nil
+}
+
+// checkVersion verifies a message of an operator has the protocol version of the ceremony
+func (c *Initiator) checkVersion(tsp *wire.SignedTransport) error {
+	if tsp.Message.Version != c.Version {
+		return fmt.Errorf("operator %d responded with protocol version %d, ceremony runs version %d", tsp.Signer, tsp.Message.Version, c.Version)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const namespace = "ssv_dkg"
+
+// Reasons of failed ceremonies
+const (
+	// ReasonInvalidInit the init message is malformed or its parameters are not supported
+	ReasonInvalidInit = "invalid_init"
+	// ReasonSignature the signature of the initiator is not valid
+	ReasonSignature = "signature"
+	// ReasonNotAllowed the ceremony is rejected by the operator policy
+	ReasonNotAllowed = "not_allowed"
+	// ReasonMaxInstances the operator runs the max number of instances
+	ReasonMaxInstances = "max_instances"
+	// ReasonReplayed the init message expired or its request ID was already used
+	ReasonReplayed = "replayed"
+	// ReasonMessage the DKG can't start with the exchange messages received
+	ReasonMessage = "message"
+	// ReasonDKG the DKG protocol or creation of its result failed
+	ReasonDKG = "dkg"
+	// ReasonTimeout the instance was cleaned before it finished
+	ReasonTimeout = "timeout"
+	// ReasonCancelled the initiator cancelled the ceremony
+	ReasonCancelled = "cancelled"
+)
+
+// Operator metrics
+var (
+	CeremoniesStarted = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "operator",
+		Name:      "ceremonies_started_total",
+		Help:      "Ceremonies started by the operator",
+	}, []string{"type"})
+	CeremoniesCompleted = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "operator",
+		Name:      "ceremonies_completed_total",
+		Help:      "Ceremonies completed successfully by the operator",
+	})
+	CeremoniesFailed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "operator",
+		Name:      "ceremonies_failed_total",
+		Help:      "Ceremonies failed or rejected at init by the operator",
+	}, []string{"reason"})
+	PhaseDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "operator",
+		Name:      "phase_duration_seconds",
+		Help:      "Duration of the ceremony phases",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"phase"})
+	RateLimited = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "operator",
+		Name:      "rate_limited_requests_total",
+		Help:      "Requests rejected by the rate limits of the operator",
+	}, []string{"limit"})
+	SignatureFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "operator",
+		Name:      "signature_verification_failures_total",
+		Help:      "Messages with invalid initiator or operator signatures",
+	}, []string{"signer"})
+	RejectedMessages = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "operator",
+		Name:      "rejected_messages_total",
+		Help:      "DKG messages of other operators rejected without failing the ceremony",
+	})
+)
+
+// Initiator metrics
+var (
+	OperatorRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "initiator",
+		Name:      "operator_request_duration_seconds",
+		Help:      "Latency of the requests to operators",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+	}, []string{"operator", "route"})
+	OperatorRequestErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "initiator",
+		Name:      "operator_request_errors_total",
+		Help:      "Failed requests to operators, unreachable operators and error responses",
+	}, []string{"operator", "route"})
+)
+
+// NewInstanceCollectors creates the gauges of the instances held by an operator, active counts the instances not
+// finished yet and saturation is the share of the max number of instances held
+func NewInstanceCollectors(active, saturation func() float64) []prometheus.Collector {
+	return []prometheus.Collector{
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "operator",
+			Name:      "active_instances",
+			Help:      "Instances running a ceremony",
+		}, active),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "operator",
+			Name:      "instances_saturation",
+			Help:      "Instances held by the operator as a share of the max number of instances",
+		}, saturation),
+	}
+}
+
+// NewOperatorRegistry creates a registry with the operator metrics, the Go runtime and process metrics
+func NewOperatorRegistry(cs ...prometheus.Collector) *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		CeremoniesStarted,
+		CeremoniesCompleted,
+		CeremoniesFailed,
+		PhaseDuration,
+		RateLimited,
+		SignatureFailures,
+		RejectedMessages,
+	)
+	reg.MustRegister(cs...)
+	return reg
+}
+
+// NewInitiatorRegistry creates a registry with the initiator metrics
+func NewInitiatorRegistry() *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(OperatorRequestDuration, OperatorRequestErrors)
+	return reg
+}
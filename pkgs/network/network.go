@@ -0,0 +1,171 @@
+package network
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Network is an Ethereum network validators are created for
+type Network struct {
+	// Name of the network, i.e. mainnet
+	Name string
+	// ForkVersion identifies the network at the ceremony messages
+	ForkVersion [4]byte
+	// GenesisForkVersion is used to compute the deposit signing domain
+	GenesisForkVersion [4]byte
+	// DepositContract address of the deposit contract
+	DepositContract string
+}
+
+var (
+	// Mainnet is the Ethereum main network
+	Mainnet = Network{
+		Name:               "mainnet",
+		ForkVersion:        [4]byte{0x00, 0x00, 0x00, 0x00},
+		GenesisForkVersion: [4]byte{0x00, 0x00, 0x00, 0x00},
+		DepositContract:    "0x00000000219ab540356cBB839Cbe05303d7705Fa",
+	}
+	// Prater is the Prater (Goerli) test network
+	Prater = Network{
+		Name:               "prater",
+		ForkVersion:        [4]byte{0x00, 0x00, 0x10, 0x20},
+		GenesisForkVersion: [4]byte{0x00, 0x00, 0x10, 0x20},
+		DepositContract:    "0xff50ed3d0ec03aC01D4C79aAd74928BFF48a7b2b",
+	}
+	// Holesky is the Holesky test network
+	Holesky = Network{
+		Name:               "holesky",
+		ForkVersion:        [4]byte{0x01, 0x01, 0x70, 0x00},
+		GenesisForkVersion: [4]byte{0x01, 0x01, 0x70, 0x00},
+		DepositContract:    "0x4242424242424242424242424242424242424242",
+	}
+	// NowTestNetwork is a network used for testing, it has no deposit contract
+	NowTestNetwork = Network{
+		Name:               "now_test_network",
+		ForkVersion:        [4]byte{0x99, 0x99, 0x99, 0x99},
+		GenesisForkVersion: [4]byte{0x99, 0x99, 0x99, 0x99},
+	}
+)
+
+var registry = struct {
+	mtx    sync.RWMutex
+	byName map[string]Network
+	byFork map[[4]byte]Network
+}{
+	byName: make(map[string]Network),
+	byFork: make(map[[4]byte]Network),
+}
+
+func init() {
+	for _, n := range []Network{Mainnet, Prater, Holesky, NowTestNetwork} {
+		if err := Register(n); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Register adds a network to the known networks, name and fork version of the network should be unique
+func Register(n Network) error {
+	if n.Name == "" {
+		return fmt.Errorf("network name is required")
+	}
+	registry.mtx.Lock()
+	defer registry.mtx.Unlock()
+	if existing, ok := registry.byName[n.Name]; ok {
+		if existing == n {
+			return nil
+		}
+		return fmt.Errorf("network %s is already registered", n.Name)
+	}
+	if existing, ok := registry.byFork[n.ForkVersion]; ok {
+		return fmt.Errorf("fork version %x is already used by network %s", n.ForkVersion, existing.Name)
+	}
+	registry.byName[n.Name] = n
+	registry.byFork[n.ForkVersion] = n
+	return nil
+}
+
+// ByName returns the network with the given name
+func ByName(name string) (Network, error) {
+	registry.mtx.RLock()
+	defer registry.mtx.RUnlock()
+	n, ok := registry.byName[name]
+	if !ok {
+		return Network{}, fmt.Errorf("unknown network %s, known networks: %s", name, strings.Join(names(), ", "))
+	}
+	return n, nil
+}
+
+// ByFork returns the network with the given fork version
+func ByFork(fork [4]byte) (Network, error) {
+	registry.mtx.RLock()
+	defer registry.mtx.RUnlock()
+	n, ok := registry.byFork[fork]
+	if !ok {
+		return Network{}, fmt.Errorf("unknown fork version %x", fork)
+	}
+	return n, nil
+}
+
+// Names returns the names of all known networks, sorted
+func Names() []string {
+	registry.mtx.RLock()
+	defer registry.mtx.RUnlock()
+	return names()
+}
+
+func names() []string {
+	names := make([]string, 0, len(registry.byName))
+	for name := range registry.byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Config is a custom network definition read from a config file, versions are hex encoded
+type Config struct {
+	Name               string `mapstructure:"name"`
+	ForkVersion        string `mapstructure:"forkVersion"`
+	GenesisForkVersion string `mapstructure:"genesisForkVersion"`
+	DepositContract    string `mapstructure:"depositContract"`
+}
+
+// RegisterConfigs adds custom networks defined at a config file to the known networks
+func RegisterConfigs(configs []Config) error {
+	for _, cfg := range configs {
+		forkVersion, err := parseVersion(cfg.ForkVersion)
+		if err != nil {
+			return fmt.Errorf("network %s: wrong fork version: %w", cfg.Name, err)
+		}
+		genesisForkVersion, err := parseVersion(cfg.GenesisForkVersion)
+		if err != nil {
+			return fmt.Errorf("network %s: wrong genesis fork version: %w", cfg.Name, err)
+		}
+		if err := Register(Network{
+			Name:               cfg.Name,
+			ForkVersion:        forkVersion,
+			GenesisForkVersion: genesisForkVersion,
+			DepositContract:    cfg.DepositContract,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseVersion(s string) ([4]byte, error) {
+	var version [4]byte
+	b, err := hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	if err != nil {
+		return version, err
+	}
+	if len(b) != 4 {
+		return version, fmt.Errorf("version should be 4 bytes, got %d", len(b))
+	}
+	copy(version[:], b)
+	return version, nil
+}
@@ -0,0 +1,44 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	t.Run("built in networks", func(t *testing.T) {
+		for _, n := range []Network{Mainnet, Prater, Holesky, NowTestNetwork} {
+			byName, err := ByName(n.Name)
+			require.NoError(t, err)
+			require.Equal(t, n, byName)
+			byFork, err := ByFork(n.ForkVersion)
+			require.NoError(t, err)
+			require.Equal(t, n, byFork)
+		}
+	})
+	t.Run("unknown networks", func(t *testing.T) {
+		_, err := ByName("goerli")
+		require.ErrorContains(t, err, "unknown network goerli")
+		_, err = ByFork([4]byte{0x01, 0x02, 0x03, 0x04})
+		require.ErrorContains(t, err, "unknown fork version 01020304")
+	})
+	t.Run("custom networks", func(t *testing.T) {
+		err := RegisterConfigs([]Config{{
+			Name:               "devnet",
+			ForkVersion:        "0x10000038",
+			GenesisForkVersion: "0x10000000",
+			DepositContract:    "0x4242424242424242424242424242424242424242",
+		}})
+		require.NoError(t, err)
+		n, err := ByFork([4]byte{0x10, 0x00, 0x00, 0x38})
+		require.NoError(t, err)
+		require.Equal(t, "devnet", n.Name)
+		require.Equal(t, [4]byte{0x10, 0x00, 0x00, 0x00}, n.GenesisForkVersion)
+	})
+	t.Run("conflicting networks", func(t *testing.T) {
+		require.ErrorContains(t, RegisterConfigs([]Config{{Name: "mainnet", ForkVersion: "0x20000000", GenesisForkVersion: "0x20000000"}}), "already registered")
+		require.ErrorContains(t, RegisterConfigs([]Config{{Name: "other", ForkVersion: "0x01017000", GenesisForkVersion: "0x01017000"}}), "already used by network holesky")
+		require.ErrorContains(t, RegisterConfigs([]Config{{Name: "short", ForkVersion: "0x0102", GenesisForkVersion: "0x0102"}}), "wrong fork version")
+	})
+}
@@ -0,0 +1,30 @@
+package operator
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/dkg"
+	"github.com/bloxapp/ssv-dkg/pkgs/metrics"
+)
+
+// instanceCollectors report the instances of the switch, active are the instances not finished yet,
+// saturation is the share of MaxInstances held by the switch
+func (s *Switch) instanceCollectors() []prometheus.Collector {
+	active := func() float64 {
+		s.Mtx.RLock()
+		defer s.Mtx.RUnlock()
+		count := 0
+		for _, inst := range s.Instances {
+			if inst.Status().Phase != dkg.PhaseOutput {
+				count++
+			}
+		}
+		return float64(count)
+	}
+	saturation := func() float64 {
+		s.Mtx.RLock()
+		defer s.Mtx.RUnlock()
+		return float64(len(s.Instances)) / MaxInstances
+	}
+	return metrics.NewInstanceCollectors(active, saturation)
+}
@@ -2,6 +2,7 @@ package operator
 
 import (
 	"crypto/rsa"
+	"crypto/tls"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -10,11 +11,14 @@ import (
 
 	"time"
 
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/metrics"
+	"github.com/bloxapp/ssv-dkg/pkgs/network"
 	"github.com/bloxapp/ssv-dkg/pkgs/wire"
-	ssvspec_types "github.com/bloxapp/ssv-spec/types"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/httprate"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/zap"
 )
 
@@ -23,21 +27,10 @@ type Server struct {
 	HttpServer *http.Server
 	Router     chi.Router
 	State      *Switch
-}
-
-type KeySign struct {
-	ValidatorPK ssvspec_types.ValidatorPK
-	SigningRoot []byte
-}
-
-// Encode returns a msg encoded bytes or error
-func (msg *KeySign) Encode() ([]byte, error) {
-	return json.Marshal(msg)
-}
-
-// Decode returns error if decoding failed
-func (msg *KeySign) Decode(data []byte) error {
-	return json.Unmarshal(data, msg)
+	// Version of the operator build, served at the identity route
+	Version string
+	// TLSConfig serves HTTPS if set, plain HTTP otherwise
+	TLSConfig *tls.Config
 }
 
 // TODO: either do all json or all SSZ
@@ -50,6 +43,7 @@ func RegisterRoutes(s *Server) {
 		500,
 		1*time.Minute,
 		httprate.WithLimitHandler(func(w http.ResponseWriter, r *http.Request) {
+			metrics.RateLimited.WithLabelValues("all").Inc()
 			w.Header().Set("Content-Type", "application/json")
 			w.WriteHeader(http.StatusTooManyRequests)
 			w.Write([]byte(ErrTooManyOperatorRequests))
@@ -57,9 +51,10 @@ func RegisterRoutes(s *Server) {
 	))
 	s.Router.Route("/init", func(r chi.Router) {
 		r.Use(httprate.Limit(
-			5,
+			InitRateLimit,
 			time.Minute,
 			httprate.WithLimitHandler(func(w http.ResponseWriter, r *http.Request) {
+				metrics.RateLimited.WithLabelValues("init").Inc()
 				w.Header().Set("Content-Type", "application/json")
 				w.WriteHeader(http.StatusTooManyRequests)
 				w.Write([]byte(ErrTooManyDKGRequests))
@@ -77,7 +72,7 @@ func RegisterRoutes(s *Server) {
 			}
 
 			// Validate that incoming message is an init message
-			if signedInitMsg.Message.Type != wire.InitMessageType {
+			if signedInitMsg.Message.Type != wire.InitMessageType && signedInitMsg.Message.Type != wire.InitReshareMessageType {
 				s.Logger.Error("received bad msg non init message sent to init route")
 				writer.WriteHeader(http.StatusBadRequest)
 				writer.Write(wire.MakeErr(errors.New("not init message to init route")))
@@ -99,6 +94,25 @@ func RegisterRoutes(s *Server) {
 			writer.WriteHeader(http.StatusOK)
 			writer.Write(b)
 		})
+		// a batch of init messages counts as a single request for the rate limit, its size is capped to the limit
+		r.Post("/batch", func(writer http.ResponseWriter, request *http.Request) {
+			s.Logger.Debug("incoming batch of INIT msgs")
+			rawdata, err := io.ReadAll(request.Body)
+			if err != nil {
+				writer.WriteHeader(http.StatusBadRequest)
+				writer.Write(wire.MakeErr(err))
+				return
+			}
+			b, err := s.State.InitInstances(rawdata)
+			if err != nil {
+				s.Logger.Error("failed to initiate instances", zap.Error(err))
+				writer.WriteHeader(http.StatusBadRequest)
+				writer.Write(wire.MakeErr(err))
+				return
+			}
+			writer.WriteHeader(http.StatusOK)
+			writer.Write(b)
+		})
 	})
 	s.Router.Route("/dkg", func(r chi.Router) {
 		r.Post("/", func(writer http.ResponseWriter, request *http.Request) {
@@ -109,7 +123,24 @@ func RegisterRoutes(s *Server) {
 				writer.Write(wire.MakeErr(err))
 				return
 			}
-			b, err := s.State.ProcessMessage(rawdata)
+			b, err := s.State.ProcessMessage(request.Context(), rawdata)
+			if err != nil {
+				writer.WriteHeader(http.StatusBadRequest)
+				writer.Write(wire.MakeErr(err))
+				return
+			}
+			writer.WriteHeader(http.StatusOK)
+			writer.Write(b)
+		})
+		r.Post("/batch", func(writer http.ResponseWriter, request *http.Request) {
+			s.Logger.Debug("received a batch of dkg protocol messages")
+			rawdata, err := io.ReadAll(request.Body)
+			if err != nil {
+				writer.WriteHeader(http.StatusBadRequest)
+				writer.Write(wire.MakeErr(err))
+				return
+			}
+			b, err := s.State.ProcessBatchMessage(request.Context(), rawdata)
 			if err != nil {
 				writer.WriteHeader(http.StatusBadRequest)
 				writer.Write(wire.MakeErr(err))
@@ -119,6 +150,124 @@ func RegisterRoutes(s *Server) {
 			writer.Write(b)
 		})
 	})
+	s.Router.Get("/health", func(writer http.ResponseWriter, request *http.Request) {
+		writeJSON(writer, http.StatusOK, map[string]string{"status": "ok"})
+	})
+	s.Router.Get("/identity", func(writer http.ResponseWriter, request *http.Request) {
+		identity, err := s.Identity()
+		if err != nil {
+			writeJSON(writer, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+			return
+		}
+		writeJSON(writer, http.StatusOK, identity)
+	})
+	s.Router.Method(http.MethodGet, "/metrics", promhttp.HandlerFor(metrics.NewOperatorRegistry(s.State.instanceCollectors()...), promhttp.HandlerOpts{}))
+	s.Router.Route("/instances", func(r chi.Router) {
+		r.Get("/", func(writer http.ResponseWriter, request *http.Request) {
+			writeJSON(writer, http.StatusOK, s.State.InstanceStatuses())
+		})
+		r.Get("/{reqID}", func(writer http.ResponseWriter, request *http.Request) {
+			var id InstanceID
+			reqID, err := hex.DecodeString(chi.URLParam(request, "reqID"))
+			if err != nil || len(reqID) != len(id) {
+				writeJSON(writer, http.StatusBadRequest, map[string]string{"error": "wrong request ID, should be 24 hex encoded bytes"})
+				return
+			}
+			copy(id[:], reqID)
+			status, err := s.State.InstanceStatus(id)
+			if err != nil {
+				writeJSON(writer, http.StatusNotFound, map[string]string{"error": err.Error()})
+				return
+			}
+			writeJSON(writer, http.StatusOK, status)
+		})
+	})
+	s.Router.Route("/cancel", func(r chi.Router) {
+		r.Post("/", func(writer http.ResponseWriter, request *http.Request) {
+			s.Logger.Debug("received a cancel message")
+			rawdata, err := io.ReadAll(request.Body)
+			if err != nil {
+				writer.WriteHeader(http.StatusBadRequest)
+				writer.Write(wire.MakeErr(err))
+				return
+			}
+			signedCancelMsg := &wire.SignedTransport{}
+			if err := signedCancelMsg.UnmarshalSSZ(rawdata); err != nil {
+				s.Logger.Error("parsing failed: ", zap.Error(err))
+				writer.WriteHeader(http.StatusBadRequest)
+				writer.Write(wire.MakeErr(err))
+				return
+			}
+			b, err := s.State.CancelInstance(signedCancelMsg.Message, signedCancelMsg.Signature)
+			if err != nil {
+				s.Logger.Error("failed to cancel instance", zap.Error(err))
+				writer.WriteHeader(http.StatusBadRequest)
+				writer.Write(wire.MakeErr(err))
+				return
+			}
+			writer.WriteHeader(http.StatusOK)
+			writer.Write(b)
+		})
+	})
+	s.Router.Route("/sign", func(r chi.Router) {
+		r.Post("/", func(writer http.ResponseWriter, request *http.Request) {
+			s.Logger.Debug("received a signing request")
+			rawdata, err := io.ReadAll(request.Body)
+			if err != nil {
+				writer.WriteHeader(http.StatusBadRequest)
+				writer.Write(wire.MakeErr(err))
+				return
+			}
+			signedSignMsg := &wire.SignedTransport{}
+			if err := signedSignMsg.UnmarshalSSZ(rawdata); err != nil {
+				s.Logger.Error("parsing failed: ", zap.Error(err))
+				writer.WriteHeader(http.StatusBadRequest)
+				writer.Write(wire.MakeErr(err))
+				return
+			}
+			b, err := s.State.ProcessSignRequest(signedSignMsg.Message, signedSignMsg.Signature)
+			if err != nil {
+				s.Logger.Error("failed to sign", zap.Error(err))
+				writer.WriteHeader(http.StatusBadRequest)
+				writer.Write(wire.MakeErr(err))
+				return
+			}
+			writer.WriteHeader(http.StatusOK)
+			writer.Write(b)
+		})
+	})
+}
+
+func writeJSON(writer http.ResponseWriter, status int, v interface{}) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+	if err := json.NewEncoder(writer).Encode(v); err != nil {
+		writer.Write([]byte(err.Error()))
+	}
+}
+
+// Identity returns the public key, version, protocol versions and networks of the operator
+func (s *Server) Identity() (*wire.Identity, error) {
+	identity, err := s.State.Identity()
+	if err != nil {
+		return nil, err
+	}
+	identity.Version = s.Version
+	return identity, nil
+}
+
+// Identity returns the public key, protocol versions and networks of the operator, the version of the release is
+// known only to the server
+func (s *Switch) Identity() (*wire.Identity, error) {
+	pubKey, err := crypto.EncodePublicKey(&s.PrivateKey.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &wire.Identity{
+		PubKey:           string(pubKey),
+		ProtocolVersions: wire.SupportedProtocolVersions,
+		Networks:         network.Names(),
+	}, nil
 }
 
 func New(key *rsa.PrivateKey, logger *zap.Logger) *Server {
@@ -134,9 +283,15 @@ func New(key *rsa.PrivateKey, logger *zap.Logger) *Server {
 }
 
 func (s *Server) Start(port uint16) error {
-	srv := &http.Server{Addr: fmt.Sprintf(":%v", port), Handler: s.Router}
+	srv := &http.Server{Addr: fmt.Sprintf(":%v", port), Handler: s.Router, TLSConfig: s.TLSConfig}
 	s.HttpServer = srv
-	err := s.HttpServer.ListenAndServe()
+	var err error
+	if s.TLSConfig != nil {
+		// certificates are set at the TLS config
+		err = s.HttpServer.ListenAndServeTLS("", "")
+	} else {
+		err = s.HttpServer.ListenAndServe()
+	}
 	if err != nil {
 		return err
 	}
@@ -145,5 +300,9 @@ func (s *Server) Start(port uint16) error {
 }
 
 func (s *Server) Stop() error {
+	s.State.Stop()
+	if s.HttpServer == nil {
+		return nil
+	}
 	return s.HttpServer.Close()
 }
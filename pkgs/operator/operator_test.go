@@ -1,6 +1,7 @@
 package operator
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
@@ -51,11 +52,16 @@ func TestRateLimit(t *testing.T) {
 			Fork:                  [4]byte{0, 0, 0, 0},
 			Owner:                 common.HexToAddress("0x0000000000000000000000000000000000000007"),
 			Nonce:                 0,
+			Amount:                uint64(crypto.MaxEffectiveBalanceInGwei),
+			WithdrawalPrefix:      crypto.ETH1WithdrawalPrefixByte,
+			Timestamp:             uint64(time.Now().Unix()),
+			Expiry:                uint64(time.Now().Add(wire.DefaultInitTTL).Unix()),
 		}
 		sszinit, err := init.MarshalSSZ()
 		require.NoError(t, err)
 
 		ts := &wire.Transport{
+			Version:    wire.ProtocolVersion,
 			Type:       wire.InitMessageType,
 			Identifier: [24]byte{},
 			Data:       sszinit,
@@ -183,10 +189,14 @@ func TestWrongInitiatorSignature(t *testing.T) {
 			Fork:                  [4]byte{0, 0, 0, 0},
 			Owner:                 owner,
 			Nonce:                 0,
+			Amount:                uint64(crypto.MaxEffectiveBalanceInGwei),
+			WithdrawalPrefix:      crypto.ETH1WithdrawalPrefixByte,
+			Timestamp:             uint64(time.Now().Unix()),
+			Expiry:                uint64(time.Now().Add(wire.DefaultInitTTL).Unix()),
 			InitiatorPublicKey:    wrongPub,
 		}
 		id := crypto.NewID()
-		results, err := c.SendInitMsg(init, id, parts)
+		results, err := c.SendInitMsg(context.Background(), init, id, parts)
 		require.NoError(t, err)
 		var errs []error
 		for i := 0; i < len(results); i++ {
@@ -242,12 +252,17 @@ func TestWrongInitiatorSignature(t *testing.T) {
 			Fork:                  [4]byte{0, 0, 0, 0},
 			Owner:                 owner,
 			Nonce:                 0,
+			Amount:                uint64(crypto.MaxEffectiveBalanceInGwei),
+			WithdrawalPrefix:      crypto.ETH1WithdrawalPrefixByte,
+			Timestamp:             uint64(time.Now().Unix()),
+			Expiry:                uint64(time.Now().Add(wire.DefaultInitTTL).Unix()),
 			InitiatorPublicKey:    wrongPub,
 		}
 		id := crypto.NewID()
 		sszinit, err := init.MarshalSSZ()
 		require.NoError(t, err)
 		initMessage := &wire.Transport{
+			Version:    wire.ProtocolVersion,
 			Type:       wire.InitMessageType,
 			Identifier: id,
 			Data:       sszinit,
@@ -261,7 +276,7 @@ func TestWrongInitiatorSignature(t *testing.T) {
 			Signature: sig}
 		signedInitMsgBts, err := signedInitMsg.MarshalSSZ()
 		require.NoError(t, err)
-		results, err := c.SendToAll(consts.API_INIT_URL, signedInitMsgBts, parts)
+		results, err := c.SendToAll(context.Background(), consts.API_INIT_URL, signedInitMsgBts, parts)
 		require.NoError(t, err)
 		var errs []error
 		for i := 0; i < len(results); i++ {
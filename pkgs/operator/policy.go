@@ -0,0 +1,197 @@
+package operator
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"gopkg.in/yaml.v3"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/network"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+)
+
+// ErrNotAllowed is returned for ceremonies rejected by the access policy of the operator
+var ErrNotAllowed = errors.New("not allowed by the operator policy")
+
+// AccessPolicyConfig is the content of an operator policy file, an empty list allows any value
+type AccessPolicyConfig struct {
+	// Initiators base64 encoded RSA public keys of initiators allowed to start ceremonies
+	Initiators []string `yaml:"initiators"`
+	// Owners addresses of validator owners
+	Owners []string `yaml:"owners"`
+	// WithdrawalAddresses addresses validators can withdraw to
+	WithdrawalAddresses []string `yaml:"withdrawalAddresses"`
+	// Networks names of networks validators can be created for
+	Networks []string `yaml:"networks"`
+	// Operators IDs of operators allowed to take part in ceremonies together with this operator
+	Operators []uint64 `yaml:"operators"`
+}
+
+// AccessPolicy limits which ceremonies the operator takes part in
+type AccessPolicy struct {
+	initiators          []*rsa.PublicKey
+	owners              map[common.Address]struct{}
+	withdrawalAddresses map[common.Address]struct{}
+	networks            map[string]struct{}
+	operators           map[uint64]struct{}
+}
+
+// LoadAccessPolicy reads an operator policy file in YAML or JSON format
+func LoadAccessPolicy(path string) (*AccessPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cfg AccessPolicyConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse policy file: %w", err)
+	}
+	return NewAccessPolicy(cfg)
+}
+
+// NewAccessPolicy validates the config and creates the policy out of it. Networks should be registered in advance.
+func NewAccessPolicy(cfg AccessPolicyConfig) (*AccessPolicy, error) {
+	p := &AccessPolicy{}
+	for _, encoded := range cfg.Initiators {
+		pk, err := crypto.ParseRSAPubkey([]byte(encoded))
+		if err != nil {
+			return nil, fmt.Errorf("wrong initiator public key %s: %w", encoded, err)
+		}
+		p.initiators = append(p.initiators, pk)
+	}
+	var err error
+	if p.owners, err = addressSet(cfg.Owners); err != nil {
+		return nil, fmt.Errorf("wrong owner: %w", err)
+	}
+	if p.withdrawalAddresses, err = addressSet(cfg.WithdrawalAddresses); err != nil {
+		return nil, fmt.Errorf("wrong withdrawal address: %w", err)
+	}
+	if len(cfg.Networks) > 0 {
+		p.networks = make(map[string]struct{}, len(cfg.Networks))
+		for _, name := range cfg.Networks {
+			if _, err := network.ByName(name); err != nil {
+				return nil, err
+			}
+			p.networks[name] = struct{}{}
+		}
+	}
+	if len(cfg.Operators) > 0 {
+		p.operators = make(map[uint64]struct{}, len(cfg.Operators))
+		for _, id := range cfg.Operators {
+			p.operators[id] = struct{}{}
+		}
+	}
+	return p, nil
+}
+
+func addressSet(addrs []string) (map[common.Address]struct{}, error) {
+	if len(addrs) == 0 {
+		return nil, nil
+	}
+	set := make(map[common.Address]struct{}, len(addrs))
+	for _, addr := range addrs {
+		if !common.IsHexAddress(addr) {
+			return nil, fmt.Errorf("%s is not an address", addr)
+		}
+		set[common.HexToAddress(addr)] = struct{}{}
+	}
+	return set, nil
+}
+
+// CheckInit checks a ceremony creating a new validator is allowed, self is the public key of the operator
+func (p *AccessPolicy) CheckInit(init *wire.Init, initiatorPubKey, self *rsa.PublicKey) error {
+	if p == nil {
+		return nil
+	}
+	if err := p.checkInitiator(initiatorPubKey); err != nil {
+		return err
+	}
+	if err := p.checkOwner(init.Owner); err != nil {
+		return err
+	}
+	if p.withdrawalAddresses != nil {
+		if _, ok := p.withdrawalAddresses[common.BytesToAddress(init.WithdrawalCredentials)]; !ok {
+			return fmt.Errorf("withdrawal address %x is %w", init.WithdrawalCredentials, ErrNotAllowed)
+		}
+	}
+	if p.networks != nil {
+		n, err := network.ByFork(init.Fork)
+		if err != nil {
+			return err
+		}
+		if _, ok := p.networks[n.Name]; !ok {
+			return fmt.Errorf("network %s is %w", n.Name, ErrNotAllowed)
+		}
+	}
+	return p.checkOperators(init.Operators, self)
+}
+
+// CheckReshare checks a ceremony resharing a validator key is allowed, self is the public key of the operator
+func (p *AccessPolicy) CheckReshare(reshare *wire.Reshare, initiatorPubKey, self *rsa.PublicKey) error {
+	if p == nil {
+		return nil
+	}
+	if err := p.checkInitiator(initiatorPubKey); err != nil {
+		return err
+	}
+	if err := p.checkOwner(reshare.Owner); err != nil {
+		return err
+	}
+	if err := p.checkOperators(reshare.OldOperators, self); err != nil {
+		return err
+	}
+	return p.checkOperators(reshare.NewOperators, self)
+}
+
+// CheckSign checks the initiator is allowed to request signatures by the operator's shares
+func (p *AccessPolicy) CheckSign(initiatorPubKey *rsa.PublicKey) error {
+	if p == nil {
+		return nil
+	}
+	return p.checkInitiator(initiatorPubKey)
+}
+
+func (p *AccessPolicy) checkInitiator(pk *rsa.PublicKey) error {
+	if p.initiators == nil {
+		return nil
+	}
+	for _, allowed := range p.initiators {
+		if allowed.Equal(pk) {
+			return nil
+		}
+	}
+	initiatorID := sha256.Sum256(pk.N.Bytes())
+	return fmt.Errorf("initiator %x is %w", initiatorID[:], ErrNotAllowed)
+}
+
+func (p *AccessPolicy) checkOwner(owner [20]byte) error {
+	if p.owners == nil {
+		return nil
+	}
+	if _, ok := p.owners[owner]; !ok {
+		return fmt.Errorf("owner %s is %w", common.Address(owner).Hex(), ErrNotAllowed)
+	}
+	return nil
+}
+
+func (p *AccessPolicy) checkOperators(ops []*wire.Operator, self *rsa.PublicKey) error {
+	if p.operators == nil {
+		return nil
+	}
+	for _, op := range ops {
+		if _, ok := p.operators[op.ID]; ok {
+			continue
+		}
+		pk, err := crypto.ParseRSAPubkey(op.PubKey)
+		if err == nil && pk.Equal(self) {
+			continue
+		}
+		return fmt.Errorf("operator %d is %w", op.ID, ErrNotAllowed)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package operator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bloxapp/ssv/logging"
+	"github.com/bloxapp/ssv/utils/rsaencryption"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/wire"
+)
+
+func TestAccessPolicy(t *testing.T) {
+	if err := logging.SetGlobalLogger("info", "capital", "console", nil); err != nil {
+		panic(err)
+	}
+	logger := zap.L().Named("state-tests")
+	privateKey, ops := generateOperatorsData(t, 4)
+	_, pv, err := rsaencryption.GenerateKeys()
+	require.NoError(t, err)
+	initiatorKey, err := rsaencryption.ConvertPemToPrivateKey(string(pv))
+	require.NoError(t, err)
+	encInitiatorPubKey, err := crypto.EncodePublicKey(&initiatorKey.PublicKey)
+	require.NoError(t, err)
+	_, otherPv, err := rsaencryption.GenerateKeys()
+	require.NoError(t, err)
+	otherInitiatorKey, err := rsaencryption.ConvertPemToPrivateKey(string(otherPv))
+	require.NoError(t, err)
+
+	owner := common.HexToAddress("0x0000000000000000000000000000000000000007")
+	withdraw := common.HexToAddress("0x0000000000000000000000000000000000000009")
+	policyFile := filepath.Join(t.TempDir(), "policy.yaml")
+	require.NoError(t, os.WriteFile(policyFile, []byte(`initiators:
+  - `+string(encInitiatorPubKey)+`
+owners:
+  - "0x0000000000000000000000000000000000000007"
+withdrawalAddresses:
+  - "0x0000000000000000000000000000000000000009"
+networks:
+  - mainnet
+  - holesky
+operators: [2, 3, 4]
+`), 0o600))
+	policy, err := LoadAccessPolicy(policyFile)
+	require.NoError(t, err)
+
+	newInit := func() *wire.Init {
+		return &wire.Init{
+			Operators:             ops,
+			T:                     3,
+			WithdrawalCredentials: withdraw.Bytes(),
+			Fork:                  [4]byte{0, 0, 0, 0},
+			Owner:                 owner,
+			Amount:                uint64(crypto.MaxEffectiveBalanceInGwei),
+			WithdrawalPrefix:      crypto.ETH1WithdrawalPrefixByte,
+			InitiatorPublicKey:    encInitiatorPubKey,
+			Timestamp:             uint64(time.Now().Unix()),
+			Expiry:                uint64(time.Now().Add(wire.DefaultInitTTL).Unix()),
+		}
+	}
+	t.Run("allowed ceremony", func(t *testing.T) {
+		// the operator itself (ID 1) doesn't have to be listed
+		require.NoError(t, policy.CheckInit(newInit(), &initiatorKey.PublicKey, &privateKey.PublicKey))
+	})
+	t.Run("rejected ceremonies", func(t *testing.T) {
+		tests := []struct {
+			name   string
+			modify func(init *wire.Init)
+			err    string
+		}{
+			{"owner", func(init *wire.Init) { init.Owner = common.HexToAddress("0x08") }, "owner 0x0000000000000000000000000000000000000008 is not allowed by the operator policy"},
+			{"withdrawal address", func(init *wire.Init) { init.WithdrawalCredentials = common.HexToAddress("0x08").Bytes() }, "withdrawal address 0000000000000000000000000000000000000008 is not allowed by the operator policy"},
+			{"network", func(init *wire.Init) { init.Fork = [4]byte{0x00, 0x00, 0x10, 0x20} }, "network prater is not allowed by the operator policy"},
+			{"co-operator", func(init *wire.Init) { init.Operators = append(init.Operators, &wire.Operator{ID: 5, PubKey: encInitiatorPubKey}) }, "operator 5 is not allowed by the operator policy"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				init := newInit()
+				tt.modify(init)
+				err := policy.CheckInit(init, &initiatorKey.PublicKey, &privateKey.PublicKey)
+				require.ErrorIs(t, err, ErrNotAllowed)
+				require.EqualError(t, err, tt.err)
+			})
+		}
+		err := policy.CheckInit(newInit(), &otherInitiatorKey.PublicKey, &privateKey.PublicKey)
+		require.ErrorIs(t, err, ErrNotAllowed)
+		require.ErrorContains(t, err, "initiator")
+	})
+	t.Run("sign requests", func(t *testing.T) {
+		require.NoError(t, policy.CheckSign(&initiatorKey.PublicKey))
+		err := policy.CheckSign(&otherInitiatorKey.PublicKey)
+		require.ErrorIs(t, err, ErrNotAllowed)
+		require.ErrorContains(t, err, "initiator")
+	})
+	t.Run("empty policy allows any ceremony", func(t *testing.T) {
+		empty, err := NewAccessPolicy(AccessPolicyConfig{})
+		require.NoError(t, err)
+		init := newInit()
+		init.Owner = common.HexToAddress("0x08")
+		require.NoError(t, empty.CheckInit(init, &otherInitiatorKey.PublicKey, &privateKey.PublicKey))
+	})
+	t.Run("wrong policy", func(t *testing.T) {
+		_, err := NewAccessPolicy(AccessPolicyConfig{Networks: []string{"goerli"}})
+		require.ErrorContains(t, err, "unknown network goerli")
+		_, err = NewAccessPolicy(AccessPolicyConfig{Owners: []string{"0x07"}})
+		require.ErrorContains(t, err, "wrong owner: 0x07 is not an address")
+		_, err = NewAccessPolicy(AccessPolicyConfig{Initiators: []string{"key"}})
+		require.ErrorContains(t, err, "wrong initiator public key")
+	})
+	t.Run("switch rejects ceremony", func(t *testing.T) {
+		swtch := NewSwitch(privateKey, logger)
+		swtch.AccessPolicy = policy
+		init := newInit()
+		init.Owner = common.HexToAddress("0x08")
+		initMsg, err := init.MarshalSSZ()
+		require.NoError(t, err)
+		var reqID [24]byte
+		copy(reqID[:], "testPolicyRequestID12345")
+		transport := &wire.Transport{
+			Version:    wire.ProtocolVersion,
+			Type:       wire.InitMessageType,
+			Identifier: reqID,
+			Data:       initMsg,
+		}
+		transportSSZ, err := transport.MarshalSSZ()
+		require.NoError(t, err)
+		sig, err := crypto.SignRSA(initiatorKey, transportSSZ)
+		require.NoError(t, err)
+		_, err = swtch.InitInstance(reqID, transport, sig)
+		require.True(t, errors.Is(err, ErrNotAllowed))
+		require.EqualError(t, err, "init: owner 0x0000000000000000000000000000000000000008 is not allowed by the operator policy")
+		require.Len(t, swtch.Instances, 0)
+	})
+}
@@ -2,17 +2,24 @@ package operator
 
 import (
 	"bytes"
+	"context"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha256"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
 	"github.com/bloxapp/ssv-dkg/pkgs/dkg"
+	"github.com/bloxapp/ssv-dkg/pkgs/metrics"
+	"github.com/bloxapp/ssv-dkg/pkgs/network"
+	"github.com/bloxapp/ssv-dkg/pkgs/store"
+	"github.com/bloxapp/ssv-dkg/pkgs/threshold"
 	"github.com/bloxapp/ssv-dkg/pkgs/wire"
 	"github.com/bloxapp/ssv/utils/rsaencryption"
 	bls3 "github.com/drand/kyber-bls12381"
@@ -22,15 +29,34 @@ import (
 const MaxInstances = 1024
 const MaxInstanceTime = 5 * time.Minute
 
+// InitRateLimit is the max number of requests to the init routes per minute
+const InitRateLimit = 5
+
+// MaxInitBatchSize is the max number of init messages in a batch. A batch counts as a single request
+// for the rate limit, so its size is capped to the limit.
+const MaxInitBatchSize = InitRateLimit
+
+// maxBatchParallelism is the max number of messages of a batch processed in parallel
+const maxBatchParallelism = 8
+
 var ErrMissingInstance = errors.New("got message to instance that I don't have, send Init first")
 var ErrAlreadyExists = errors.New("got init msg for existing instance")
 var ErrMaxInstances = errors.New("max number of instances ongoing, please wait")
+var ErrMaxInitBatchSize = fmt.Errorf("max %d init messages in a batch", MaxInitBatchSize)
+var errInitiatorSignature = errors.New("initiator signature isn't valid")
+var ErrReplayed = errors.New("request ID of the init message was already used")
+var ErrStopped = errors.New("operator is stopped")
 
 type Instance interface {
 	Process(uint64, *wire.SignedTransport) error
-	ReadResponse() []byte
+	StartWithExchanges() error
+	ReadResponse(ctx context.Context) ([]byte, error)
 	ReadError() error
 	VerifyInitiatorMessage(msg, sig []byte) error
+	Status() dkg.Status
+	Initiator() *rsa.PublicKey
+	ProcessOnce(ctx context.Context, hash [32]byte, f func() error) ([]byte, error)
+	Cancel()
 }
 
 type instWrapper struct {
@@ -38,6 +64,11 @@ type instWrapper struct {
 	InitiatorPublicKey *rsa.PublicKey
 	respChan           chan []byte
 	errChan            chan error
+	// responses to the processed messages of the initiator, by hash of the messages
+	responses map[[32]byte][]byte
+	// pending is the hash of processed messages whose response wasn't read before their request was done
+	pending *[32]byte
+	mtx     sync.Mutex
 }
 
 func (iw *instWrapper) VerifyInitiatorMessage(msg []byte, sig []byte) error {
@@ -52,18 +83,92 @@ func (iw *instWrapper) VerifyInitiatorMessage(msg []byte, sig []byte) error {
 	return nil
 }
 
-func (iw *instWrapper) ReadResponse() []byte {
-	return <-iw.respChan
+func (iw *instWrapper) Initiator() *rsa.PublicKey {
+	return iw.InitiatorPublicKey
+}
+
+// ReadResponse waits for the response of the instance until the ceremony is cancelled or the context is done
+func (iw *instWrapper) ReadResponse(ctx context.Context) ([]byte, error) {
+	select {
+	case resp := <-iw.respChan:
+		return resp, nil
+	case <-iw.Cancelled():
+		return nil, dkg.ErrCancelled
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
 }
 func (iw *instWrapper) ReadError() error {
 	return <-iw.errChan
 }
 
+// ProcessOnce processes messages of the initiator one at a time. Messages processed successfully before aren't processed
+// again, the previous response is returned to let the initiator resume an interrupted ceremony. If the context is done
+// before the response, the response is kept for the same messages sent again.
+func (iw *instWrapper) ProcessOnce(ctx context.Context, hash [32]byte, f func() error) ([]byte, error) {
+	iw.mtx.Lock()
+	defer iw.mtx.Unlock()
+	if resp, ok := iw.responses[hash]; ok {
+		iw.Logger.Info("Returning the response to already processed messages", zap.Uint64("from", iw.ID))
+		return resp, nil
+	}
+	if iw.pending == nil {
+		if err := f(); err != nil {
+			return nil, err
+		}
+		iw.pending = &hash
+	} else if *iw.pending != hash {
+		return nil, fmt.Errorf("response to previous messages wasn't read yet")
+	}
+	resp, err := iw.ReadResponse(ctx)
+	if err != nil {
+		return nil, err
+	}
+	iw.pending = nil
+	iw.responses[hash] = resp
+	return resp, nil
+}
+
 type InstanceID [24]byte
 
-func (s *Switch) CreateInstance(reqID [24]byte, init *wire.Init, initiatorPublicKey *rsa.PublicKey) (Instance, []byte, error) {
+// InstanceStatus is the state of a DKG instance reported by the status API
+type InstanceStatus struct {
+	RequestID string `json:"requestID"`
+	// Initiator is the hash of the initiator RSA public key, as printed in the logs
+	Initiator string    `json:"initiator"`
+	Operators []uint64  `json:"operators"`
+	Threshold uint64    `json:"threshold"`
+	StartTime time.Time `json:"startTime"`
+	Phase     dkg.Phase `json:"phase"`
+	// ValidatorPubKey is the created or reshared validator public key, set when the ceremony finished successfully
+	ValidatorPubKey string `json:"validatorPubKey,omitempty"`
+	// Error is the reason the ceremony failed
+	Error string `json:"error,omitempty"`
+	// RejectedMessages is the number of messages of other operators rejected without failing the ceremony
+	RejectedMessages uint64 `json:"rejectedMessages,omitempty"`
+	// LastRejection is the reason the last message was rejected
+	LastRejection string `json:"lastRejection,omitempty"`
+}
+
+// CreateInstance creates an instance of a new validator ceremony, running the given protocol version
+func (s *Switch) CreateInstance(reqID [24]byte, version uint64, init *wire.Init, initiatorPublicKey *rsa.PublicKey) (Instance, []byte, error) {
+	return s.createInstance(reqID, version, init.Operators, init.Owner, init.Nonce, initiatorPublicKey, func(owner *dkg.LocalOwner) (*wire.Transport, error) {
+		return owner.Init(reqID, init)
+	})
+}
+
+// CreateReshareInstance creates an instance resharing an existing validator key from the old operators to the new ones
+func (s *Switch) CreateReshareInstance(reqID [24]byte, version uint64, reshare *wire.Reshare, initiatorPublicKey *rsa.PublicKey) (Instance, []byte, error) {
+	return s.createInstance(reqID, version, dkg.ReshareOperators(reshare), reshare.Owner, reshare.Nonce, initiatorPublicKey, func(owner *dkg.LocalOwner) (*wire.Transport, error) {
+		return owner.InitReshare(reqID, reshare)
+	})
+}
 
-	verify, err := s.CreateVerifyFunc(init.Operators)
+func (s *Switch) createInstance(reqID [24]byte, version uint64, ops []*wire.Operator, ownerAddr [20]byte, nonce uint64, initiatorPublicKey *rsa.PublicKey, initF func(*dkg.LocalOwner) (*wire.Transport, error)) (Instance, []byte, error) {
+	if s.ctx.Err() != nil {
+		return nil, nil, ErrStopped
+	}
+	verify, err := s.CreateVerifyFunc(ops)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -74,7 +179,7 @@ func (s *Switch) CreateInstance(reqID [24]byte, init *wire.Init, initiatorPublic
 	if err != nil {
 		return nil, nil, err
 	}
-	for _, op := range init.Operators {
+	for _, op := range ops {
 		if bytes.Equal(op.PubKey, pkBytes) {
 			operatorID = op.ID
 			break
@@ -87,27 +192,38 @@ func (s *Switch) CreateInstance(reqID [24]byte, init *wire.Init, initiatorPublic
 
 	bchan := make(chan []byte, 1)
 
+	var owner *dkg.LocalOwner
+	// nobody reads the messages of a cancelled ceremony
 	broadcast := func(msg []byte) error {
-		bchan <- msg
-		return nil
+		select {
+		case bchan <- msg:
+			return nil
+		case <-owner.Cancelled():
+			return dkg.ErrCancelled
+		}
 	}
 
 	opts := dkg.OwnerOpts{
-		Logger:      s.Logger.With(zap.String("instance", hex.EncodeToString(reqID[:]))),
-		BroadcastF:  broadcast,
-		SignFunc:    s.Sign,
-		VerifyFunc:  verify,
-		EncryptFunc: s.Encrypt,
-		DecryptFunc: s.Decrypt,
-		Suite:       bls3.NewBLS12381Suite(),
-		ID:          operatorID,
-		RSAPub:      &s.PrivateKey.PublicKey,
-		Owner:       init.Owner,
-		Nonce:       init.Nonce,
-	}
-	owner := dkg.New(opts)
+		Logger:       s.Logger.With(zap.String("instance", hex.EncodeToString(reqID[:]))),
+		BroadcastF:   broadcast,
+		SignFunc:     s.Sign,
+		VerifyFunc:   verify,
+		EncryptFunc:  s.Encrypt,
+		DecryptFunc:  s.Decrypt,
+		Suite:        bls3.NewBLS12381Suite(),
+		ID:           operatorID,
+		RSAPub:       &s.PrivateKey.PublicKey,
+		Owner:        ownerAddr,
+		Nonce:        nonce,
+		Store:        s.Store,
+		Phaser:       s.Phaser,
+		PhaseTimeout: s.PhaseTimeout,
+		Version:      version,
+		Ctx:          s.ctx,
+	}
+	owner = dkg.New(opts)
 	// wait for exchange msg
-	resp, err := owner.Init(reqID, init)
+	resp, err := initF(owner)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -115,7 +231,13 @@ func (s *Switch) CreateInstance(reqID [24]byte, init *wire.Init, initiatorPublic
 		return nil, nil, err
 	}
 	res := <-bchan
-	return &instWrapper{owner, initiatorPublicKey, bchan, owner.ErrorChan}, res, nil
+	return &instWrapper{
+		LocalOwner:         owner,
+		InitiatorPublicKey: initiatorPublicKey,
+		respChan:           bchan,
+		errChan:            owner.ErrorChan,
+		responses:          make(map[[32]byte][]byte),
+	}, res, nil
 }
 
 func (s *Switch) Sign(msg []byte) ([]byte, error) {
@@ -153,27 +275,140 @@ type Switch struct {
 	InstanceInitTime map[InstanceID]time.Time
 	Instances        map[InstanceID]Instance
 	PrivateKey       *rsa.PrivateKey
+	// Store keeps shares created by the operator, needed for signing and resharing
+	Store *store.Store
+	// Policy limits the number of operators and the threshold of ceremonies the operator takes part in
+	Policy threshold.Policy
+	// AccessPolicy limits initiators and parameters of ceremonies the operator takes part in, any ceremony is allowed if not set
+	AccessPolicy *AccessPolicy
+	// Phaser moves DKG ceremonies between phases
+	Phaser wire.PhaserType
+	// PhaseTimeout is the max duration of a DKG phase, wire.DefaultPhaseTimeout if not set
+	PhaseTimeout time.Duration
+	// Requests records request IDs of init messages until they expire, so replayed init messages are rejected
+	Requests *store.Requests
+	// ctx is the parent context of the instances, done when the switch stops
+	ctx  context.Context
+	stop context.CancelFunc
 }
 
 func NewSwitch(pv *rsa.PrivateKey, logger *zap.Logger) *Switch {
+	ctx, stop := context.WithCancel(context.Background())
 	return &Switch{
 		Logger:           logger,
 		Mtx:              sync.RWMutex{},
 		InstanceInitTime: make(map[InstanceID]time.Time, MaxInstances),
 		Instances:        make(map[InstanceID]Instance, MaxInstances),
 		PrivateKey:       pv,
+		Policy:           threshold.DefaultPolicy,
+		Requests:         store.NewRequests(),
+		ctx:              ctx,
+		stop:             stop,
+	}
+}
+
+// Stop tears down all instances, their ceremonies are cancelled and new ones can't start
+func (s *Switch) Stop() {
+	if s.stop != nil {
+		s.stop()
+	}
+	s.Mtx.Lock()
+	defer s.Mtx.Unlock()
+	for id := range s.Instances {
+		s.removeInstance(id, metrics.ReasonCancelled)
 	}
 }
 
 func (s *Switch) InitInstance(reqID [24]byte, initMsg *wire.Transport, initiatorSignature []byte) ([]byte, error) {
+	resp, err := s.initInstance(reqID, initMsg, initiatorSignature)
+	if err != nil {
+		metrics.CeremoniesFailed.WithLabelValues(initFailureReason(err)).Inc()
+	}
+	return resp, err
+}
+
+func initFailureReason(err error) string {
+	switch {
+	case errors.Is(err, errInitiatorSignature):
+		return metrics.ReasonSignature
+	case errors.Is(err, ErrNotAllowed):
+		return metrics.ReasonNotAllowed
+	case errors.Is(err, ErrMaxInstances):
+		return metrics.ReasonMaxInstances
+	case errors.Is(err, ErrReplayed), errors.Is(err, wire.ErrInitExpired):
+		return metrics.ReasonReplayed
+	default:
+		return metrics.ReasonInvalidInit
+	}
+}
+
+func (s *Switch) initInstance(reqID [24]byte, initMsg *wire.Transport, initiatorSignature []byte) ([]byte, error) {
 	logger := s.Logger.With(zap.String("reqid", hex.EncodeToString(reqID[:])))
 	logger.Info("🚀 Initializing DKG instance")
-	init := &wire.Init{}
-	if err := init.UnmarshalSSZ(initMsg.Data); err != nil {
-		return nil, fmt.Errorf("init: failed to unmarshal init message: %s", err.Error())
+	var initiatorPubKeyBytes []byte
+	var createF func(initiatorPubKey *rsa.PublicKey) (Instance, []byte, error)
+	var checkF func(initiatorPubKey *rsa.PublicKey) error
+	var ceremonyType string
+	var timestamp, expiry uint64
+	if err := wire.CheckVersion(initMsg.Version); err != nil {
+		return nil, fmt.Errorf("init: %w", err)
+	}
+	switch initMsg.Type {
+	case wire.InitMessageType:
+		ceremonyType = "init"
+		init := &wire.Init{}
+		if err := init.UnmarshalSSZ(initMsg.Data); err != nil {
+			return nil, fmt.Errorf("init: failed to unmarshal init message: %s", err.Error())
+		}
+		// reject unknown networks instead of falling back to mainnet, deposit data signed for a wrong network is invalid
+		if _, err := network.ByFork(init.Fork); err != nil {
+			return nil, fmt.Errorf("init: %s", err.Error())
+		}
+		if err := s.Policy.Validate(len(init.Operators), init.T); err != nil {
+			return nil, fmt.Errorf("init: %s", err.Error())
+		}
+		if _, err := crypto.WithdrawalCredentials(init.WithdrawalPrefix, init.WithdrawalCredentials); err != nil {
+			return nil, fmt.Errorf("init: %s", err.Error())
+		}
+		if err := crypto.ValidateDepositAmount(init.WithdrawalPrefix, phase0.Gwei(init.Amount)); err != nil {
+			return nil, fmt.Errorf("init: %s", err.Error())
+		}
+		initiatorPubKeyBytes = init.InitiatorPublicKey
+		timestamp, expiry = init.Timestamp, init.Expiry
+		checkF = func(initiatorPubKey *rsa.PublicKey) error {
+			return s.AccessPolicy.CheckInit(init, initiatorPubKey, &s.PrivateKey.PublicKey)
+		}
+		createF = func(initiatorPubKey *rsa.PublicKey) (Instance, []byte, error) {
+			return s.CreateInstance(reqID, initMsg.Version, init, initiatorPubKey)
+		}
+	case wire.InitReshareMessageType:
+		ceremonyType = "reshare"
+		reshare := &wire.Reshare{}
+		if err := reshare.UnmarshalSSZ(initMsg.Data); err != nil {
+			return nil, fmt.Errorf("init: failed to unmarshal reshare message: %s", err.Error())
+		}
+		if reshare.OldT == 0 || reshare.OldT > uint64(len(reshare.OldOperators)) {
+			return nil, fmt.Errorf("init: wrong old threshold %d for %d old operators", reshare.OldT, len(reshare.OldOperators))
+		}
+		if err := s.Policy.Validate(len(reshare.NewOperators), reshare.NewT); err != nil {
+			return nil, fmt.Errorf("init: new operators: %s", err.Error())
+		}
+		initiatorPubKeyBytes = reshare.InitiatorPublicKey
+		timestamp, expiry = reshare.Timestamp, reshare.Expiry
+		checkF = func(initiatorPubKey *rsa.PublicKey) error {
+			return s.AccessPolicy.CheckReshare(reshare, initiatorPubKey, &s.PrivateKey.PublicKey)
+		}
+		createF = func(initiatorPubKey *rsa.PublicKey) (Instance, []byte, error) {
+			return s.CreateReshareInstance(reqID, initMsg.Version, reshare, initiatorPubKey)
+		}
+	default:
+		return nil, fmt.Errorf("init: unexpected message type %s", initMsg.Type.String())
+	}
+	if err := wire.CheckInitTime(timestamp, expiry, time.Now()); err != nil {
+		return nil, fmt.Errorf("init: %w", err)
 	}
 	// Check that incoming init message signature is valid
-	initiatorPubKey, err := crypto.ParseRSAPubkey(init.InitiatorPublicKey)
+	initiatorPubKey, err := crypto.ParseRSAPubkey(initiatorPubKeyBytes)
 	if err != nil {
 		return nil, fmt.Errorf("init: failed parse initiator public key: %s", err.Error())
 	}
@@ -183,10 +418,15 @@ func (s *Switch) InitInstance(reqID [24]byte, initMsg *wire.Transport, initiator
 	}
 	err = crypto.VerifyRSA(initiatorPubKey, marshalledWireMsg, initiatorSignature)
 	if err != nil {
-		return nil, fmt.Errorf("init: initiator signature isn't valid: %s", err.Error())
+		metrics.SignatureFailures.WithLabelValues("initiator").Inc()
+		return nil, fmt.Errorf("init: %w: %s", errInitiatorSignature, err.Error())
 	}
 	initiatorID := sha256.Sum256(initiatorPubKey.N.Bytes())
 	s.Logger.Info("✅ init message signature is successfully verified", zap.String("from initiator", fmt.Sprintf("%x", initiatorID[:])))
+	// the policy is checked only for authenticated messages, not to disclose it to anyone
+	if err := checkF(initiatorPubKey); err != nil {
+		return nil, fmt.Errorf("init: %w", err)
+	}
 	s.Mtx.Lock()
 	l := len(s.Instances)
 	if l >= MaxInstances {
@@ -203,11 +443,18 @@ func (s *Switch) InitInstance(reqID [24]byte, initMsg *wire.Transport, initiator
 			s.Mtx.Unlock()
 			return nil, ErrAlreadyExists
 		}
-		delete(s.Instances, reqID)
-		delete(s.InstanceInitTime, reqID)
+		s.removeInstance(reqID, metrics.ReasonTimeout)
+	}
+	// the request ID is used once, it's kept until the init message expires and is rejected anyway
+	if err := s.Requests.Add(reqID, time.Unix(int64(expiry), 0).Add(wire.MaxClockSkew)); err != nil {
+		s.Mtx.Unlock()
+		if errors.Is(err, store.ErrRequestSeen) {
+			return nil, ErrReplayed
+		}
+		return nil, fmt.Errorf("init: %s", err.Error())
 	}
 	s.Mtx.Unlock()
-	inst, resp, err := s.CreateInstance(reqID, init, initiatorPubKey)
+	inst, resp, err := createF(initiatorPubKey)
 	if err != nil {
 		return nil, fmt.Errorf("init: failed to create instance: %s", err.Error())
 	}
@@ -220,31 +467,95 @@ func (s *Switch) InitInstance(reqID [24]byte, initMsg *wire.Transport, initiator
 	s.Instances[reqID] = inst
 	s.InstanceInitTime[reqID] = time.Now()
 	s.Mtx.Unlock()
+	metrics.CeremoniesStarted.WithLabelValues(ceremonyType).Inc()
 
 	return resp, nil
 
 }
 
+// removeInstance removes an instance, instances removed before they finished are counted as failed for the reason.
+// The lock should be held.
+func (s *Switch) removeInstance(id InstanceID, reason string) {
+	if inst, ok := s.Instances[id]; ok {
+		if inst.Status().Phase != dkg.PhaseOutput {
+			metrics.CeremoniesFailed.WithLabelValues(reason).Inc()
+		}
+		inst.Cancel()
+	}
+	delete(s.Instances, id)
+	delete(s.InstanceInitTime, id)
+}
+
 func (s *Switch) CleanInstances() int {
 	count := 0
 	for id, instime := range s.InstanceInitTime {
 		if time.Now().After(instime.Add(MaxInstanceTime)) {
-			delete(s.Instances, id)
-			delete(s.InstanceInitTime, id)
+			s.removeInstance(id, metrics.ReasonTimeout)
 			count++
 		}
 	}
 	return count
 }
 
-func (s *Switch) ProcessMessage(dkgMsg []byte) ([]byte, error) {
+// InstanceStatuses lists the instances held by the operator, oldest first
+func (s *Switch) InstanceStatuses() []InstanceStatus {
+	s.Mtx.RLock()
+	defer s.Mtx.RUnlock()
+	statuses := make([]InstanceStatus, 0, len(s.Instances))
+	for id, inst := range s.Instances {
+		statuses = append(statuses, s.instanceStatus(id, inst))
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].StartTime.Before(statuses[j].StartTime)
+	})
+	return statuses
+}
+
+// InstanceStatus returns the status of an instance by its request ID
+func (s *Switch) InstanceStatus(id InstanceID) (InstanceStatus, error) {
+	s.Mtx.RLock()
+	defer s.Mtx.RUnlock()
+	inst, ok := s.Instances[id]
+	if !ok {
+		return InstanceStatus{}, fmt.Errorf("instance %x not found", id[:])
+	}
+	return s.instanceStatus(id, inst), nil
+}
+
+func (s *Switch) instanceStatus(id InstanceID, inst Instance) InstanceStatus {
+	status := inst.Status()
+	initiatorID := sha256.Sum256(inst.Initiator().N.Bytes())
+	res := InstanceStatus{
+		RequestID: hex.EncodeToString(id[:]),
+		Initiator: hex.EncodeToString(initiatorID[:]),
+		Operators: status.Operators,
+		Threshold: status.Threshold,
+		StartTime: s.InstanceInitTime[id],
+		Phase:     status.Phase,
+		Error:     status.Error,
+
+		RejectedMessages: status.RejectedMessages,
+		LastRejection:    status.LastRejection,
+	}
+	if status.ValidatorPubKey != nil {
+		res.ValidatorPubKey = hex.EncodeToString(status.ValidatorPubKey)
+	}
+	return res
+}
+
+// ProcessMessage passes the messages of the initiator to their instance and waits for its response
+// until the context is done
+func (s *Switch) ProcessMessage(ctx context.Context, dkgMsg []byte) ([]byte, error) {
 	// get instanceID
 	st := &wire.MultipleSignedTransports{}
 	err := st.UnmarshalSSZ(dkgMsg)
 	if err != nil {
 		return nil, fmt.Errorf("process message: failed to unmarshal dkg message: %s", err.Error())
 	}
+	return s.processInstanceMessage(ctx, st)
+}
 
+func (s *Switch) processInstanceMessage(ctx context.Context, st *wire.MultipleSignedTransports) ([]byte, error) {
 	id := InstanceID(st.Identifier)
 
 	s.Mtx.RLock()
@@ -263,17 +574,179 @@ func (s *Switch) ProcessMessage(dkgMsg []byte) ([]byte, error) {
 		mltplMsgsBytes = append(mltplMsgsBytes, tsBytes...)
 	}
 	// Verify initiator signature
-	err = inst.VerifyInitiatorMessage(mltplMsgsBytes, st.Signature)
+	err := inst.VerifyInitiatorMessage(mltplMsgsBytes, st.Signature)
 	if err != nil {
+		metrics.SignatureFailures.WithLabelValues("initiator").Inc()
 		return nil, fmt.Errorf("process message: failed to verify initiator signature: %s", err.Error())
 	}
-	for _, ts := range st.Messages {
-		err = inst.Process(ts.Signer, ts)
-		if err != nil {
-			return nil, fmt.Errorf("process message: failed to process dkg message: %s", err.Error())
+	// messages sent again by a resuming initiator get the same response
+	return inst.ProcessOnce(ctx, sha256.Sum256(mltplMsgsBytes), func() error {
+		for _, ts := range st.Messages {
+			if err := inst.Process(ts.Signer, ts); err != nil {
+				return fmt.Errorf("process message: failed to process dkg message: %s", err.Error())
+			}
+		}
+		// exchange messages of offline operators are missing if the initiator tolerates them
+		if len(st.Messages) > 0 && st.Messages[0].Message.Type == wire.ExchangeMessageType {
+			if err := inst.StartWithExchanges(); err != nil {
+				return fmt.Errorf("process message: failed to start dkg: %s", err.Error())
+			}
 		}
+		return nil
+	})
+}
+
+// CancelInstance tears down the instance of a ceremony cancelled by its initiator. The goroutines of the instance end
+// and its secret material is dropped.
+func (s *Switch) CancelInstance(cancelMsg *wire.Transport, initiatorSignature []byte) ([]byte, error) {
+	if cancelMsg.Type != wire.CancelMessageType {
+		return nil, fmt.Errorf("cancel: unexpected message type %s", cancelMsg.Type.String())
+	}
+	id := InstanceID(cancelMsg.Identifier)
+	s.Mtx.RLock()
+	inst, ok := s.Instances[id]
+	s.Mtx.RUnlock()
+	if !ok {
+		return nil, ErrMissingInstance
+	}
+	msgBytes, err := cancelMsg.MarshalSSZ()
+	if err != nil {
+		return nil, fmt.Errorf("cancel: failed to marshal message: %s", err.Error())
+	}
+	if err := inst.VerifyInitiatorMessage(msgBytes, initiatorSignature); err != nil {
+		metrics.SignatureFailures.WithLabelValues("initiator").Inc()
+		return nil, fmt.Errorf("cancel: failed to verify initiator signature: %s", err.Error())
+	}
+	s.Mtx.Lock()
+	defer s.Mtx.Unlock()
+	// the instance could be replaced by a new one after the message was verified
+	if s.Instances[id] != inst {
+		return nil, ErrMissingInstance
 	}
-	resp := inst.ReadResponse()
+	s.removeInstance(id, metrics.ReasonCancelled)
+	s.Logger.Info("🛑 Instance cancelled by the initiator", zap.String("reqid", hex.EncodeToString(id[:])))
+	return nil, nil
+}
 
-	return resp, nil
+// InitInstances initializes an instance for every init message of the batch.
+// A failure of one instance doesn't affect others, its error is returned at the instance's place of the response.
+func (s *Switch) InitInstances(batchMsg []byte) ([]byte, error) {
+	batch := &wire.BatchSignedTransports{}
+	if err := batch.UnmarshalSSZ(batchMsg); err != nil {
+		return nil, fmt.Errorf("init: failed to unmarshal batch message: %s", err.Error())
+	}
+	if len(batch.Messages) > MaxInitBatchSize {
+		return nil, ErrMaxInitBatchSize
+	}
+	return fanOut(len(batch.Messages), func(i int) ([]byte, error) {
+		msg := batch.Messages[i]
+		return s.InitInstance(msg.Message.Identifier, msg.Message, msg.Signature)
+	})
+}
+
+// ProcessBatchMessage passes every message of the batch to its instance, instances process their messages in parallel.
+// A failure of one instance doesn't affect others, its error is returned at the instance's place of the response.
+func (s *Switch) ProcessBatchMessage(ctx context.Context, batchMsg []byte) ([]byte, error) {
+	batch := &wire.BatchMultipleSignedTransports{}
+	if err := batch.UnmarshalSSZ(batchMsg); err != nil {
+		return nil, fmt.Errorf("process message: failed to unmarshal batch message: %s", err.Error())
+	}
+	return fanOut(len(batch.Messages), func(i int) ([]byte, error) {
+		return s.processInstanceMessage(ctx, batch.Messages[i])
+	})
+}
+
+// fanOut runs f for every message of a batch, up to maxBatchParallelism in parallel, and collects the responses in order
+func fanOut(n int, f func(i int) ([]byte, error)) ([]byte, error) {
+	resp := &wire.BatchResponse{Responses: make([][]byte, n)}
+	sem := make(chan struct{}, maxBatchParallelism)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			res, err := f(i)
+			if err != nil {
+				res = wire.MakeErr(err)
+			}
+			resp.Responses[i] = res
+		}(i)
+	}
+	wg.Wait()
+	return resp.MarshalSSZ()
+}
+
+// ProcessSignRequest signs the requested root with the stored share of the validator key held by the requested operators.
+// Only the initiator who created the validator is allowed to request signatures, if the access policy allows it.
+func (s *Switch) ProcessSignRequest(signMsg *wire.Transport, initiatorSignature []byte) ([]byte, error) {
+	if signMsg.Type != wire.BlsSignRequestType {
+		return nil, fmt.Errorf("sign: unexpected message type %s", signMsg.Type.String())
+	}
+	if err := wire.CheckVersion(signMsg.Version); err != nil {
+		return nil, fmt.Errorf("sign: %w", err)
+	}
+	keySign := &wire.KeySign{}
+	if err := keySign.UnmarshalSSZ(signMsg.Data); err != nil {
+		return nil, fmt.Errorf("sign: failed to unmarshal sign request: %s", err.Error())
+	}
+	// sign requests expire like init messages, a captured request can't be sent to the operators again later
+	if err := wire.CheckInitTime(keySign.Timestamp, keySign.Expiry, time.Now()); err != nil {
+		return nil, fmt.Errorf("sign: %w", err)
+	}
+	if s.Store == nil {
+		return nil, fmt.Errorf("sign: operator doesn't store shares")
+	}
+	share, err := s.Store.Find(keySign.ValidatorPK, keySign.Operators, keySign.T)
+	if err != nil {
+		return nil, fmt.Errorf("sign: %s", err.Error())
+	}
+	initiatorPubKey, err := crypto.ParseRSAPubkey(share.InitiatorPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("sign: failed parse initiator public key: %s", err.Error())
+	}
+	marshalledWireMsg, err := signMsg.MarshalSSZ()
+	if err != nil {
+		return nil, fmt.Errorf("sign: failed to marshal transport message: %s", err.Error())
+	}
+	if err := crypto.VerifyRSA(initiatorPubKey, marshalledWireMsg, initiatorSignature); err != nil {
+		metrics.SignatureFailures.WithLabelValues("initiator").Inc()
+		return nil, fmt.Errorf("sign: initiator signature isn't valid: %s", err.Error())
+	}
+	// the policy is checked only for authenticated messages, not to disclose it to anyone
+	if err := s.AccessPolicy.CheckSign(initiatorPubKey); err != nil {
+		return nil, fmt.Errorf("sign: %w", err)
+	}
+	s.Logger.Info("✅ signing request signature is successfully verified", zap.String("validator", hex.EncodeToString(keySign.ValidatorPK)), zap.String("root", hex.EncodeToString(keySign.SigningRoot)))
+	partialSig := &wire.PartialSignature{
+		ValidatorPK: keySign.ValidatorPK,
+		SigningRoot: keySign.SigningRoot,
+		SharePK:     share.SecretKey.GetPublicKey().Serialize(),
+		Signature:   share.SecretKey.SignByte(keySign.SigningRoot).Serialize(),
+	}
+	data, err := partialSig.MarshalSSZ()
+	if err != nil {
+		return nil, err
+	}
+	resp := &wire.Transport{
+		Version:    signMsg.Version,
+		Type:       wire.BlsSignResponseType,
+		Identifier: signMsg.Identifier,
+		Data:       data,
+	}
+	respBytes, err := resp.MarshalSSZ()
+	if err != nil {
+		return nil, err
+	}
+	sig, err := s.Sign(respBytes)
+	if err != nil {
+		return nil, err
+	}
+	signed := &wire.SignedTransport{
+		Message:   resp,
+		Signer:    share.OperatorID,
+		Signature: sig,
+	}
+	return signed.MarshalSSZ()
 }
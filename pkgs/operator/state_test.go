@@ -1,13 +1,21 @@
 package operator
 
 import (
+	"bytes"
+	"context"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
 	"fmt"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+	"github.com/bloxapp/ssv-dkg/pkgs/dkg"
+	"github.com/bloxapp/ssv-dkg/pkgs/store"
 	"github.com/bloxapp/ssv-dkg/pkgs/wire"
 	"github.com/bloxapp/ssv/logging"
 	"github.com/bloxapp/ssv/utils/rsaencryption"
@@ -70,7 +78,7 @@ func TestCreateInstance(t *testing.T) {
 			InitiatorPublicKey: encPubKey,
 		}
 
-		inst, resp, err := s.CreateInstance(reqID, init, &priv.PublicKey)
+		inst, resp, err := s.CreateInstance(reqID, wire.ProtocolVersion, init, &priv.PublicKey)
 
 		require.NoError(t, err)
 		require.NotNil(t, inst)
@@ -116,15 +124,22 @@ func TestInitInstance(t *testing.T) {
 	init := &wire.Init{
 		// Populate the Init message fields as needed for testing
 		// For example:
-		Operators:          ops,
-		Owner:              common.HexToAddress("0x0000000"),
-		Nonce:              1,
-		InitiatorPublicKey: encPubKey,
+		Operators:             ops,
+		Owner:                 common.HexToAddress("0x0000000"),
+		Nonce:                 1,
+		InitiatorPublicKey:    encPubKey,
+		T:                     3,
+		WithdrawalCredentials: common.HexToAddress("0x0000000000000000000000000000000000000009").Bytes(),
+		Amount:                uint64(crypto.MaxEffectiveBalanceInGwei),
+		WithdrawalPrefix:      crypto.ETH1WithdrawalPrefixByte,
+		Timestamp:             uint64(time.Now().Unix()),
+		Expiry:                uint64(time.Now().Add(wire.DefaultInitTTL).Unix()),
 	}
 
 	initmsg, err := init.MarshalSSZ()
 	require.NoError(t, err)
 	initMessage := &wire.Transport{
+		Version:    wire.ProtocolVersion,
 		Type:       wire.InitMessageType,
 		Identifier: reqID,
 		Data:       initmsg,
@@ -138,11 +153,104 @@ func TestInitInstance(t *testing.T) {
 	require.NotNil(t, resp)
 
 	require.Len(t, swtch.Instances, 1)
+	initiatorID := sha256.Sum256(priv.PublicKey.N.Bytes())
+	statuses := swtch.InstanceStatuses()
+	require.Len(t, statuses, 1)
+	require.Equal(t, hex.EncodeToString(reqID[:]), statuses[0].RequestID)
+	require.Equal(t, hex.EncodeToString(initiatorID[:]), statuses[0].Initiator)
+	require.Equal(t, []uint64{1, 2, 3, 4}, statuses[0].Operators)
+	require.Equal(t, uint64(3), statuses[0].Threshold)
+	require.Equal(t, dkg.PhaseExchange, statuses[0].Phase)
+	require.Empty(t, statuses[0].ValidatorPubKey)
+	require.Empty(t, statuses[0].Error)
+	_, err = swtch.InstanceStatus(InstanceID{1})
+	require.ErrorContains(t, err, "not found")
 
 	resp2, err2 := swtch.InitInstance(reqID, initMessage, sig)
 	require.Equal(t, err2, ErrAlreadyExists)
 	require.Nil(t, resp2)
 
+	// init messages which would result in invalid deposit data are rejected
+	invalidInits := []struct {
+		name   string
+		modify func(init *wire.Init)
+		err    string
+	}{
+		{"unknown network", func(init *wire.Init) { init.Fork = [4]byte{0x01, 0x02, 0x03, 0x04} }, "unknown fork version 01020304"},
+		{"amount over 32 ETH", func(init *wire.Init) { init.Amount = uint64(crypto.MaxEffectiveBalanceInGwei) + 1 }, "deposit amount"},
+		{"unknown credentials type", func(init *wire.Init) { init.WithdrawalPrefix = 3 }, "unsupported withdrawal credentials type 0x03"},
+		{"unsafe threshold", func(init *wire.Init) { init.T = 2 }, "threshold 2 for 4 operators should be between 3 and 4"},
+	}
+	for i, tc := range invalidInits {
+		invalidInit := *init
+		tc.modify(&invalidInit)
+		invalidInitMsg, err := invalidInit.MarshalSSZ()
+		require.NoError(t, err)
+		var invalidReqID [24]byte
+		copy(invalidReqID[:], fmt.Sprintf("testInvalidInit%v", i))
+		invalidTransport := &wire.Transport{
+			Version:    wire.ProtocolVersion,
+			Type:       wire.InitMessageType,
+			Identifier: invalidReqID,
+			Data:       invalidInitMsg,
+		}
+		invalidSSZ, err := invalidTransport.MarshalSSZ()
+		require.NoError(t, err)
+		invalidSig, err := crypto.SignRSA(priv, invalidSSZ)
+		require.NoError(t, err)
+		_, err = swtch.InitInstance(invalidReqID, invalidTransport, invalidSig)
+		require.ErrorContains(t, err, tc.err, tc.name)
+	}
+	require.Len(t, swtch.Instances, 1)
+
+	// init messages of an unsupported protocol version are rejected with a typed error
+	var newerReqID [24]byte
+	copy(newerReqID[:], "testNewerVersion")
+	newerTransport := &wire.Transport{
+		Version:    wire.ProtocolVersion + 1,
+		Type:       wire.InitMessageType,
+		Identifier: newerReqID,
+		Data:       initmsg,
+	}
+	newerSSZ, err := newerTransport.MarshalSSZ()
+	require.NoError(t, err)
+	newerSig, err := crypto.SignRSA(priv, newerSSZ)
+	require.NoError(t, err)
+	_, err = swtch.InitInstance(newerReqID, newerTransport, newerSig)
+	var versionErr *wire.ErrUnsupportedVersion
+	require.ErrorAs(t, err, &versionErr)
+	require.Equal(t, wire.ProtocolVersion+1, versionErr.Version)
+	require.Len(t, swtch.Instances, 1)
+
+	// init messages of a v1 initiator, without the timestamp, expiry and offline flag, get a version error instead of a parse error
+	timeFields := make([]byte, 16)
+	binary.LittleEndian.PutUint64(timeFields[:8], init.Timestamp)
+	binary.LittleEndian.PutUint64(timeFields[8:], init.Expiry)
+	at := bytes.Index(initmsg, timeFields)
+	require.NotEqual(t, -1, at)
+	v1Init := append(append([]byte{}, initmsg[:at]...), initmsg[at+17:]...)
+	// offsets of the operators, withdrawal credentials and initiator public key follow the shorter fixed part
+	for _, off := range []int{0, 12, 48} {
+		binary.LittleEndian.PutUint32(v1Init[off:], binary.LittleEndian.Uint32(v1Init[off:])-17)
+	}
+	require.Error(t, (&wire.Init{}).UnmarshalSSZ(v1Init))
+	var v1ReqID [24]byte
+	copy(v1ReqID[:], "testV1Version")
+	v1Transport := &wire.Transport{
+		Version:    1,
+		Type:       wire.InitMessageType,
+		Identifier: v1ReqID,
+		Data:       v1Init,
+	}
+	v1SSZ, err := v1Transport.MarshalSSZ()
+	require.NoError(t, err)
+	v1Sig, err := crypto.SignRSA(priv, v1SSZ)
+	require.NoError(t, err)
+	_, err = swtch.InitInstance(v1ReqID, v1Transport, v1Sig)
+	require.ErrorAs(t, err, &versionErr)
+	require.Equal(t, uint64(1), versionErr.Version)
+	require.Len(t, swtch.Instances, 1)
+
 	var tested = false
 
 	for i := 0; i < MaxInstances; i++ {
@@ -163,10 +271,92 @@ func TestInitInstance(t *testing.T) {
 
 	swtch.InstanceInitTime[reqID] = time.Now().Add(-6 * time.Minute)
 
+	// the request ID of an expired instance can't be used again
 	resp, err = swtch.InitInstance(reqID, initMessage, sig)
+	require.ErrorIs(t, err, ErrReplayed)
+	require.Nil(t, resp)
+
+}
+
+func TestInitInstances(t *testing.T) {
+	if err := logging.SetGlobalLogger("info", "capital", "console", nil); err != nil {
+		panic(err)
+	}
+	logger := zap.L().Named("state-tests")
+	privateKey, ops := generateOperatorsData(t, 4)
+	swtch := NewSwitch(privateKey, logger)
+
+	_, pv, err := rsaencryption.GenerateKeys()
+	require.NoError(t, err)
+	priv, err := rsaencryption.ConvertPemToPrivateKey(string(pv))
+	require.NoError(t, err)
+	encPubKey, err := crypto.EncodePublicKey(&priv.PublicKey)
 	require.NoError(t, err)
-	require.NotNil(t, resp)
 
+	batch := &wire.BatchSignedTransports{}
+	for i := 0; i < 3; i++ {
+		init := &wire.Init{
+			Operators:             ops,
+			Owner:                 common.HexToAddress("0x0000000"),
+			Nonce:                 uint64(i),
+			InitiatorPublicKey:    encPubKey,
+			T:                     3,
+			WithdrawalCredentials: common.HexToAddress("0x0000000000000000000000000000000000000009").Bytes(),
+			Amount:                uint64(crypto.MaxEffectiveBalanceInGwei),
+			WithdrawalPrefix:      crypto.ETH1WithdrawalPrefixByte,
+			Timestamp:             uint64(time.Now().Unix()),
+			Expiry:                uint64(time.Now().Add(wire.DefaultInitTTL).Unix()),
+		}
+		initmsg, err := init.MarshalSSZ()
+		require.NoError(t, err)
+		var reqID [24]byte
+		copy(reqID[:], fmt.Sprintf("testRequestID123456789%v", i))
+		initMessage := &wire.Transport{
+			Version:    wire.ProtocolVersion,
+			Type:       wire.InitMessageType,
+			Identifier: reqID,
+			Data:       initmsg,
+		}
+		tsssz, err := initMessage.MarshalSSZ()
+		require.NoError(t, err)
+		sig, err := crypto.SignRSA(priv, tsssz)
+		require.NoError(t, err)
+		// break the signature of the second message
+		if i == 1 {
+			sig[0] ^= 0xff
+		}
+		batch.Messages = append(batch.Messages, &wire.SignedTransport{Message: initMessage, Signature: sig})
+	}
+	batchBytes, err := batch.MarshalSSZ()
+	require.NoError(t, err)
+	respBytes, err := swtch.InitInstances(batchBytes)
+	require.NoError(t, err)
+	resp := &wire.BatchResponse{}
+	require.NoError(t, resp.UnmarshalSSZ(respBytes))
+	require.Len(t, resp.Responses, 3)
+	for i, res := range resp.Responses {
+		tsp := &wire.SignedTransport{}
+		if i == 1 {
+			require.Error(t, tsp.UnmarshalSSZ(res))
+			errmsg, parseErr := wire.GetErr(res)
+			require.NoError(t, parseErr)
+			require.ErrorContains(t, errmsg, "initiator signature isn't valid")
+			continue
+		}
+		require.NoError(t, tsp.UnmarshalSSZ(res))
+		require.Equal(t, batch.Messages[i].Message.Identifier, tsp.Message.Identifier)
+	}
+	require.Len(t, swtch.Instances, 2)
+
+	// a batch can't start more instances than the init rate limit allows
+	for len(batch.Messages) <= MaxInitBatchSize {
+		batch.Messages = append(batch.Messages, batch.Messages[0])
+	}
+	batchBytes, err = batch.MarshalSSZ()
+	require.NoError(t, err)
+	_, err = swtch.InitInstances(batchBytes)
+	require.ErrorIs(t, err, ErrMaxInitBatchSize)
+	require.Len(t, swtch.Instances, 2)
 }
 
 func TestSwitch_cleanInstances(t *testing.T) {
@@ -188,15 +378,22 @@ func TestSwitch_cleanInstances(t *testing.T) {
 	init := &wire.Init{
 		// Populate the Init message fields as needed for testing
 		// For example:
-		Operators:          ops,
-		Owner:              common.HexToAddress("0x0000000"),
-		Nonce:              1,
-		InitiatorPublicKey: encPubKey,
+		Operators:             ops,
+		Owner:                 common.HexToAddress("0x0000000"),
+		Nonce:                 1,
+		InitiatorPublicKey:    encPubKey,
+		T:                     3,
+		WithdrawalCredentials: common.HexToAddress("0x0000000000000000000000000000000000000009").Bytes(),
+		Amount:                uint64(crypto.MaxEffectiveBalanceInGwei),
+		WithdrawalPrefix:      crypto.ETH1WithdrawalPrefixByte,
+		Timestamp:             uint64(time.Now().Unix()),
+		Expiry:                uint64(time.Now().Add(wire.DefaultInitTTL).Unix()),
 	}
 
 	initmsg, err := init.MarshalSSZ()
 	require.NoError(t, err)
 	initMessage := &wire.Transport{
+		Version:    wire.ProtocolVersion,
 		Type:       wire.InitMessageType,
 		Identifier: reqID,
 		Data:       initmsg,
@@ -217,3 +414,322 @@ func TestSwitch_cleanInstances(t *testing.T) {
 	require.Len(t, swtch.Instances, 0)
 
 }
+
+func TestSwitch_CancelInstance(t *testing.T) {
+	privateKey, ops := generateOperatorsData(t, 4)
+	logger := zap.L().Named("state-tests")
+	swtch := NewSwitch(privateKey, logger)
+	var reqID [24]byte
+	copy(reqID[:], "testRequestID1234567890")
+	_, pv, err := rsaencryption.GenerateKeys()
+	require.NoError(t, err)
+	priv, err := rsaencryption.ConvertPemToPrivateKey(string(pv))
+	require.NoError(t, err)
+	encPubKey, err := crypto.EncodePublicKey(&priv.PublicKey)
+	require.NoError(t, err)
+
+	init := &wire.Init{
+		Operators:             ops,
+		Owner:                 common.HexToAddress("0x0000000"),
+		Nonce:                 1,
+		InitiatorPublicKey:    encPubKey,
+		T:                     3,
+		WithdrawalCredentials: common.HexToAddress("0x0000000000000000000000000000000000000009").Bytes(),
+		Amount:                uint64(crypto.MaxEffectiveBalanceInGwei),
+		WithdrawalPrefix:      crypto.ETH1WithdrawalPrefixByte,
+		Timestamp:             uint64(time.Now().Unix()),
+		Expiry:                uint64(time.Now().Add(wire.DefaultInitTTL).Unix()),
+	}
+	initmsg, err := init.MarshalSSZ()
+	require.NoError(t, err)
+	initMessage := &wire.Transport{
+		Version:    wire.ProtocolVersion,
+		Type:       wire.InitMessageType,
+		Identifier: reqID,
+		Data:       initmsg,
+	}
+	tsssz, err := initMessage.MarshalSSZ()
+	require.NoError(t, err)
+	sig, err := crypto.SignRSA(priv, tsssz)
+	require.NoError(t, err)
+	_, err = swtch.InitInstance(reqID, initMessage, sig)
+	require.NoError(t, err)
+	inst := swtch.Instances[reqID]
+
+	cancelMessage := &wire.Transport{
+		Version:    wire.ProtocolVersion,
+		Type:       wire.CancelMessageType,
+		Identifier: reqID,
+	}
+	cancelssz, err := cancelMessage.MarshalSSZ()
+	require.NoError(t, err)
+
+	t.Run("test cancel signed by another initiator", func(t *testing.T) {
+		otherSig, err := crypto.SignRSA(privateKey, cancelssz)
+		require.NoError(t, err)
+		_, err = swtch.CancelInstance(cancelMessage, otherSig)
+		require.ErrorContains(t, err, "failed to verify initiator signature")
+		require.Len(t, swtch.Instances, 1)
+	})
+	t.Run("test cancel of wrong message type", func(t *testing.T) {
+		_, err := swtch.CancelInstance(initMessage, sig)
+		require.ErrorContains(t, err, "unexpected message type")
+		require.Len(t, swtch.Instances, 1)
+	})
+	t.Run("test cancel tears down the instance", func(t *testing.T) {
+		cancelSig, err := crypto.SignRSA(priv, cancelssz)
+		require.NoError(t, err)
+		_, err = swtch.CancelInstance(cancelMessage, cancelSig)
+		require.NoError(t, err)
+		require.Len(t, swtch.Instances, 0)
+		require.Len(t, swtch.InstanceInitTime, 0)
+		// a message waiting for the response of the cancelled instance returns
+		_, err = inst.ReadResponse(context.Background())
+		require.ErrorIs(t, err, dkg.ErrCancelled)
+		_, err = swtch.CancelInstance(cancelMessage, cancelSig)
+		require.ErrorIs(t, err, ErrMissingInstance)
+	})
+	t.Run("test request ID can't be used after cancel", func(t *testing.T) {
+		_, err := swtch.InitInstance(reqID, initMessage, sig)
+		require.ErrorIs(t, err, ErrReplayed)
+		require.Len(t, swtch.Instances, 0)
+	})
+}
+
+func TestSwitch_ContextDone(t *testing.T) {
+	privateKey, ops := generateOperatorsData(t, 4)
+	logger := zap.L().Named("state-tests")
+	swtch := NewSwitch(privateKey, logger)
+	var reqID [24]byte
+	copy(reqID[:], "testRequestID0987654321")
+	_, pv, err := rsaencryption.GenerateKeys()
+	require.NoError(t, err)
+	priv, err := rsaencryption.ConvertPemToPrivateKey(string(pv))
+	require.NoError(t, err)
+	encPubKey, err := crypto.EncodePublicKey(&priv.PublicKey)
+	require.NoError(t, err)
+
+	init := &wire.Init{
+		Operators:             ops,
+		Owner:                 common.HexToAddress("0x0000000"),
+		Nonce:                 1,
+		InitiatorPublicKey:    encPubKey,
+		T:                     3,
+		WithdrawalCredentials: common.HexToAddress("0x0000000000000000000000000000000000000009").Bytes(),
+		Amount:                uint64(crypto.MaxEffectiveBalanceInGwei),
+		WithdrawalPrefix:      crypto.ETH1WithdrawalPrefixByte,
+		Timestamp:             uint64(time.Now().Unix()),
+		Expiry:                uint64(time.Now().Add(wire.DefaultInitTTL).Unix()),
+	}
+	initmsg, err := init.MarshalSSZ()
+	require.NoError(t, err)
+	initMessage := &wire.Transport{
+		Version:    wire.ProtocolVersion,
+		Type:       wire.InitMessageType,
+		Identifier: reqID,
+		Data:       initmsg,
+	}
+	tsssz, err := initMessage.MarshalSSZ()
+	require.NoError(t, err)
+	sig, err := crypto.SignRSA(priv, tsssz)
+	require.NoError(t, err)
+	_, err = swtch.InitInstance(reqID, initMessage, sig)
+	require.NoError(t, err)
+	inst := swtch.Instances[reqID].(*instWrapper)
+
+	processed := 0
+	process := func() error {
+		processed++
+		return nil
+	}
+	hash := sha256.Sum256([]byte("messages"))
+	t.Run("test processing returns when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := inst.ProcessOnce(ctx, hash, process)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Equal(t, 1, processed)
+		_, err = inst.ProcessOnce(context.Background(), sha256.Sum256([]byte("other messages")), process)
+		require.ErrorContains(t, err, "wasn't read yet")
+		require.Equal(t, 1, processed)
+	})
+	t.Run("test retry reads the response of the processed messages", func(t *testing.T) {
+		inst.respChan <- []byte("response")
+		resp, err := inst.ProcessOnce(context.Background(), hash, process)
+		require.NoError(t, err)
+		require.Equal(t, []byte("response"), resp)
+		require.Equal(t, 1, processed)
+	})
+	t.Run("test stop tears down instances", func(t *testing.T) {
+		swtch.Stop()
+		require.Len(t, swtch.Instances, 0)
+		_, err := inst.ReadResponse(context.Background())
+		require.ErrorIs(t, err, dkg.ErrCancelled)
+		copy(reqID[:], "testRequestID1111111111")
+		initMessage.Identifier = reqID
+		tsssz, err := initMessage.MarshalSSZ()
+		require.NoError(t, err)
+		sig, err := crypto.SignRSA(priv, tsssz)
+		require.NoError(t, err)
+		_, err = swtch.InitInstance(reqID, initMessage, sig)
+		require.ErrorContains(t, err, ErrStopped.Error())
+	})
+}
+
+func TestExchangeMessages(t *testing.T) {
+	privateKey, ops := generateOperatorsData(t, 4)
+	logger := zap.L().Named("state-tests")
+	swtch := NewSwitch(privateKey, logger)
+	_, pv, err := rsaencryption.GenerateKeys()
+	require.NoError(t, err)
+	priv, err := rsaencryption.ConvertPemToPrivateKey(string(pv))
+	require.NoError(t, err)
+	encPubKey, err := crypto.EncodePublicKey(&priv.PublicKey)
+	require.NoError(t, err)
+	newInit := func(tolerateOffline bool) *wire.Init {
+		return &wire.Init{
+			Operators:             ops,
+			Owner:                 common.HexToAddress("0x0000000"),
+			Nonce:                 1,
+			InitiatorPublicKey:    encPubKey,
+			T:                     3,
+			WithdrawalCredentials: common.HexToAddress("0x0000000000000000000000000000000000000009").Bytes(),
+			Amount:                uint64(crypto.MaxEffectiveBalanceInGwei),
+			WithdrawalPrefix:      crypto.ETH1WithdrawalPrefixByte,
+			Timestamp:             uint64(time.Now().Unix()),
+			Expiry:                uint64(time.Now().Add(wire.DefaultInitTTL).Unix()),
+			TolerateOffline:       tolerateOffline,
+		}
+	}
+	t.Run("test DKG doesn't start without all exchanges unless the initiator opted in", func(t *testing.T) {
+		var reqID [24]byte
+		copy(reqID[:], "testNoOfflineOptIn")
+		inst, _, err := swtch.CreateInstance(reqID, wire.ProtocolVersion, newInit(false), &priv.PublicKey)
+		require.NoError(t, err)
+		require.ErrorContains(t, inst.StartWithExchanges(), "the initiator doesn't tolerate offline operators")
+	})
+	t.Run("test DKG starts without all exchanges if the initiator opted in", func(t *testing.T) {
+		var reqID [24]byte
+		copy(reqID[:], "testOfflineOptIn")
+		inst, _, err := swtch.CreateInstance(reqID, wire.ProtocolVersion, newInit(true), &priv.PublicKey)
+		require.NoError(t, err)
+		require.ErrorContains(t, inst.StartWithExchanges(), "exchange message of operator 1 is missing")
+	})
+	t.Run("test exchange message to a resharing instance is rejected", func(t *testing.T) {
+		var reqID [24]byte
+		copy(reqID[:], "testReshareExchange")
+		reshare := &wire.Reshare{
+			ValidatorPubKey:    make([]byte, 48),
+			OldOperators:       ops[1:],
+			NewOperators:       ops[:3],
+			OldT:               2,
+			NewT:               2,
+			Owner:              common.HexToAddress("0x0000000"),
+			Nonce:              1,
+			InitiatorPublicKey: encPubKey,
+		}
+		inst, _, err := swtch.CreateReshareInstance(reqID, wire.ProtocolVersion, reshare, &priv.PublicKey)
+		require.NoError(t, err)
+		exch, err := (&wire.Exchange{PK: make([]byte, 48)}).MarshalSSZ()
+		require.NoError(t, err)
+		msg := &wire.Transport{
+			Version:    wire.ProtocolVersion,
+			Type:       wire.ExchangeMessageType,
+			Identifier: reqID,
+			Data:       exch,
+		}
+		msgBytes, err := msg.MarshalSSZ()
+		require.NoError(t, err)
+		sig, err := crypto.SignRSA(privateKey, msgBytes)
+		require.NoError(t, err)
+		err = inst.Process(1, &wire.SignedTransport{Message: msg, Signer: 1, Signature: sig})
+		require.ErrorContains(t, err, "received exchange message for a resharing instance")
+	})
+}
+
+func TestReplayedInit(t *testing.T) {
+	privateKey, ops := generateOperatorsData(t, 4)
+	logger := zap.L().Named("state-tests")
+	requestsPath := filepath.Join(t.TempDir(), "requests.json")
+	requests, err := store.OpenRequests(requestsPath)
+	require.NoError(t, err)
+	swtch := NewSwitch(privateKey, logger)
+	swtch.Requests = requests
+	_, pv, err := rsaencryption.GenerateKeys()
+	require.NoError(t, err)
+	priv, err := rsaencryption.ConvertPemToPrivateKey(string(pv))
+	require.NoError(t, err)
+	encPubKey, err := crypto.EncodePublicKey(&priv.PublicKey)
+	require.NoError(t, err)
+
+	signedInit := func(reqID [24]byte, created time.Time, ttl time.Duration) (*wire.Transport, []byte) {
+		timestamp, expiry := wire.InitTime(created, ttl)
+		init := &wire.Init{
+			Operators:             ops,
+			Owner:                 common.HexToAddress("0x0000000"),
+			Nonce:                 1,
+			InitiatorPublicKey:    encPubKey,
+			T:                     3,
+			WithdrawalCredentials: common.HexToAddress("0x0000000000000000000000000000000000000009").Bytes(),
+			Amount:                uint64(crypto.MaxEffectiveBalanceInGwei),
+			WithdrawalPrefix:      crypto.ETH1WithdrawalPrefixByte,
+			Timestamp:             timestamp,
+			Expiry:                expiry,
+		}
+		initmsg, err := init.MarshalSSZ()
+		require.NoError(t, err)
+		initMessage := &wire.Transport{
+			Version:    wire.ProtocolVersion,
+			Type:       wire.InitMessageType,
+			Identifier: reqID,
+			Data:       initmsg,
+		}
+		tsssz, err := initMessage.MarshalSSZ()
+		require.NoError(t, err)
+		sig, err := crypto.SignRSA(priv, tsssz)
+		require.NoError(t, err)
+		return initMessage, sig
+	}
+
+	reqID := crypto.NewID()
+	initMessage, sig := signedInit(reqID, time.Now(), wire.DefaultInitTTL)
+	_, err = swtch.InitInstance(reqID, initMessage, sig)
+	require.NoError(t, err)
+
+	t.Run("test replay after the instance is cleaned", func(t *testing.T) {
+		swtch.InstanceInitTime[reqID] = time.Now().Add(-MaxInstanceTime - time.Second)
+		require.Equal(t, 1, swtch.CleanInstances())
+		_, err := swtch.InitInstance(reqID, initMessage, sig)
+		require.ErrorIs(t, err, ErrReplayed)
+		require.Len(t, swtch.Instances, 0)
+	})
+	t.Run("test replay after restart", func(t *testing.T) {
+		requests, err := store.OpenRequests(requestsPath)
+		require.NoError(t, err)
+		restarted := NewSwitch(privateKey, logger)
+		restarted.Requests = requests
+		_, err = restarted.InitInstance(reqID, initMessage, sig)
+		require.ErrorIs(t, err, ErrReplayed)
+		require.Len(t, restarted.Instances, 0)
+	})
+	t.Run("test expired init", func(t *testing.T) {
+		id := crypto.NewID()
+		expiredMessage, expiredSig := signedInit(id, time.Now().Add(-10*time.Minute), wire.DefaultInitTTL)
+		_, err := swtch.InitInstance(id, expiredMessage, expiredSig)
+		require.ErrorIs(t, err, wire.ErrInitExpired)
+	})
+	t.Run("test init valid for too long", func(t *testing.T) {
+		id := crypto.NewID()
+		longMessage, longSig := signedInit(id, time.Now(), wire.MaxInitTTL+time.Minute)
+		_, err := swtch.InitInstance(id, longMessage, longSig)
+		require.ErrorContains(t, err, "longer than")
+	})
+	t.Run("test unsigned init doesn't use the request ID", func(t *testing.T) {
+		id := crypto.NewID()
+		msg, validSig := signedInit(id, time.Now(), wire.DefaultInitTTL)
+		_, err := swtch.InitInstance(id, msg, append([]byte{}, validSig[1:]...))
+		require.Error(t, err)
+		_, err = swtch.InitInstance(id, msg, validSig)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,59 @@
+package operator
+
+import (
+	"crypto/rsa"
+	"crypto/tls"
+	"fmt"
+
+	"github.com/bloxapp/ssv-dkg/pkgs/crypto"
+)
+
+// TLSOptions configure TLS of the operator server
+type TLSOptions struct {
+	// CertPath and KeyPath are the PEM encoded certificate and key served by the operator
+	CertPath string
+	KeyPath  string
+	// Pinned serves a certificate issued by the operator RSA key instead of a certificate of CertPath,
+	// initiators verify it with the operator public key of operators info
+	Pinned bool
+	// ClientCAPath are PEM encoded certificates of initiators, or of their authorities, the operator trusts.
+	// If set, initiators have to authenticate with a trusted client certificate (mTLS).
+	ClientCAPath string
+}
+
+// Enabled tells if the options enable TLS
+func (o TLSOptions) Enabled() bool {
+	return o.CertPath != "" || o.KeyPath != "" || o.Pinned || o.ClientCAPath != ""
+}
+
+// NewTLSConfig creates the TLS configuration of the operator server
+func NewTLSConfig(key *rsa.PrivateKey, opts TLSOptions) (*tls.Config, error) {
+	var cert tls.Certificate
+	var err error
+	switch {
+	case opts.Pinned && (opts.CertPath != "" || opts.KeyPath != ""):
+		return nil, fmt.Errorf("either a pinned certificate or a certificate and key should be used, not both")
+	case opts.Pinned:
+		cert, err = crypto.PinnedCertificate(key)
+	case opts.CertPath != "" && opts.KeyPath != "":
+		cert, err = tls.LoadX509KeyPair(opts.CertPath, opts.KeyPath)
+	default:
+		return nil, fmt.Errorf("TLS needs either a pinned certificate or both a certificate and a key")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if opts.ClientCAPath != "" {
+		clientCAs, err := crypto.LoadCertPool(opts.ClientCAPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load trusted client certificates: %w", err)
+		}
+		config.ClientCAs = clientCAs
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return config, nil
+}
@@ -65,6 +65,33 @@ func CreateTestOperatorFromFile(t *testing.T, id uint64, examplePath string) *Te
 }
 
 func CreateTestOperator(t *testing.T, id uint64) *TestOperator {
+	priv, s := createTestServer(t)
+	sTest := httptest.NewServer(s.Router)
+	return &TestOperator{
+		ID:      id,
+		PrivKey: priv,
+		HttpSrv: sTest,
+		Srv:     s,
+	}
+}
+
+// CreateTestTLSOperator creates a test operator serving TLS configured by the options
+func CreateTestTLSOperator(t *testing.T, id uint64, opts TLSOptions) *TestOperator {
+	priv, s := createTestServer(t)
+	tlsConfig, err := NewTLSConfig(priv, opts)
+	require.NoError(t, err)
+	sTest := httptest.NewUnstartedServer(s.Router)
+	sTest.TLS = tlsConfig
+	sTest.StartTLS()
+	return &TestOperator{
+		ID:      id,
+		PrivKey: priv,
+		HttpSrv: sTest,
+		Srv:     s,
+	}
+}
+
+func createTestServer(t *testing.T) (*rsa.PrivateKey, *Server) {
 	if err := logging.SetGlobalLogger("info", "capital", "console", nil); err != nil {
 		panic(err)
 	}
@@ -83,13 +110,7 @@ func CreateTestOperator(t *testing.T, id uint64) *TestOperator {
 		State:  swtch,
 	}
 	RegisterRoutes(s)
-	sTest := httptest.NewServer(s.Router)
-	return &TestOperator{
-		ID:      id,
-		PrivKey: priv,
-		HttpSrv: sTest,
-		Srv:     s,
-	}
+	return priv, s
 }
 
 // ReconstructSignatures receives a map of user indexes and serialized bls.Sign.
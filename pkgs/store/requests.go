@@ -0,0 +1,110 @@
+package store
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrRequestSeen is returned for a request ID which is already recorded
+var ErrRequestSeen = errors.New("request ID was already used")
+
+// Requests records the request IDs of ceremonies an operator took part in. A request ID is kept until the init
+// message of the request expires, the message is rejected as expired afterwards.
+type Requests struct {
+	mtx  sync.Mutex
+	path string
+	// seen request IDs with the time they are kept until
+	seen map[[24]byte]time.Time
+}
+
+// NewRequests creates a record of request IDs kept in memory only
+func NewRequests() *Requests {
+	return &Requests{seen: make(map[[24]byte]time.Time)}
+}
+
+// OpenRequests loads the record of request IDs at the path, the record is saved to the path on every change
+func OpenRequests(path string) (*Requests, error) {
+	r := &Requests{path: path, seen: make(map[[24]byte]time.Time)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			return nil, err
+		}
+		return r, r.save()
+	}
+	if err != nil {
+		return nil, err
+	}
+	var f map[string]time.Time
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+	for id, until := range f {
+		reqID, err := hex.DecodeString(id)
+		if err != nil {
+			return nil, err
+		}
+		if len(reqID) != 24 {
+			return nil, fmt.Errorf("wrong request ID length %d", len(reqID))
+		}
+		var rid [24]byte
+		copy(rid[:], reqID)
+		r.seen[rid] = until
+	}
+	return r, nil
+}
+
+// Add records the request ID until the given time, ErrRequestSeen if it's already recorded.
+// The request ID isn't recorded if the record can't be saved.
+func (r *Requests) Add(reqID [24]byte, until time.Time) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	now := time.Now()
+	for id, t := range r.seen {
+		if now.After(t) {
+			delete(r.seen, id)
+		}
+	}
+	if _, ok := r.seen[reqID]; ok {
+		return ErrRequestSeen
+	}
+	r.seen[reqID] = until
+	if err := r.save(); err != nil {
+		delete(r.seen, reqID)
+		return fmt.Errorf("failed to save request IDs: %w", err)
+	}
+	return nil
+}
+
+// Len returns the number of recorded request IDs
+func (r *Requests) Len() int {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return len(r.seen)
+}
+
+// save writes the record to a temporary file which replaces the previous one, so a crash doesn't leave a partial record
+func (r *Requests) save() error {
+	if r.path == "" {
+		return nil
+	}
+	f := make(map[string]time.Time, len(r.seen))
+	for id, until := range r.seen {
+		f[hex.EncodeToString(id[:])] = until
+	}
+	data, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	tmp := r.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, r.path)
+}
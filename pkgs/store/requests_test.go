@@ -0,0 +1,41 @@
+package store
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequests(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "requests", "requests.json")
+	r, err := OpenRequests(path)
+	require.NoError(t, err)
+	require.FileExists(t, path)
+
+	require.NoError(t, r.Add([24]byte{1}, time.Now().Add(time.Minute)))
+	require.ErrorIs(t, r.Add([24]byte{1}, time.Now().Add(time.Minute)), ErrRequestSeen)
+	require.NoError(t, r.Add([24]byte{2}, time.Now().Add(time.Minute)))
+
+	t.Run("test request IDs are kept after restart", func(t *testing.T) {
+		r, err := OpenRequests(path)
+		require.NoError(t, err)
+		require.Equal(t, 2, r.Len())
+		require.ErrorIs(t, r.Add([24]byte{1}, time.Now().Add(time.Minute)), ErrRequestSeen)
+	})
+	t.Run("test expired request IDs are dropped", func(t *testing.T) {
+		require.NoError(t, r.Add([24]byte{3}, time.Now().Add(-time.Second)))
+		require.NoError(t, r.Add([24]byte{4}, time.Now().Add(time.Minute)))
+		require.Equal(t, 3, r.Len())
+		r, err := OpenRequests(path)
+		require.NoError(t, err)
+		require.Equal(t, 3, r.Len())
+		require.NoError(t, r.Add([24]byte{3}, time.Now().Add(time.Minute)))
+	})
+	t.Run("test in memory record", func(t *testing.T) {
+		r := NewRequests()
+		require.NoError(t, r.Add([24]byte{1}, time.Now().Add(time.Minute)))
+		require.ErrorIs(t, r.Add([24]byte{1}, time.Now().Add(time.Minute)), ErrRequestSeen)
+	})
+}
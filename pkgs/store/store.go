@@ -0,0 +1,285 @@
+package store
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/herumi/bls-eth-go-binary/bls"
+	keystorev4 "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+const shareFilePrefix = "share_"
+
+func init() {
+	_ = bls.Init(bls.BLS12_381)
+	_ = bls.SetETHmode(bls.EthModeDraft07)
+}
+
+// Share is an operator's BLS key share of a validator created by a DKG or resharing ceremony
+type Share struct {
+	// RequestID of the ceremony which created the share
+	RequestID [24]byte
+	// ValidatorPubKey the public key of the shared validator key
+	ValidatorPubKey []byte
+	// OperatorID of the share holder, share index is OperatorID - 1
+	OperatorID uint64
+	// SecretKey BLS key share
+	SecretKey *bls.SecretKey
+	// Commits public polynomial commitments of the shared key
+	Commits [][]byte
+	// Operators holding shares of the validator key
+	Operators []uint64
+	// Threshold needed to sign with the shares
+	Threshold uint64
+	// InitiatorPublicKey of the initiator who started the ceremony
+	InitiatorPublicKey []byte
+	// CreatedAt time the share was stored
+	CreatedAt time.Time
+}
+
+// shareFile is a share as written to disk, the secret is kept as EIP-2335 keystore
+type shareFile struct {
+	RequestID          string                 `json:"request_id"`
+	ValidatorPubKey    string                 `json:"validator_pubkey"`
+	OperatorID         uint64                 `json:"operator_id"`
+	Commits            []string               `json:"commits"`
+	Operators          []uint64               `json:"operators"`
+	Threshold          uint64                 `json:"threshold"`
+	InitiatorPublicKey string                 `json:"initiator_pubkey"`
+	CreatedAt          time.Time              `json:"created_at"`
+	Keystore           map[string]interface{} `json:"keystore"`
+}
+
+// Store keeps operator's shares encrypted with the operator password at a directory
+type Store struct {
+	mtx      sync.RWMutex
+	dir      string
+	password string
+	// shares indexed by validator public key and request ID
+	shares map[string]map[[24]byte]*Share
+}
+
+// New opens the store at the directory, creating it if needed, and loads all stored shares
+func New(dir, password string) (*Store, error) {
+	if password == "" {
+		return nil, fmt.Errorf("password is required to encrypt shares")
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	s := &Store{
+		dir:      dir,
+		password: password,
+		shares:   make(map[string]map[[24]byte]*Share),
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasPrefix(e.Name(), shareFilePrefix) {
+			continue
+		}
+		share, err := s.load(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load share %s: %w", e.Name(), err)
+		}
+		s.index(share)
+	}
+	return s, nil
+}
+
+// Save encrypts and writes the share to disk
+func (s *Store) Save(share *Share) error {
+	if share.CreatedAt.IsZero() {
+		share.CreatedAt = time.Now().UTC()
+	}
+	sharePubKey := share.SecretKey.GetPublicKey().Serialize()
+	crypto, err := keystorev4.New().Encrypt(share.SecretKey.Serialize(), s.password)
+	if err != nil {
+		return err
+	}
+	commits := make([]string, 0, len(share.Commits))
+	for _, c := range share.Commits {
+		commits = append(commits, hex.EncodeToString(c))
+	}
+	operators := make([]uint64, len(share.Operators))
+	copy(operators, share.Operators)
+	sort.Slice(operators, func(i, j int) bool { return operators[i] < operators[j] })
+	share.Operators = operators
+	f := &shareFile{
+		RequestID:          hex.EncodeToString(share.RequestID[:]),
+		ValidatorPubKey:    hex.EncodeToString(share.ValidatorPubKey),
+		OperatorID:         share.OperatorID,
+		Commits:            commits,
+		Operators:          operators,
+		Threshold:          share.Threshold,
+		InitiatorPublicKey: string(share.InitiatorPublicKey),
+		CreatedAt:          share.CreatedAt,
+		Keystore: map[string]interface{}{
+			"crypto":      crypto,
+			"description": fmt.Sprintf("ssv-dkg share of validator %x", share.ValidatorPubKey),
+			"pubkey":      hex.EncodeToString(sharePubKey),
+			"path":        "",
+			"uuid":        uuid.New().String(),
+			"version":     4,
+		},
+	}
+	data, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(s.path(share.ValidatorPubKey, share.RequestID), data, 0600); err != nil {
+		return err
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.index(share)
+	return nil
+}
+
+// Get returns the share of the validator created by the given request
+func (s *Store) Get(validatorPubKey []byte, reqID [24]byte) (*Share, bool) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	share, ok := s.shares[hex.EncodeToString(validatorPubKey)][reqID]
+	return share, ok
+}
+
+// Latest returns the most recently created share of the validator,
+// an operator may hold several shares of a validator after resharing
+func (s *Store) Latest(validatorPubKey []byte) (*Share, error) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	var latest *Share
+	for _, share := range s.shares[hex.EncodeToString(validatorPubKey)] {
+		if latest == nil || share.CreatedAt.After(latest.CreatedAt) {
+			latest = share
+		}
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("no stored share of validator %x", validatorPubKey)
+	}
+	return latest, nil
+}
+
+// Find returns the share of the validator held by the given operators set with the given threshold
+func (s *Store) Find(validatorPubKey []byte, operators []uint64, threshold uint64) (*Share, error) {
+	ids := make([]uint64, len(operators))
+	copy(ids, operators)
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	var found *Share
+	for _, share := range s.shares[hex.EncodeToString(validatorPubKey)] {
+		if share.Threshold != threshold || !equalIDs(share.Operators, ids) {
+			continue
+		}
+		if found == nil || share.CreatedAt.After(found.CreatedAt) {
+			found = share
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("no stored share of validator %x for operators %v and threshold %d", validatorPubKey, ids, threshold)
+	}
+	return found, nil
+}
+
+// List returns all stored shares
+func (s *Store) List() []*Share {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	shares := make([]*Share, 0)
+	for _, byReqID := range s.shares {
+		for _, share := range byReqID {
+			shares = append(shares, share)
+		}
+	}
+	return shares
+}
+
+func (s *Store) index(share *Share) {
+	pk := hex.EncodeToString(share.ValidatorPubKey)
+	if _, ok := s.shares[pk]; !ok {
+		s.shares[pk] = make(map[[24]byte]*Share)
+	}
+	s.shares[pk][share.RequestID] = share
+}
+
+func (s *Store) path(validatorPubKey []byte, reqID [24]byte) string {
+	return filepath.Join(s.dir, fmt.Sprintf("%s%x_%x.json", shareFilePrefix, validatorPubKey, reqID[:]))
+}
+
+func (s *Store) load(path string) (*Share, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	f := &shareFile{}
+	if err := json.Unmarshal(data, f); err != nil {
+		return nil, err
+	}
+	crypto, ok := f.Keystore["crypto"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("keystore crypto section is missing")
+	}
+	secret, err := keystorev4.New().Decrypt(crypto, s.password)
+	if err != nil {
+		return nil, err
+	}
+	sk := &bls.SecretKey{}
+	if err := sk.Deserialize(secret); err != nil {
+		return nil, err
+	}
+	reqID, err := hex.DecodeString(f.RequestID)
+	if err != nil {
+		return nil, err
+	}
+	if len(reqID) != 24 {
+		return nil, fmt.Errorf("wrong request ID length %d", len(reqID))
+	}
+	validatorPubKey, err := hex.DecodeString(f.ValidatorPubKey)
+	if err != nil {
+		return nil, err
+	}
+	commits := make([][]byte, 0, len(f.Commits))
+	for _, c := range f.Commits {
+		commit, err := hex.DecodeString(c)
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, commit)
+	}
+	share := &Share{
+		ValidatorPubKey:    validatorPubKey,
+		OperatorID:         f.OperatorID,
+		SecretKey:          sk,
+		Commits:            commits,
+		Operators:          f.Operators,
+		Threshold:          f.Threshold,
+		InitiatorPublicKey: []byte(f.InitiatorPublicKey),
+		CreatedAt:          f.CreatedAt,
+	}
+	copy(share.RequestID[:], reqID)
+	return share, nil
+}
+
+func equalIDs(a, b []uint64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
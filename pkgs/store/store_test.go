@@ -0,0 +1,72 @@
+package store
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/herumi/bls-eth-go-binary/bls"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestShare(t *testing.T, validatorPubKey []byte, reqID byte, operators []uint64, threshold uint64) *Share {
+	sk := &bls.SecretKey{}
+	sk.SetByCSPRNG()
+	return &Share{
+		RequestID:          [24]byte{reqID},
+		ValidatorPubKey:    validatorPubKey,
+		OperatorID:         operators[0],
+		SecretKey:          sk,
+		Commits:            [][]byte{validatorPubKey},
+		Operators:          operators,
+		Threshold:          threshold,
+		InitiatorPublicKey: []byte("initiator"),
+	}
+}
+
+func TestStore(t *testing.T) {
+	dir := t.TempDir()
+	s, err := New(dir, "password")
+	require.NoError(t, err)
+	vpk := []byte{1, 2, 3}
+	first := newTestShare(t, vpk, 1, []uint64{4, 3, 2, 1}, 3)
+	require.NoError(t, s.Save(first))
+	second := newTestShare(t, vpk, 2, []uint64{3, 4, 5, 6, 7}, 4)
+	second.CreatedAt = first.CreatedAt.Add(time.Minute)
+	require.NoError(t, s.Save(second))
+
+	t.Run("test secrets are encrypted at rest", func(t *testing.T) {
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		for _, e := range entries {
+			data, err := os.ReadFile(dir + "/" + e.Name())
+			require.NoError(t, err)
+			require.False(t, strings.Contains(string(data), first.SecretKey.SerializeToHexStr()))
+			require.False(t, strings.Contains(string(data), second.SecretKey.SerializeToHexStr()))
+		}
+	})
+	t.Run("test load stored shares", func(t *testing.T) {
+		s, err := New(dir, "password")
+		require.NoError(t, err)
+		require.Len(t, s.List(), 2)
+		share, ok := s.Get(vpk, first.RequestID)
+		require.True(t, ok)
+		require.True(t, share.SecretKey.IsEqual(first.SecretKey))
+		require.Equal(t, []uint64{1, 2, 3, 4}, share.Operators)
+		require.Equal(t, first.Commits, share.Commits)
+		latest, err := s.Latest(vpk)
+		require.NoError(t, err)
+		require.Equal(t, second.RequestID, latest.RequestID)
+		found, err := s.Find(vpk, []uint64{1, 2, 3, 4}, 3)
+		require.NoError(t, err)
+		require.Equal(t, first.RequestID, found.RequestID)
+		_, err = s.Find(vpk, []uint64{1, 2, 3, 4}, 4)
+		require.ErrorContains(t, err, "no stored share")
+	})
+	t.Run("test wrong password", func(t *testing.T) {
+		_, err := New(dir, "wrong")
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,63 @@
+package threshold
+
+import "fmt"
+
+// MaxOperators is the max number of operators of a ceremony supported by the wire messages
+const MaxOperators = 64
+
+// Policy limits the number of operators of a ceremony
+type Policy struct {
+	MinOperators int
+	MaxOperators int
+}
+
+// DefaultPolicy allows 4 to 13 operators, the cluster sizes supported by the SSV network
+var DefaultPolicy = Policy{MinOperators: 4, MaxOperators: 13}
+
+// NewPolicy creates a policy allowing min to max operators
+func NewPolicy(min, max int) (Policy, error) {
+	p := Policy{MinOperators: min, MaxOperators: max}
+	if min < 1 {
+		return p, fmt.Errorf("min amount of operators should be at least 1, got %d", min)
+	}
+	if max < min {
+		return p, fmt.Errorf("max amount of operators %d is less than min amount %d", max, min)
+	}
+	if max > MaxOperators {
+		return p, fmt.Errorf("max amount of operators %d is over the supported %d", max, MaxOperators)
+	}
+	return p, nil
+}
+
+// ValidateOperators checks the number of operators is allowed by the policy
+func (p Policy) ValidateOperators(n int) error {
+	if n < p.MinOperators {
+		return fmt.Errorf("minimum supported amount of operators is %d", p.MinOperators)
+	}
+	if n > p.MaxOperators {
+		return fmt.Errorf("maximum supported amount of operators is %d", p.MaxOperators)
+	}
+	return nil
+}
+
+// Validate checks the number of operators is allowed by the policy and the threshold is safe for them
+func (p Policy) Validate(n int, t uint64) error {
+	if err := p.ValidateOperators(n); err != nil {
+		return err
+	}
+	if t < Min(n) || t > uint64(n) {
+		return fmt.Errorf("threshold %d for %d operators should be between %d and %d", t, n, Min(n), n)
+	}
+	return nil
+}
+
+// Default is the 3f+1 threshold of n operators, tolerating f faulty operators
+func Default(n int) uint64 {
+	return uint64(n - ((n - 1) / 3))
+}
+
+// Min is the min safe threshold of n operators, a majority of them,
+// so two disjoint groups of operators can't sign with the same key
+func Min(n int) uint64 {
+	return uint64(n/2 + 1)
+}
@@ -0,0 +1,37 @@
+package threshold
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestThreshold(t *testing.T) {
+	for n, want := range map[int][2]uint64{4: {3, 3}, 7: {5, 4}, 10: {7, 6}, 13: {9, 7}, 20: {14, 11}} {
+		require.Equal(t, want[0], Default(n))
+		require.Equal(t, want[1], Min(n))
+	}
+}
+
+func TestPolicy(t *testing.T) {
+	t.Run("default policy", func(t *testing.T) {
+		require.NoError(t, DefaultPolicy.Validate(4, 3))
+		require.NoError(t, DefaultPolicy.Validate(7, 7))
+		require.ErrorContains(t, DefaultPolicy.Validate(3, 2), "minimum supported amount of operators is 4")
+		require.ErrorContains(t, DefaultPolicy.Validate(14, 10), "maximum supported amount of operators is 13")
+		require.ErrorContains(t, DefaultPolicy.Validate(7, 3), "threshold 3 for 7 operators should be between 4 and 7")
+		require.ErrorContains(t, DefaultPolicy.Validate(4, 5), "threshold 5 for 4 operators should be between 3 and 4")
+	})
+	t.Run("custom policy", func(t *testing.T) {
+		p, err := NewPolicy(2, 20)
+		require.NoError(t, err)
+		require.NoError(t, p.Validate(2, 2))
+		require.NoError(t, p.Validate(20, 11))
+		_, err = NewPolicy(0, 4)
+		require.Error(t, err)
+		_, err = NewPolicy(7, 4)
+		require.Error(t, err)
+		_, err = NewPolicy(4, MaxOperators+1)
+		require.Error(t, err)
+	})
+}
@@ -24,6 +24,24 @@ type Config struct {
 	T      int
 	Board  dkg.Board
 
+	// OldNodes are the current share holders when resharing, nil for a new DKG
+	OldNodes []dkg.Node
+	// OldT is the threshold used by the old nodes when resharing
+	OldT int
+	// Share is the current share of an old node when resharing
+	Share *dkg.DistKeyShare
+	// PublicCoeffs are the old public polynomial commitments required by new nodes when resharing
+	PublicCoeffs []kyber.Point
+
+	// Phaser moves the protocol between phases, EventPhaser by default
+	Phaser PhaserType
+	// PhaseTimeout is the max duration of a phase, DefaultPhaseTimeout if not set
+	PhaseTimeout time.Duration
+	// PhaseF is called when the protocol moves to the next phase, optional
+	PhaseF func(dkg.Phase)
+	// Stop ends the remaining phases right away when closed, so the protocol finishes without waiting, optional
+	Stop <-chan struct{}
+
 	Logger *zap.Logger
 }
 
@@ -54,12 +72,45 @@ func NewDKGProtocol(config *Config) (*dkg.Protocol, error) {
 		Auth:      drand_bls.NewSchemeOnG2(config.Suite),
 		Log:       dkgLogger,
 	}
+	if config.OldNodes != nil {
+		// resharing: old nodes deal their current shares to the new nodes
+		dkgConfig.OldNodes = config.OldNodes
+		dkgConfig.OldThreshold = config.OldT
+		dkgConfig.Share = config.Share
+		dkgConfig.PublicCoeffs = config.PublicCoeffs
+	}
 
-	phaser := dkg.NewTimePhaser(time.Second * 5)
+	timeout := config.PhaseTimeout
+	if timeout == 0 {
+		timeout = DefaultPhaseTimeout
+	}
+	phaseF := config.PhaseF
+	if phaseF == nil {
+		phaseF = func(dkg.Phase) {}
+	}
+	board := config.Board
+	var phaser dkg.Phaser
+	var startPhaser func()
+	switch config.Phaser {
+	case EventPhaser:
+		eventPhaser := newEventPhaser(config.Board, len(dkgConfig.OldNodes), timeout, phaseF, config.Stop)
+		phaser, board, startPhaser = eventPhaser, eventPhaser, eventPhaser.Start
+	case TimePhaser:
+		timePhaser := dkg.NewTimePhaserFunc(func(phase dkg.Phase) {
+			phaseF(phase)
+			select {
+			case <-time.After(timeout):
+			case <-config.Stop:
+			}
+		})
+		phaser, startPhaser = timePhaser, timePhaser.Start
+	default:
+		return nil, fmt.Errorf("unknown phaser %d", config.Phaser)
+	}
 
 	ret, err := dkg.NewProtocol(
 		dkgConfig,
-		config.Board,
+		board,
 		phaser,
 		false,
 	)
@@ -67,7 +118,7 @@ func NewDKGProtocol(config *Config) (*dkg.Protocol, error) {
 		return nil, err
 	}
 
-	go phaser.Start()
+	go startPhaser()
 
 	return ret, nil
 }
@@ -0,0 +1,41 @@
+package wire
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultInitTTL is the time operators accept an init or sign message for after it's created
+	DefaultInitTTL = time.Minute
+	// MaxInitTTL is the longest time an operator accepts an init or sign message for, a message expiring later is rejected
+	MaxInitTTL = 5 * time.Minute
+	// MaxClockSkew is the tolerated difference between the clocks of the initiator and an operator
+	MaxClockSkew = 30 * time.Second
+)
+
+// ErrInitExpired is returned for init and sign messages past their expiry
+var ErrInitExpired = errors.New("message expired")
+
+// InitTime returns the timestamp and expiry of an init or sign message created now
+func InitTime(now time.Time, ttl time.Duration) (timestamp, expiry uint64) {
+	return uint64(now.Unix()), uint64(now.Add(ttl).Unix())
+}
+
+// CheckInitTime checks an init or sign message with the timestamp and expiry is valid now
+func CheckInitTime(timestamp, expiry uint64, now time.Time) error {
+	if expiry <= timestamp {
+		return fmt.Errorf("message expiry %d isn't after its timestamp %d", expiry, timestamp)
+	}
+	if time.Duration(expiry-timestamp)*time.Second > MaxInitTTL {
+		return fmt.Errorf("message is valid for %s, longer than %s", time.Duration(expiry-timestamp)*time.Second, MaxInitTTL)
+	}
+	if time.Unix(int64(timestamp), 0).After(now.Add(MaxClockSkew)) {
+		return fmt.Errorf("message is created in the future at %d", timestamp)
+	}
+	if now.Add(-MaxClockSkew).After(time.Unix(int64(expiry), 0)) {
+		return fmt.Errorf("%w at %d", ErrInitExpired, expiry)
+	}
+	return nil
+}
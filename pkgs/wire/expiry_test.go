@@ -0,0 +1,24 @@
+package wire
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckInitTime(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	ts, exp := InitTime(now, DefaultInitTTL)
+	require.NoError(t, CheckInitTime(ts, exp, now))
+	// clocks of the initiator and the operator may differ a bit
+	require.NoError(t, CheckInitTime(ts, exp, now.Add(-MaxClockSkew)))
+	require.NoError(t, CheckInitTime(ts, exp, now.Add(DefaultInitTTL+MaxClockSkew)))
+
+	require.ErrorIs(t, CheckInitTime(ts, exp, now.Add(DefaultInitTTL+MaxClockSkew+time.Second)), ErrInitExpired)
+	require.ErrorContains(t, CheckInitTime(ts, exp, now.Add(-MaxClockSkew-time.Second)), "created in the future")
+	require.ErrorContains(t, CheckInitTime(ts, ts, now), "isn't after its timestamp")
+	require.ErrorContains(t, CheckInitTime(ts, 0, now), "isn't after its timestamp")
+	ts, exp = InitTime(now, MaxInitTTL+time.Second)
+	require.ErrorContains(t, CheckInitTime(ts, exp, now), "longer than 5m0s")
+}
@@ -0,0 +1,72 @@
+package wire
+
+import "fmt"
+
+// ProtocolVersion is the latest version of the messages exchanged between the initiator and operators.
+// Version 2 adds the timestamp and expiry to init, reshare and sign requests, the offline operators opt-in to init
+// messages, and the operators and threshold of the shares to sign requests.
+const ProtocolVersion uint64 = 2
+
+// SupportedProtocolVersions are the protocol versions an operator takes part in ceremonies with.
+// Version 1 isn't supported, its init, reshare and sign messages aren't decoded anymore.
+var SupportedProtocolVersions = []uint64{ProtocolVersion}
+
+// Identity describes an operator, served at its identity route
+type Identity struct {
+	// PubKey is the RSA public key of the operator, a base64 encoded PEM
+	PubKey           string   `json:"pubKey"`
+	Version          string   `json:"version"`
+	ProtocolVersions []uint64 `json:"protocolVersions"`
+	Networks         []string `json:"networks"`
+}
+
+// ErrUnsupportedVersion is returned for messages of a protocol version that isn't supported
+type ErrUnsupportedVersion struct {
+	Version   uint64
+	Supported []uint64
+}
+
+func (e *ErrUnsupportedVersion) Error() string {
+	return fmt.Sprintf("unsupported protocol version %d, supported versions: %v", e.Version, e.Supported)
+}
+
+// CheckVersion returns ErrUnsupportedVersion if the version isn't one of SupportedProtocolVersions
+func CheckVersion(version uint64) error {
+	if !containsVersion(SupportedProtocolVersions, version) {
+		return &ErrUnsupportedVersion{Version: version, Supported: SupportedProtocolVersions}
+	}
+	return nil
+}
+
+// NegotiateVersion returns the highest of SupportedProtocolVersions supported by all participants
+func NegotiateVersion(participants ...[]uint64) (uint64, error) {
+	var best uint64
+	for _, v := range SupportedProtocolVersions {
+		if v <= best {
+			continue
+		}
+		common := true
+		for _, versions := range participants {
+			if !containsVersion(versions, v) {
+				common = false
+				break
+			}
+		}
+		if common {
+			best = v
+		}
+	}
+	if best == 0 {
+		return 0, fmt.Errorf("no protocol version is supported by all participants, supported versions: %v", SupportedProtocolVersions)
+	}
+	return best, nil
+}
+
+func containsVersion(versions []uint64, version uint64) bool {
+	for _, v := range versions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,27 @@
+package wire
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiateVersion(t *testing.T) {
+	supported := SupportedProtocolVersions
+	t.Cleanup(func() { SupportedProtocolVersions = supported })
+	SupportedProtocolVersions = []uint64{1, 2, 3}
+
+	v, err := NegotiateVersion([]uint64{1, 2, 3}, []uint64{2, 3, 4})
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), v)
+	v, err = NegotiateVersion([]uint64{1, 2}, []uint64{1, 2, 3})
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), v)
+	_, err = NegotiateVersion([]uint64{1}, []uint64{2})
+	require.ErrorContains(t, err, "no protocol version is supported by all participants")
+
+	require.NoError(t, CheckVersion(2))
+	var versionErr *ErrUnsupportedVersion
+	require.ErrorAs(t, CheckVersion(4), &versionErr)
+	require.Equal(t, uint64(4), versionErr.Version)
+}
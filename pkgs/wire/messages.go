@@ -2,7 +2,7 @@ package wire
 
 type MultipleSignedTransports struct {
 	Identifier [24]byte           `ssz-size:"24"` // this is kinda wasteful, maybe take it out of the msgs?
-	Messages   []*SignedTransport `ssz-max:"13"`  // max num of operators
+	Messages   []*SignedTransport `ssz-max:"64"`  // max num of operators, threshold.MaxOperators
 	Signature  []byte             `ssz-max:"2048"`
 }
 
@@ -23,6 +23,11 @@ const (
 	KyberJustificationBundleMessageType
 	BlsSignRequestType
 	ErrorMessageType
+	ReshareExchangeMessageType
+	ReshareReadyMessageType
+	BlsSignResponseType
+	// CancelMessageType is sent by the initiator to drop an instance of a ceremony it won't finish
+	CancelMessageType
 )
 
 func (t TransportType) String() string {
@@ -45,12 +50,24 @@ func (t TransportType) String() string {
 		return "BlsSignRequestType"
 	case ErrorMessageType:
 		return "ErrorMessageType"
+	case InitReshareMessageType:
+		return "InitReshareMessageType"
+	case ReshareExchangeMessageType:
+		return "ReshareExchangeMessageType"
+	case ReshareReadyMessageType:
+		return "ReshareReadyMessageType"
+	case BlsSignResponseType:
+		return "BlsSignResponseType"
+	case CancelMessageType:
+		return "CancelMessageType"
 	default:
 		return "no type impl"
 	}
 }
 
 type Transport struct {
+	// Version of the wire protocol, the first field keeps its offset stable across versions
+	Version    uint64
 	Type       TransportType
 	Identifier [24]byte `ssz-size:"24"`
 	Data       []byte   `ssz-max:"8388608"` // 2^23
@@ -74,7 +91,7 @@ type Operator struct {
 
 type Init struct {
 	// Operators involved in the DKG
-	Operators []*Operator `ssz-max:"13"`
+	Operators []*Operator `ssz-max:"64"`
 	// T is the threshold for signing
 	T uint64
 	// WithdrawalCredentials for deposit data
@@ -87,6 +104,39 @@ type Init struct {
 	Nonce uint64
 	// Initiator public key
 	InitiatorPublicKey []byte `ssz-max:"2048"`
+	// Amount of the deposit in Gwei
+	Amount uint64
+	// WithdrawalPrefix is the type of the withdrawal credentials, 0x01 or 0x02 for compounding
+	WithdrawalPrefix uint8
+	// Timestamp unix time in seconds the message was created at
+	Timestamp uint64
+	// Expiry unix time in seconds after which operators reject the message
+	Expiry uint64
+	// TolerateOffline allows operators to start the DKG without the operators which didn't exchange
+	TolerateOffline bool
+}
+
+type Reshare struct {
+	// ValidatorPubKey public key of the validator which shares are reshared
+	ValidatorPubKey []byte `ssz-size:"48"`
+	// OldOperators holding the current shares
+	OldOperators []*Operator `ssz-max:"64"`
+	// NewOperators receiving the new shares
+	NewOperators []*Operator `ssz-max:"64"`
+	// OldT is the threshold used by the old operators
+	OldT uint64
+	// NewT is the threshold for signing with the new shares
+	NewT uint64
+	// Owner address
+	Owner [20]byte `ssz-size:"20"`
+	// Owner nonce
+	Nonce uint64
+	// Initiator public key
+	InitiatorPublicKey []byte `ssz-max:"2048"`
+	// Timestamp unix time in seconds the message was created at
+	Timestamp uint64
+	// Expiry unix time in seconds after which operators reject the message
+	Expiry uint64
 }
 
 // Exchange contains the session auth/ encryption key for each node
@@ -94,9 +144,71 @@ type Exchange struct {
 	PK []byte `ssz-max:"2048"`
 }
 
+// ReshareExchange contains the session auth/ encryption key for each node
+// and the public polynomial commitments of the old operators
+type ReshareExchange struct {
+	PK      []byte `ssz-max:"2048"`
+	Commits []byte `ssz-max:"4096"` // concatenated compressed G1 points
+}
+
 type Output struct {
 	EncryptedShare              []byte `ssz-max:"4096"`
 	SharePK                     []byte `ssz-max:"4096"`
 	ValidatorPK                 []byte `ssz-size:"48"`
 	DepositDataPartialSignature []byte `ssz-size:"96"`
 }
+
+// KeySign requests a partial signature by the operators' shares of a validator key
+type KeySign struct {
+	ValidatorPK []byte `ssz-size:"48"`
+	SigningRoot []byte `ssz-size:"32"`
+	// Operators IDs of the operators holding the shares, a validator key may be shared by several sets after resharing
+	Operators []uint64 `ssz-max:"64"`
+	// T is the threshold of the shares
+	T uint64
+	// Timestamp unix time in seconds the message was created at
+	Timestamp uint64
+	// Expiry unix time in seconds after which operators reject the message
+	Expiry uint64
+}
+
+// PartialSignature is a signature of a KeySign request by the operator's share
+type PartialSignature struct {
+	ValidatorPK []byte `ssz-size:"48"`
+	SigningRoot []byte `ssz-size:"32"`
+	SharePK     []byte `ssz-size:"48"`
+	Signature   []byte `ssz-size:"96"`
+}
+
+// BatchSignedTransports contains init messages of several instances sent in a single request,
+// every message is signed by the initiator
+type BatchSignedTransports struct {
+	Messages []*SignedTransport `ssz-max:"128"`
+}
+
+// BatchMultipleSignedTransports contains dkg messages of several instances sent in a single request
+type BatchMultipleSignedTransports struct {
+	Messages []*MultipleSignedTransports `ssz-max:"128"`
+}
+
+// BatchResponse contains responses of an operator to a batched request, in the order of the request messages.
+// Every response is either a signed transport or an error of the instance.
+type BatchResponse struct {
+	Responses [][]byte `ssz-max:"128,8388608"`
+}
+
+// Proof binds a key share of a validator to the operator which created it at a ceremony
+type Proof struct {
+	OperatorID      uint64
+	SharePubKey     []byte   `ssz-size:"48"`
+	ValidatorPubKey []byte   `ssz-size:"48"`
+	Owner           [20]byte `ssz-size:"20"`
+	Nonce           uint64
+	RequestID       [24]byte `ssz-size:"24"`
+}
+
+// SignedProof is a proof signed by the RSA key of the operator
+type SignedProof struct {
+	Proof     *Proof
+	Signature []byte `ssz-max:"2048"`
+}
@@ -1,5 +1,5 @@
 // Code generated by fastssz. DO NOT EDIT.
-// Hash: 2ef65878a6e68cef5674c5a4b2aa735fcfc78c921b6432f6b1d9678f273e2c40
+// Hash: b4d435286434f989289f0e5f8687a16335475b0b8385e34e67b28d460445eb7b
 // Version: 0.1.3
 package wire
 
@@ -32,8 +32,8 @@ func (m *MultipleSignedTransports) MarshalSSZTo(buf []byte) (dst []byte, err err
 	offset += len(m.Signature)
 
 	// Field (1) 'Messages'
-	if size := len(m.Messages); size > 13 {
-		err = ssz.ErrListTooBigFn("MultipleSignedTransports.Messages", size, 13)
+	if size := len(m.Messages); size > 64 {
+		err = ssz.ErrListTooBigFn("MultipleSignedTransports.Messages", size, 64)
 		return
 	}
 	{
@@ -90,7 +90,7 @@ func (m *MultipleSignedTransports) UnmarshalSSZ(buf []byte) error {
 	// Field (1) 'Messages'
 	{
 		buf = tail[o1:o2]
-		num, err := ssz.DecodeDynamicLength(buf, 13)
+		num, err := ssz.DecodeDynamicLength(buf, 64)
 		if err != nil {
 			return err
 		}
@@ -155,7 +155,7 @@ func (m *MultipleSignedTransports) HashTreeRootWith(hh ssz.HashWalker) (err erro
 	{
 		subIndx := hh.Index()
 		num := uint64(len(m.Messages))
-		if num > 13 {
+		if num > 64 {
 			err = ssz.ErrIncorrectListSize
 			return
 		}
@@ -164,7 +164,7 @@ func (m *MultipleSignedTransports) HashTreeRootWith(hh ssz.HashWalker) (err erro
 				return
 			}
 		}
-		hh.MerkleizeWithMixin(subIndx, num, 13)
+		hh.MerkleizeWithMixin(subIndx, num, 64)
 	}
 
 	// Field (2) 'Signature'
@@ -294,19 +294,22 @@ func (t *Transport) MarshalSSZ() ([]byte, error) {
 // MarshalSSZTo ssz marshals the Transport object to a target array
 func (t *Transport) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 	dst = buf
-	offset := int(36)
+	offset := int(44)
 
-	// Field (0) 'Type'
+	// Field (0) 'Version'
+	dst = ssz.MarshalUint64(dst, t.Version)
+
+	// Field (1) 'Type'
 	dst = ssz.MarshalUint64(dst, uint64(t.Type))
 
-	// Field (1) 'Identifier'
+	// Field (2) 'Identifier'
 	dst = append(dst, t.Identifier[:]...)
 
-	// Offset (2) 'Data'
+	// Offset (3) 'Data'
 	dst = ssz.WriteOffset(dst, offset)
 	offset += len(t.Data)
 
-	// Field (2) 'Data'
+	// Field (3) 'Data'
 	if size := len(t.Data); size > 8388608 {
 		err = ssz.ErrBytesLengthFn("Transport.Data", size, 8388608)
 		return
@@ -320,31 +323,34 @@ func (t *Transport) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 func (t *Transport) UnmarshalSSZ(buf []byte) error {
 	var err error
 	size := uint64(len(buf))
-	if size < 36 {
+	if size < 44 {
 		return ssz.ErrSize
 	}
 
 	tail := buf
-	var o2 uint64
+	var o3 uint64
 
-	// Field (0) 'Type'
-	t.Type = TransportType(ssz.UnmarshallUint64(buf[0:8]))
+	// Field (0) 'Version'
+	t.Version = ssz.UnmarshallUint64(buf[0:8])
 
-	// Field (1) 'Identifier'
-	copy(t.Identifier[:], buf[8:32])
+	// Field (1) 'Type'
+	t.Type = TransportType(ssz.UnmarshallUint64(buf[8:16]))
 
-	// Offset (2) 'Data'
-	if o2 = ssz.ReadOffset(buf[32:36]); o2 > size {
+	// Field (2) 'Identifier'
+	copy(t.Identifier[:], buf[16:40])
+
+	// Offset (3) 'Data'
+	if o3 = ssz.ReadOffset(buf[40:44]); o3 > size {
 		return ssz.ErrOffset
 	}
 
-	if o2 < 36 {
+	if o3 < 44 {
 		return ssz.ErrInvalidVariableOffset
 	}
 
-	// Field (2) 'Data'
+	// Field (3) 'Data'
 	{
-		buf = tail[o2:]
+		buf = tail[o3:]
 		if len(buf) > 8388608 {
 			return ssz.ErrBytesLength
 		}
@@ -358,9 +364,9 @@ func (t *Transport) UnmarshalSSZ(buf []byte) error {
 
 // SizeSSZ returns the ssz encoded size in bytes for the Transport object
 func (t *Transport) SizeSSZ() (size int) {
-	size = 36
+	size = 44
 
-	// Field (2) 'Data'
+	// Field (3) 'Data'
 	size += len(t.Data)
 
 	return
@@ -375,13 +381,16 @@ func (t *Transport) HashTreeRoot() ([32]byte, error) {
 func (t *Transport) HashTreeRootWith(hh ssz.HashWalker) (err error) {
 	indx := hh.Index()
 
-	// Field (0) 'Type'
+	// Field (0) 'Version'
+	hh.PutUint64(t.Version)
+
+	// Field (1) 'Type'
 	hh.PutUint64(uint64(t.Type))
 
-	// Field (1) 'Identifier'
+	// Field (2) 'Identifier'
 	hh.PutBytes(t.Identifier[:])
 
-	// Field (2) 'Data'
+	// Field (3) 'Data'
 	{
 		elemIndx := hh.Index()
 		byteLen := uint64(len(t.Data))
@@ -770,7 +779,7 @@ func (i *Init) MarshalSSZ() ([]byte, error) {
 // MarshalSSZTo ssz marshals the Init object to a target array
 func (i *Init) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 	dst = buf
-	offset := int(52)
+	offset := int(78)
 
 	// Offset (0) 'Operators'
 	dst = ssz.WriteOffset(dst, offset)
@@ -799,9 +808,24 @@ func (i *Init) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 	dst = ssz.WriteOffset(dst, offset)
 	offset += len(i.InitiatorPublicKey)
 
+	// Field (7) 'Amount'
+	dst = ssz.MarshalUint64(dst, i.Amount)
+
+	// Field (8) 'WithdrawalPrefix'
+	dst = ssz.MarshalUint8(dst, i.WithdrawalPrefix)
+
+	// Field (9) 'Timestamp'
+	dst = ssz.MarshalUint64(dst, i.Timestamp)
+
+	// Field (10) 'Expiry'
+	dst = ssz.MarshalUint64(dst, i.Expiry)
+
+	// Field (11) 'TolerateOffline'
+	dst = ssz.MarshalBool(dst, i.TolerateOffline)
+
 	// Field (0) 'Operators'
-	if size := len(i.Operators); size > 13 {
-		err = ssz.ErrListTooBigFn("Init.Operators", size, 13)
+	if size := len(i.Operators); size > 64 {
+		err = ssz.ErrListTooBigFn("Init.Operators", size, 64)
 		return
 	}
 	{
@@ -838,7 +862,7 @@ func (i *Init) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 func (i *Init) UnmarshalSSZ(buf []byte) error {
 	var err error
 	size := uint64(len(buf))
-	if size < 52 {
+	if size < 78 {
 		return ssz.ErrSize
 	}
 
@@ -850,7 +874,7 @@ func (i *Init) UnmarshalSSZ(buf []byte) error {
 		return ssz.ErrOffset
 	}
 
-	if o0 < 52 {
+	if o0 < 78 {
 		return ssz.ErrInvalidVariableOffset
 	}
 
@@ -876,10 +900,25 @@ func (i *Init) UnmarshalSSZ(buf []byte) error {
 		return ssz.ErrOffset
 	}
 
+	// Field (7) 'Amount'
+	i.Amount = ssz.UnmarshallUint64(buf[52:60])
+
+	// Field (8) 'WithdrawalPrefix'
+	i.WithdrawalPrefix = ssz.UnmarshallUint8(buf[60:61])
+
+	// Field (9) 'Timestamp'
+	i.Timestamp = ssz.UnmarshallUint64(buf[61:69])
+
+	// Field (10) 'Expiry'
+	i.Expiry = ssz.UnmarshallUint64(buf[69:77])
+
+	// Field (11) 'TolerateOffline'
+	i.TolerateOffline = ssz.UnmarshalBool(buf[77:78])
+
 	// Field (0) 'Operators'
 	{
 		buf = tail[o0:o2]
-		num, err := ssz.DecodeDynamicLength(buf, 13)
+		num, err := ssz.DecodeDynamicLength(buf, 64)
 		if err != nil {
 			return err
 		}
@@ -926,7 +965,7 @@ func (i *Init) UnmarshalSSZ(buf []byte) error {
 
 // SizeSSZ returns the ssz encoded size in bytes for the Init object
 func (i *Init) SizeSSZ() (size int) {
-	size = 52
+	size = 78
 
 	// Field (0) 'Operators'
 	for ii := 0; ii < len(i.Operators); ii++ {
@@ -956,7 +995,7 @@ func (i *Init) HashTreeRootWith(hh ssz.HashWalker) (err error) {
 	{
 		subIndx := hh.Index()
 		num := uint64(len(i.Operators))
-		if num > 13 {
+		if num > 64 {
 			err = ssz.ErrIncorrectListSize
 			return
 		}
@@ -965,7 +1004,7 @@ func (i *Init) HashTreeRootWith(hh ssz.HashWalker) (err error) {
 				return
 			}
 		}
-		hh.MerkleizeWithMixin(subIndx, num, 13)
+		hh.MerkleizeWithMixin(subIndx, num, 64)
 	}
 
 	// Field (1) 'T'
@@ -1004,6 +1043,21 @@ func (i *Init) HashTreeRootWith(hh ssz.HashWalker) (err error) {
 		hh.MerkleizeWithMixin(elemIndx, byteLen, (2048+31)/32)
 	}
 
+	// Field (7) 'Amount'
+	hh.PutUint64(i.Amount)
+
+	// Field (8) 'WithdrawalPrefix'
+	hh.PutUint8(i.WithdrawalPrefix)
+
+	// Field (9) 'Timestamp'
+	hh.PutUint64(i.Timestamp)
+
+	// Field (10) 'Expiry'
+	hh.PutUint64(i.Expiry)
+
+	// Field (11) 'TolerateOffline'
+	hh.PutBool(i.TolerateOffline)
+
 	hh.Merkleize(indx)
 	return
 }
@@ -1013,6 +1067,326 @@ func (i *Init) GetTree() (*ssz.Node, error) {
 	return ssz.ProofTree(i)
 }
 
+// MarshalSSZ ssz marshals the Reshare object
+func (r *Reshare) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(r)
+}
+
+// MarshalSSZTo ssz marshals the Reshare object to a target array
+func (r *Reshare) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(120)
+
+	// Field (0) 'ValidatorPubKey'
+	if size := len(r.ValidatorPubKey); size != 48 {
+		err = ssz.ErrBytesLengthFn("Reshare.ValidatorPubKey", size, 48)
+		return
+	}
+	dst = append(dst, r.ValidatorPubKey...)
+
+	// Offset (1) 'OldOperators'
+	dst = ssz.WriteOffset(dst, offset)
+	for ii := 0; ii < len(r.OldOperators); ii++ {
+		offset += 4
+		offset += r.OldOperators[ii].SizeSSZ()
+	}
+
+	// Offset (2) 'NewOperators'
+	dst = ssz.WriteOffset(dst, offset)
+	for ii := 0; ii < len(r.NewOperators); ii++ {
+		offset += 4
+		offset += r.NewOperators[ii].SizeSSZ()
+	}
+
+	// Field (3) 'OldT'
+	dst = ssz.MarshalUint64(dst, r.OldT)
+
+	// Field (4) 'NewT'
+	dst = ssz.MarshalUint64(dst, r.NewT)
+
+	// Field (5) 'Owner'
+	dst = append(dst, r.Owner[:]...)
+
+	// Field (6) 'Nonce'
+	dst = ssz.MarshalUint64(dst, r.Nonce)
+
+	// Offset (7) 'InitiatorPublicKey'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(r.InitiatorPublicKey)
+
+	// Field (8) 'Timestamp'
+	dst = ssz.MarshalUint64(dst, r.Timestamp)
+
+	// Field (9) 'Expiry'
+	dst = ssz.MarshalUint64(dst, r.Expiry)
+
+	// Field (1) 'OldOperators'
+	if size := len(r.OldOperators); size > 64 {
+		err = ssz.ErrListTooBigFn("Reshare.OldOperators", size, 64)
+		return
+	}
+	{
+		offset = 4 * len(r.OldOperators)
+		for ii := 0; ii < len(r.OldOperators); ii++ {
+			dst = ssz.WriteOffset(dst, offset)
+			offset += r.OldOperators[ii].SizeSSZ()
+		}
+	}
+	for ii := 0; ii < len(r.OldOperators); ii++ {
+		if dst, err = r.OldOperators[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	// Field (2) 'NewOperators'
+	if size := len(r.NewOperators); size > 64 {
+		err = ssz.ErrListTooBigFn("Reshare.NewOperators", size, 64)
+		return
+	}
+	{
+		offset = 4 * len(r.NewOperators)
+		for ii := 0; ii < len(r.NewOperators); ii++ {
+			dst = ssz.WriteOffset(dst, offset)
+			offset += r.NewOperators[ii].SizeSSZ()
+		}
+	}
+	for ii := 0; ii < len(r.NewOperators); ii++ {
+		if dst, err = r.NewOperators[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	// Field (7) 'InitiatorPublicKey'
+	if size := len(r.InitiatorPublicKey); size > 2048 {
+		err = ssz.ErrBytesLengthFn("Reshare.InitiatorPublicKey", size, 2048)
+		return
+	}
+	dst = append(dst, r.InitiatorPublicKey...)
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the Reshare object
+func (r *Reshare) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 120 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o1, o2, o7 uint64
+
+	// Field (0) 'ValidatorPubKey'
+	if cap(r.ValidatorPubKey) == 0 {
+		r.ValidatorPubKey = make([]byte, 0, len(buf[0:48]))
+	}
+	r.ValidatorPubKey = append(r.ValidatorPubKey, buf[0:48]...)
+
+	// Offset (1) 'OldOperators'
+	if o1 = ssz.ReadOffset(buf[48:52]); o1 > size {
+		return ssz.ErrOffset
+	}
+
+	if o1 < 120 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Offset (2) 'NewOperators'
+	if o2 = ssz.ReadOffset(buf[52:56]); o2 > size || o1 > o2 {
+		return ssz.ErrOffset
+	}
+
+	// Field (3) 'OldT'
+	r.OldT = ssz.UnmarshallUint64(buf[56:64])
+
+	// Field (4) 'NewT'
+	r.NewT = ssz.UnmarshallUint64(buf[64:72])
+
+	// Field (5) 'Owner'
+	copy(r.Owner[:], buf[72:92])
+
+	// Field (6) 'Nonce'
+	r.Nonce = ssz.UnmarshallUint64(buf[92:100])
+
+	// Offset (7) 'InitiatorPublicKey'
+	if o7 = ssz.ReadOffset(buf[100:104]); o7 > size || o2 > o7 {
+		return ssz.ErrOffset
+	}
+
+	// Field (8) 'Timestamp'
+	r.Timestamp = ssz.UnmarshallUint64(buf[104:112])
+
+	// Field (9) 'Expiry'
+	r.Expiry = ssz.UnmarshallUint64(buf[112:120])
+
+	// Field (1) 'OldOperators'
+	{
+		buf = tail[o1:o2]
+		num, err := ssz.DecodeDynamicLength(buf, 64)
+		if err != nil {
+			return err
+		}
+		r.OldOperators = make([]*Operator, num)
+		err = ssz.UnmarshalDynamic(buf, num, func(indx int, buf []byte) (err error) {
+			if r.OldOperators[indx] == nil {
+				r.OldOperators[indx] = new(Operator)
+			}
+			if err = r.OldOperators[indx].UnmarshalSSZ(buf); err != nil {
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Field (2) 'NewOperators'
+	{
+		buf = tail[o2:o7]
+		num, err := ssz.DecodeDynamicLength(buf, 64)
+		if err != nil {
+			return err
+		}
+		r.NewOperators = make([]*Operator, num)
+		err = ssz.UnmarshalDynamic(buf, num, func(indx int, buf []byte) (err error) {
+			if r.NewOperators[indx] == nil {
+				r.NewOperators[indx] = new(Operator)
+			}
+			if err = r.NewOperators[indx].UnmarshalSSZ(buf); err != nil {
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Field (7) 'InitiatorPublicKey'
+	{
+		buf = tail[o7:]
+		if len(buf) > 2048 {
+			return ssz.ErrBytesLength
+		}
+		if cap(r.InitiatorPublicKey) == 0 {
+			r.InitiatorPublicKey = make([]byte, 0, len(buf))
+		}
+		r.InitiatorPublicKey = append(r.InitiatorPublicKey, buf...)
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the Reshare object
+func (r *Reshare) SizeSSZ() (size int) {
+	size = 120
+
+	// Field (1) 'OldOperators'
+	for ii := 0; ii < len(r.OldOperators); ii++ {
+		size += 4
+		size += r.OldOperators[ii].SizeSSZ()
+	}
+
+	// Field (2) 'NewOperators'
+	for ii := 0; ii < len(r.NewOperators); ii++ {
+		size += 4
+		size += r.NewOperators[ii].SizeSSZ()
+	}
+
+	// Field (7) 'InitiatorPublicKey'
+	size += len(r.InitiatorPublicKey)
+
+	return
+}
+
+// HashTreeRoot ssz hashes the Reshare object
+func (r *Reshare) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(r)
+}
+
+// HashTreeRootWith ssz hashes the Reshare object with a hasher
+func (r *Reshare) HashTreeRootWith(hh ssz.HashWalker) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'ValidatorPubKey'
+	if size := len(r.ValidatorPubKey); size != 48 {
+		err = ssz.ErrBytesLengthFn("Reshare.ValidatorPubKey", size, 48)
+		return
+	}
+	hh.PutBytes(r.ValidatorPubKey)
+
+	// Field (1) 'OldOperators'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(r.OldOperators))
+		if num > 64 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for _, elem := range r.OldOperators {
+			if err = elem.HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		hh.MerkleizeWithMixin(subIndx, num, 64)
+	}
+
+	// Field (2) 'NewOperators'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(r.NewOperators))
+		if num > 64 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for _, elem := range r.NewOperators {
+			if err = elem.HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		hh.MerkleizeWithMixin(subIndx, num, 64)
+	}
+
+	// Field (3) 'OldT'
+	hh.PutUint64(r.OldT)
+
+	// Field (4) 'NewT'
+	hh.PutUint64(r.NewT)
+
+	// Field (5) 'Owner'
+	hh.PutBytes(r.Owner[:])
+
+	// Field (6) 'Nonce'
+	hh.PutUint64(r.Nonce)
+
+	// Field (7) 'InitiatorPublicKey'
+	{
+		elemIndx := hh.Index()
+		byteLen := uint64(len(r.InitiatorPublicKey))
+		if byteLen > 2048 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		hh.Append(r.InitiatorPublicKey)
+		hh.MerkleizeWithMixin(elemIndx, byteLen, (2048+31)/32)
+	}
+
+	// Field (8) 'Timestamp'
+	hh.PutUint64(r.Timestamp)
+
+	// Field (9) 'Expiry'
+	hh.PutUint64(r.Expiry)
+
+	hh.Merkleize(indx)
+	return
+}
+
+// GetTree ssz hashes the Reshare object
+func (r *Reshare) GetTree() (*ssz.Node, error) {
+	return ssz.ProofTree(r)
+}
+
 // MarshalSSZ ssz marshals the Exchange object
 func (e *Exchange) MarshalSSZ() ([]byte, error) {
 	return ssz.MarshalSSZ(e)
@@ -1111,96 +1485,237 @@ func (e *Exchange) GetTree() (*ssz.Node, error) {
 	return ssz.ProofTree(e)
 }
 
-// MarshalSSZ ssz marshals the Output object
-func (o *Output) MarshalSSZ() ([]byte, error) {
-	return ssz.MarshalSSZ(o)
+// MarshalSSZ ssz marshals the ReshareExchange object
+func (r *ReshareExchange) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(r)
 }
 
-// MarshalSSZTo ssz marshals the Output object to a target array
-func (o *Output) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+// MarshalSSZTo ssz marshals the ReshareExchange object to a target array
+func (r *ReshareExchange) MarshalSSZTo(buf []byte) (dst []byte, err error) {
 	dst = buf
-	offset := int(152)
+	offset := int(8)
 
-	// Offset (0) 'EncryptedShare'
+	// Offset (0) 'PK'
 	dst = ssz.WriteOffset(dst, offset)
-	offset += len(o.EncryptedShare)
+	offset += len(r.PK)
 
-	// Offset (1) 'SharePK'
+	// Offset (1) 'Commits'
 	dst = ssz.WriteOffset(dst, offset)
-	offset += len(o.SharePK)
-
-	// Field (2) 'ValidatorPK'
-	if size := len(o.ValidatorPK); size != 48 {
-		err = ssz.ErrBytesLengthFn("Output.ValidatorPK", size, 48)
-		return
-	}
-	dst = append(dst, o.ValidatorPK...)
-
-	// Field (3) 'DepositDataPartialSignature'
-	if size := len(o.DepositDataPartialSignature); size != 96 {
-		err = ssz.ErrBytesLengthFn("Output.DepositDataPartialSignature", size, 96)
-		return
-	}
-	dst = append(dst, o.DepositDataPartialSignature...)
+	offset += len(r.Commits)
 
-	// Field (0) 'EncryptedShare'
-	if size := len(o.EncryptedShare); size > 4096 {
-		err = ssz.ErrBytesLengthFn("Output.EncryptedShare", size, 4096)
+	// Field (0) 'PK'
+	if size := len(r.PK); size > 2048 {
+		err = ssz.ErrBytesLengthFn("ReshareExchange.PK", size, 2048)
 		return
 	}
-	dst = append(dst, o.EncryptedShare...)
+	dst = append(dst, r.PK...)
 
-	// Field (1) 'SharePK'
-	if size := len(o.SharePK); size > 4096 {
-		err = ssz.ErrBytesLengthFn("Output.SharePK", size, 4096)
+	// Field (1) 'Commits'
+	if size := len(r.Commits); size > 4096 {
+		err = ssz.ErrBytesLengthFn("ReshareExchange.Commits", size, 4096)
 		return
 	}
-	dst = append(dst, o.SharePK...)
+	dst = append(dst, r.Commits...)
 
 	return
 }
 
-// UnmarshalSSZ ssz unmarshals the Output object
-func (o *Output) UnmarshalSSZ(buf []byte) error {
+// UnmarshalSSZ ssz unmarshals the ReshareExchange object
+func (r *ReshareExchange) UnmarshalSSZ(buf []byte) error {
 	var err error
 	size := uint64(len(buf))
-	if size < 152 {
+	if size < 8 {
 		return ssz.ErrSize
 	}
 
 	tail := buf
 	var o0, o1 uint64
 
-	// Offset (0) 'EncryptedShare'
+	// Offset (0) 'PK'
 	if o0 = ssz.ReadOffset(buf[0:4]); o0 > size {
 		return ssz.ErrOffset
 	}
 
-	if o0 < 152 {
+	if o0 < 8 {
 		return ssz.ErrInvalidVariableOffset
 	}
 
-	// Offset (1) 'SharePK'
+	// Offset (1) 'Commits'
 	if o1 = ssz.ReadOffset(buf[4:8]); o1 > size || o0 > o1 {
 		return ssz.ErrOffset
 	}
 
-	// Field (2) 'ValidatorPK'
-	if cap(o.ValidatorPK) == 0 {
-		o.ValidatorPK = make([]byte, 0, len(buf[8:56]))
-	}
-	o.ValidatorPK = append(o.ValidatorPK, buf[8:56]...)
-
-	// Field (3) 'DepositDataPartialSignature'
-	if cap(o.DepositDataPartialSignature) == 0 {
-		o.DepositDataPartialSignature = make([]byte, 0, len(buf[56:152]))
-	}
-	o.DepositDataPartialSignature = append(o.DepositDataPartialSignature, buf[56:152]...)
-
-	// Field (0) 'EncryptedShare'
+	// Field (0) 'PK'
 	{
 		buf = tail[o0:o1]
-		if len(buf) > 4096 {
+		if len(buf) > 2048 {
+			return ssz.ErrBytesLength
+		}
+		if cap(r.PK) == 0 {
+			r.PK = make([]byte, 0, len(buf))
+		}
+		r.PK = append(r.PK, buf...)
+	}
+
+	// Field (1) 'Commits'
+	{
+		buf = tail[o1:]
+		if len(buf) > 4096 {
+			return ssz.ErrBytesLength
+		}
+		if cap(r.Commits) == 0 {
+			r.Commits = make([]byte, 0, len(buf))
+		}
+		r.Commits = append(r.Commits, buf...)
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the ReshareExchange object
+func (r *ReshareExchange) SizeSSZ() (size int) {
+	size = 8
+
+	// Field (0) 'PK'
+	size += len(r.PK)
+
+	// Field (1) 'Commits'
+	size += len(r.Commits)
+
+	return
+}
+
+// HashTreeRoot ssz hashes the ReshareExchange object
+func (r *ReshareExchange) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(r)
+}
+
+// HashTreeRootWith ssz hashes the ReshareExchange object with a hasher
+func (r *ReshareExchange) HashTreeRootWith(hh ssz.HashWalker) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'PK'
+	{
+		elemIndx := hh.Index()
+		byteLen := uint64(len(r.PK))
+		if byteLen > 2048 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		hh.Append(r.PK)
+		hh.MerkleizeWithMixin(elemIndx, byteLen, (2048+31)/32)
+	}
+
+	// Field (1) 'Commits'
+	{
+		elemIndx := hh.Index()
+		byteLen := uint64(len(r.Commits))
+		if byteLen > 4096 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		hh.Append(r.Commits)
+		hh.MerkleizeWithMixin(elemIndx, byteLen, (4096+31)/32)
+	}
+
+	hh.Merkleize(indx)
+	return
+}
+
+// GetTree ssz hashes the ReshareExchange object
+func (r *ReshareExchange) GetTree() (*ssz.Node, error) {
+	return ssz.ProofTree(r)
+}
+
+// MarshalSSZ ssz marshals the Output object
+func (o *Output) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(o)
+}
+
+// MarshalSSZTo ssz marshals the Output object to a target array
+func (o *Output) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(152)
+
+	// Offset (0) 'EncryptedShare'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(o.EncryptedShare)
+
+	// Offset (1) 'SharePK'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(o.SharePK)
+
+	// Field (2) 'ValidatorPK'
+	if size := len(o.ValidatorPK); size != 48 {
+		err = ssz.ErrBytesLengthFn("Output.ValidatorPK", size, 48)
+		return
+	}
+	dst = append(dst, o.ValidatorPK...)
+
+	// Field (3) 'DepositDataPartialSignature'
+	if size := len(o.DepositDataPartialSignature); size != 96 {
+		err = ssz.ErrBytesLengthFn("Output.DepositDataPartialSignature", size, 96)
+		return
+	}
+	dst = append(dst, o.DepositDataPartialSignature...)
+
+	// Field (0) 'EncryptedShare'
+	if size := len(o.EncryptedShare); size > 4096 {
+		err = ssz.ErrBytesLengthFn("Output.EncryptedShare", size, 4096)
+		return
+	}
+	dst = append(dst, o.EncryptedShare...)
+
+	// Field (1) 'SharePK'
+	if size := len(o.SharePK); size > 4096 {
+		err = ssz.ErrBytesLengthFn("Output.SharePK", size, 4096)
+		return
+	}
+	dst = append(dst, o.SharePK...)
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the Output object
+func (o *Output) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 152 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o0, o1 uint64
+
+	// Offset (0) 'EncryptedShare'
+	if o0 = ssz.ReadOffset(buf[0:4]); o0 > size {
+		return ssz.ErrOffset
+	}
+
+	if o0 < 152 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Offset (1) 'SharePK'
+	if o1 = ssz.ReadOffset(buf[4:8]); o1 > size || o0 > o1 {
+		return ssz.ErrOffset
+	}
+
+	// Field (2) 'ValidatorPK'
+	if cap(o.ValidatorPK) == 0 {
+		o.ValidatorPK = make([]byte, 0, len(buf[8:56]))
+	}
+	o.ValidatorPK = append(o.ValidatorPK, buf[8:56]...)
+
+	// Field (3) 'DepositDataPartialSignature'
+	if cap(o.DepositDataPartialSignature) == 0 {
+		o.DepositDataPartialSignature = make([]byte, 0, len(buf[56:152]))
+	}
+	o.DepositDataPartialSignature = append(o.DepositDataPartialSignature, buf[56:152]...)
+
+	// Field (0) 'EncryptedShare'
+	{
+		buf = tail[o0:o1]
+		if len(buf) > 4096 {
 			return ssz.ErrBytesLength
 		}
 		if cap(o.EncryptedShare) == 0 {
@@ -1291,3 +1806,943 @@ func (o *Output) HashTreeRootWith(hh ssz.HashWalker) (err error) {
 func (o *Output) GetTree() (*ssz.Node, error) {
 	return ssz.ProofTree(o)
 }
+
+// MarshalSSZ ssz marshals the KeySign object
+func (k *KeySign) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(k)
+}
+
+// MarshalSSZTo ssz marshals the KeySign object to a target array
+func (k *KeySign) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(108)
+
+	// Field (0) 'ValidatorPK'
+	if size := len(k.ValidatorPK); size != 48 {
+		err = ssz.ErrBytesLengthFn("KeySign.ValidatorPK", size, 48)
+		return
+	}
+	dst = append(dst, k.ValidatorPK...)
+
+	// Field (1) 'SigningRoot'
+	if size := len(k.SigningRoot); size != 32 {
+		err = ssz.ErrBytesLengthFn("KeySign.SigningRoot", size, 32)
+		return
+	}
+	dst = append(dst, k.SigningRoot...)
+
+	// Offset (2) 'Operators'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(k.Operators) * 8
+
+	// Field (3) 'T'
+	dst = ssz.MarshalUint64(dst, k.T)
+
+	// Field (4) 'Timestamp'
+	dst = ssz.MarshalUint64(dst, k.Timestamp)
+
+	// Field (5) 'Expiry'
+	dst = ssz.MarshalUint64(dst, k.Expiry)
+
+	// Field (2) 'Operators'
+	if size := len(k.Operators); size > 64 {
+		err = ssz.ErrListTooBigFn("KeySign.Operators", size, 64)
+		return
+	}
+	for ii := 0; ii < len(k.Operators); ii++ {
+		dst = ssz.MarshalUint64(dst, k.Operators[ii])
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the KeySign object
+func (k *KeySign) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 108 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o2 uint64
+
+	// Field (0) 'ValidatorPK'
+	if cap(k.ValidatorPK) == 0 {
+		k.ValidatorPK = make([]byte, 0, len(buf[0:48]))
+	}
+	k.ValidatorPK = append(k.ValidatorPK, buf[0:48]...)
+
+	// Field (1) 'SigningRoot'
+	if cap(k.SigningRoot) == 0 {
+		k.SigningRoot = make([]byte, 0, len(buf[48:80]))
+	}
+	k.SigningRoot = append(k.SigningRoot, buf[48:80]...)
+
+	// Offset (2) 'Operators'
+	if o2 = ssz.ReadOffset(buf[80:84]); o2 > size {
+		return ssz.ErrOffset
+	}
+
+	if o2 < 108 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Field (3) 'T'
+	k.T = ssz.UnmarshallUint64(buf[84:92])
+
+	// Field (4) 'Timestamp'
+	k.Timestamp = ssz.UnmarshallUint64(buf[92:100])
+
+	// Field (5) 'Expiry'
+	k.Expiry = ssz.UnmarshallUint64(buf[100:108])
+
+	// Field (2) 'Operators'
+	{
+		buf = tail[o2:]
+		num, err := ssz.DivideInt2(len(buf), 8, 64)
+		if err != nil {
+			return err
+		}
+		k.Operators = ssz.ExtendUint64(k.Operators, num)
+		for ii := 0; ii < num; ii++ {
+			k.Operators[ii] = ssz.UnmarshallUint64(buf[ii*8 : (ii+1)*8])
+		}
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the KeySign object
+func (k *KeySign) SizeSSZ() (size int) {
+	size = 108
+
+	// Field (2) 'Operators'
+	size += len(k.Operators) * 8
+
+	return
+}
+
+// HashTreeRoot ssz hashes the KeySign object
+func (k *KeySign) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(k)
+}
+
+// HashTreeRootWith ssz hashes the KeySign object with a hasher
+func (k *KeySign) HashTreeRootWith(hh ssz.HashWalker) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'ValidatorPK'
+	if size := len(k.ValidatorPK); size != 48 {
+		err = ssz.ErrBytesLengthFn("KeySign.ValidatorPK", size, 48)
+		return
+	}
+	hh.PutBytes(k.ValidatorPK)
+
+	// Field (1) 'SigningRoot'
+	if size := len(k.SigningRoot); size != 32 {
+		err = ssz.ErrBytesLengthFn("KeySign.SigningRoot", size, 32)
+		return
+	}
+	hh.PutBytes(k.SigningRoot)
+
+	// Field (2) 'Operators'
+	{
+		if size := len(k.Operators); size > 64 {
+			err = ssz.ErrListTooBigFn("KeySign.Operators", size, 64)
+			return
+		}
+		subIndx := hh.Index()
+		for _, i := range k.Operators {
+			hh.AppendUint64(i)
+		}
+		hh.FillUpTo32()
+		numItems := uint64(len(k.Operators))
+		hh.MerkleizeWithMixin(subIndx, numItems, ssz.CalculateLimit(64, numItems, 8))
+	}
+
+	// Field (3) 'T'
+	hh.PutUint64(k.T)
+
+	// Field (4) 'Timestamp'
+	hh.PutUint64(k.Timestamp)
+
+	// Field (5) 'Expiry'
+	hh.PutUint64(k.Expiry)
+
+	hh.Merkleize(indx)
+	return
+}
+
+// GetTree ssz hashes the KeySign object
+func (k *KeySign) GetTree() (*ssz.Node, error) {
+	return ssz.ProofTree(k)
+}
+
+// MarshalSSZ ssz marshals the PartialSignature object
+func (p *PartialSignature) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(p)
+}
+
+// MarshalSSZTo ssz marshals the PartialSignature object to a target array
+func (p *PartialSignature) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+
+	// Field (0) 'ValidatorPK'
+	if size := len(p.ValidatorPK); size != 48 {
+		err = ssz.ErrBytesLengthFn("PartialSignature.ValidatorPK", size, 48)
+		return
+	}
+	dst = append(dst, p.ValidatorPK...)
+
+	// Field (1) 'SigningRoot'
+	if size := len(p.SigningRoot); size != 32 {
+		err = ssz.ErrBytesLengthFn("PartialSignature.SigningRoot", size, 32)
+		return
+	}
+	dst = append(dst, p.SigningRoot...)
+
+	// Field (2) 'SharePK'
+	if size := len(p.SharePK); size != 48 {
+		err = ssz.ErrBytesLengthFn("PartialSignature.SharePK", size, 48)
+		return
+	}
+	dst = append(dst, p.SharePK...)
+
+	// Field (3) 'Signature'
+	if size := len(p.Signature); size != 96 {
+		err = ssz.ErrBytesLengthFn("PartialSignature.Signature", size, 96)
+		return
+	}
+	dst = append(dst, p.Signature...)
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the PartialSignature object
+func (p *PartialSignature) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 224 {
+		return ssz.ErrSize
+	}
+
+	// Field (0) 'ValidatorPK'
+	if cap(p.ValidatorPK) == 0 {
+		p.ValidatorPK = make([]byte, 0, len(buf[0:48]))
+	}
+	p.ValidatorPK = append(p.ValidatorPK, buf[0:48]...)
+
+	// Field (1) 'SigningRoot'
+	if cap(p.SigningRoot) == 0 {
+		p.SigningRoot = make([]byte, 0, len(buf[48:80]))
+	}
+	p.SigningRoot = append(p.SigningRoot, buf[48:80]...)
+
+	// Field (2) 'SharePK'
+	if cap(p.SharePK) == 0 {
+		p.SharePK = make([]byte, 0, len(buf[80:128]))
+	}
+	p.SharePK = append(p.SharePK, buf[80:128]...)
+
+	// Field (3) 'Signature'
+	if cap(p.Signature) == 0 {
+		p.Signature = make([]byte, 0, len(buf[128:224]))
+	}
+	p.Signature = append(p.Signature, buf[128:224]...)
+
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the PartialSignature object
+func (p *PartialSignature) SizeSSZ() (size int) {
+	size = 224
+	return
+}
+
+// HashTreeRoot ssz hashes the PartialSignature object
+func (p *PartialSignature) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(p)
+}
+
+// HashTreeRootWith ssz hashes the PartialSignature object with a hasher
+func (p *PartialSignature) HashTreeRootWith(hh ssz.HashWalker) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'ValidatorPK'
+	if size := len(p.ValidatorPK); size != 48 {
+		err = ssz.ErrBytesLengthFn("PartialSignature.ValidatorPK", size, 48)
+		return
+	}
+	hh.PutBytes(p.ValidatorPK)
+
+	// Field (1) 'SigningRoot'
+	if size := len(p.SigningRoot); size != 32 {
+		err = ssz.ErrBytesLengthFn("PartialSignature.SigningRoot", size, 32)
+		return
+	}
+	hh.PutBytes(p.SigningRoot)
+
+	// Field (2) 'SharePK'
+	if size := len(p.SharePK); size != 48 {
+		err = ssz.ErrBytesLengthFn("PartialSignature.SharePK", size, 48)
+		return
+	}
+	hh.PutBytes(p.SharePK)
+
+	// Field (3) 'Signature'
+	if size := len(p.Signature); size != 96 {
+		err = ssz.ErrBytesLengthFn("PartialSignature.Signature", size, 96)
+		return
+	}
+	hh.PutBytes(p.Signature)
+
+	hh.Merkleize(indx)
+	return
+}
+
+// GetTree ssz hashes the PartialSignature object
+func (p *PartialSignature) GetTree() (*ssz.Node, error) {
+	return ssz.ProofTree(p)
+}
+
+// MarshalSSZ ssz marshals the BatchSignedTransports object
+func (b *BatchSignedTransports) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(b)
+}
+
+// MarshalSSZTo ssz marshals the BatchSignedTransports object to a target array
+func (b *BatchSignedTransports) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(4)
+
+	// Offset (0) 'Messages'
+	dst = ssz.WriteOffset(dst, offset)
+	for ii := 0; ii < len(b.Messages); ii++ {
+		offset += 4
+		offset += b.Messages[ii].SizeSSZ()
+	}
+
+	// Field (0) 'Messages'
+	if size := len(b.Messages); size > 128 {
+		err = ssz.ErrListTooBigFn("BatchSignedTransports.Messages", size, 128)
+		return
+	}
+	{
+		offset = 4 * len(b.Messages)
+		for ii := 0; ii < len(b.Messages); ii++ {
+			dst = ssz.WriteOffset(dst, offset)
+			offset += b.Messages[ii].SizeSSZ()
+		}
+	}
+	for ii := 0; ii < len(b.Messages); ii++ {
+		if dst, err = b.Messages[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the BatchSignedTransports object
+func (b *BatchSignedTransports) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 4 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o0 uint64
+
+	// Offset (0) 'Messages'
+	if o0 = ssz.ReadOffset(buf[0:4]); o0 > size {
+		return ssz.ErrOffset
+	}
+
+	if o0 < 4 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Field (0) 'Messages'
+	{
+		buf = tail[o0:]
+		num, err := ssz.DecodeDynamicLength(buf, 128)
+		if err != nil {
+			return err
+		}
+		b.Messages = make([]*SignedTransport, num)
+		err = ssz.UnmarshalDynamic(buf, num, func(indx int, buf []byte) (err error) {
+			if b.Messages[indx] == nil {
+				b.Messages[indx] = new(SignedTransport)
+			}
+			if err = b.Messages[indx].UnmarshalSSZ(buf); err != nil {
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the BatchSignedTransports object
+func (b *BatchSignedTransports) SizeSSZ() (size int) {
+	size = 4
+
+	// Field (0) 'Messages'
+	for ii := 0; ii < len(b.Messages); ii++ {
+		size += 4
+		size += b.Messages[ii].SizeSSZ()
+	}
+
+	return
+}
+
+// HashTreeRoot ssz hashes the BatchSignedTransports object
+func (b *BatchSignedTransports) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(b)
+}
+
+// HashTreeRootWith ssz hashes the BatchSignedTransports object with a hasher
+func (b *BatchSignedTransports) HashTreeRootWith(hh ssz.HashWalker) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Messages'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(b.Messages))
+		if num > 128 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for _, elem := range b.Messages {
+			if err = elem.HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		hh.MerkleizeWithMixin(subIndx, num, 128)
+	}
+
+	hh.Merkleize(indx)
+	return
+}
+
+// GetTree ssz hashes the BatchSignedTransports object
+func (b *BatchSignedTransports) GetTree() (*ssz.Node, error) {
+	return ssz.ProofTree(b)
+}
+
+// MarshalSSZ ssz marshals the BatchMultipleSignedTransports object
+func (b *BatchMultipleSignedTransports) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(b)
+}
+
+// MarshalSSZTo ssz marshals the BatchMultipleSignedTransports object to a target array
+func (b *BatchMultipleSignedTransports) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(4)
+
+	// Offset (0) 'Messages'
+	dst = ssz.WriteOffset(dst, offset)
+	for ii := 0; ii < len(b.Messages); ii++ {
+		offset += 4
+		offset += b.Messages[ii].SizeSSZ()
+	}
+
+	// Field (0) 'Messages'
+	if size := len(b.Messages); size > 128 {
+		err = ssz.ErrListTooBigFn("BatchMultipleSignedTransports.Messages", size, 128)
+		return
+	}
+	{
+		offset = 4 * len(b.Messages)
+		for ii := 0; ii < len(b.Messages); ii++ {
+			dst = ssz.WriteOffset(dst, offset)
+			offset += b.Messages[ii].SizeSSZ()
+		}
+	}
+	for ii := 0; ii < len(b.Messages); ii++ {
+		if dst, err = b.Messages[ii].MarshalSSZTo(dst); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the BatchMultipleSignedTransports object
+func (b *BatchMultipleSignedTransports) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 4 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o0 uint64
+
+	// Offset (0) 'Messages'
+	if o0 = ssz.ReadOffset(buf[0:4]); o0 > size {
+		return ssz.ErrOffset
+	}
+
+	if o0 < 4 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Field (0) 'Messages'
+	{
+		buf = tail[o0:]
+		num, err := ssz.DecodeDynamicLength(buf, 128)
+		if err != nil {
+			return err
+		}
+		b.Messages = make([]*MultipleSignedTransports, num)
+		err = ssz.UnmarshalDynamic(buf, num, func(indx int, buf []byte) (err error) {
+			if b.Messages[indx] == nil {
+				b.Messages[indx] = new(MultipleSignedTransports)
+			}
+			if err = b.Messages[indx].UnmarshalSSZ(buf); err != nil {
+				return err
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the BatchMultipleSignedTransports object
+func (b *BatchMultipleSignedTransports) SizeSSZ() (size int) {
+	size = 4
+
+	// Field (0) 'Messages'
+	for ii := 0; ii < len(b.Messages); ii++ {
+		size += 4
+		size += b.Messages[ii].SizeSSZ()
+	}
+
+	return
+}
+
+// HashTreeRoot ssz hashes the BatchMultipleSignedTransports object
+func (b *BatchMultipleSignedTransports) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(b)
+}
+
+// HashTreeRootWith ssz hashes the BatchMultipleSignedTransports object with a hasher
+func (b *BatchMultipleSignedTransports) HashTreeRootWith(hh ssz.HashWalker) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Messages'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(b.Messages))
+		if num > 128 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for _, elem := range b.Messages {
+			if err = elem.HashTreeRootWith(hh); err != nil {
+				return
+			}
+		}
+		hh.MerkleizeWithMixin(subIndx, num, 128)
+	}
+
+	hh.Merkleize(indx)
+	return
+}
+
+// GetTree ssz hashes the BatchMultipleSignedTransports object
+func (b *BatchMultipleSignedTransports) GetTree() (*ssz.Node, error) {
+	return ssz.ProofTree(b)
+}
+
+// MarshalSSZ ssz marshals the BatchResponse object
+func (b *BatchResponse) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(b)
+}
+
+// MarshalSSZTo ssz marshals the BatchResponse object to a target array
+func (b *BatchResponse) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(4)
+
+	// Offset (0) 'Responses'
+	dst = ssz.WriteOffset(dst, offset)
+	for ii := 0; ii < len(b.Responses); ii++ {
+		offset += 4
+		offset += len(b.Responses[ii])
+	}
+
+	// Field (0) 'Responses'
+	if size := len(b.Responses); size > 128 {
+		err = ssz.ErrListTooBigFn("BatchResponse.Responses", size, 128)
+		return
+	}
+	{
+		offset = 4 * len(b.Responses)
+		for ii := 0; ii < len(b.Responses); ii++ {
+			dst = ssz.WriteOffset(dst, offset)
+			offset += len(b.Responses[ii])
+		}
+	}
+	for ii := 0; ii < len(b.Responses); ii++ {
+		if size := len(b.Responses[ii]); size > 8388608 {
+			err = ssz.ErrBytesLengthFn("BatchResponse.Responses[ii]", size, 8388608)
+			return
+		}
+		dst = append(dst, b.Responses[ii]...)
+	}
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the BatchResponse object
+func (b *BatchResponse) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 4 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o0 uint64
+
+	// Offset (0) 'Responses'
+	if o0 = ssz.ReadOffset(buf[0:4]); o0 > size {
+		return ssz.ErrOffset
+	}
+
+	if o0 < 4 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Field (0) 'Responses'
+	{
+		buf = tail[o0:]
+		num, err := ssz.DecodeDynamicLength(buf, 128)
+		if err != nil {
+			return err
+		}
+		b.Responses = make([][]byte, num)
+		err = ssz.UnmarshalDynamic(buf, num, func(indx int, buf []byte) (err error) {
+			if len(buf) > 8388608 {
+				return ssz.ErrBytesLength
+			}
+			if cap(b.Responses[indx]) == 0 {
+				b.Responses[indx] = make([]byte, 0, len(buf))
+			}
+			b.Responses[indx] = append(b.Responses[indx], buf...)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the BatchResponse object
+func (b *BatchResponse) SizeSSZ() (size int) {
+	size = 4
+
+	// Field (0) 'Responses'
+	for ii := 0; ii < len(b.Responses); ii++ {
+		size += 4
+		size += len(b.Responses[ii])
+	}
+
+	return
+}
+
+// HashTreeRoot ssz hashes the BatchResponse object
+func (b *BatchResponse) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(b)
+}
+
+// HashTreeRootWith ssz hashes the BatchResponse object with a hasher
+func (b *BatchResponse) HashTreeRootWith(hh ssz.HashWalker) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Responses'
+	{
+		subIndx := hh.Index()
+		num := uint64(len(b.Responses))
+		if num > 128 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		for _, elem := range b.Responses {
+			{
+				elemIndx := hh.Index()
+				byteLen := uint64(len(elem))
+				if byteLen > 8388608 {
+					err = ssz.ErrIncorrectListSize
+					return
+				}
+				hh.AppendBytes32(elem)
+				hh.MerkleizeWithMixin(elemIndx, byteLen, (8388608+31)/32)
+			}
+		}
+		hh.MerkleizeWithMixin(subIndx, num, 128)
+	}
+
+	hh.Merkleize(indx)
+	return
+}
+
+// GetTree ssz hashes the BatchResponse object
+func (b *BatchResponse) GetTree() (*ssz.Node, error) {
+	return ssz.ProofTree(b)
+}
+
+// MarshalSSZ ssz marshals the Proof object
+func (p *Proof) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(p)
+}
+
+// MarshalSSZTo ssz marshals the Proof object to a target array
+func (p *Proof) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+
+	// Field (0) 'OperatorID'
+	dst = ssz.MarshalUint64(dst, p.OperatorID)
+
+	// Field (1) 'SharePubKey'
+	if size := len(p.SharePubKey); size != 48 {
+		err = ssz.ErrBytesLengthFn("Proof.SharePubKey", size, 48)
+		return
+	}
+	dst = append(dst, p.SharePubKey...)
+
+	// Field (2) 'ValidatorPubKey'
+	if size := len(p.ValidatorPubKey); size != 48 {
+		err = ssz.ErrBytesLengthFn("Proof.ValidatorPubKey", size, 48)
+		return
+	}
+	dst = append(dst, p.ValidatorPubKey...)
+
+	// Field (3) 'Owner'
+	dst = append(dst, p.Owner[:]...)
+
+	// Field (4) 'Nonce'
+	dst = ssz.MarshalUint64(dst, p.Nonce)
+
+	// Field (5) 'RequestID'
+	dst = append(dst, p.RequestID[:]...)
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the Proof object
+func (p *Proof) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size != 156 {
+		return ssz.ErrSize
+	}
+
+	// Field (0) 'OperatorID'
+	p.OperatorID = ssz.UnmarshallUint64(buf[0:8])
+
+	// Field (1) 'SharePubKey'
+	if cap(p.SharePubKey) == 0 {
+		p.SharePubKey = make([]byte, 0, len(buf[8:56]))
+	}
+	p.SharePubKey = append(p.SharePubKey, buf[8:56]...)
+
+	// Field (2) 'ValidatorPubKey'
+	if cap(p.ValidatorPubKey) == 0 {
+		p.ValidatorPubKey = make([]byte, 0, len(buf[56:104]))
+	}
+	p.ValidatorPubKey = append(p.ValidatorPubKey, buf[56:104]...)
+
+	// Field (3) 'Owner'
+	copy(p.Owner[:], buf[104:124])
+
+	// Field (4) 'Nonce'
+	p.Nonce = ssz.UnmarshallUint64(buf[124:132])
+
+	// Field (5) 'RequestID'
+	copy(p.RequestID[:], buf[132:156])
+
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the Proof object
+func (p *Proof) SizeSSZ() (size int) {
+	size = 156
+	return
+}
+
+// HashTreeRoot ssz hashes the Proof object
+func (p *Proof) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(p)
+}
+
+// HashTreeRootWith ssz hashes the Proof object with a hasher
+func (p *Proof) HashTreeRootWith(hh ssz.HashWalker) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'OperatorID'
+	hh.PutUint64(p.OperatorID)
+
+	// Field (1) 'SharePubKey'
+	if size := len(p.SharePubKey); size != 48 {
+		err = ssz.ErrBytesLengthFn("Proof.SharePubKey", size, 48)
+		return
+	}
+	hh.PutBytes(p.SharePubKey)
+
+	// Field (2) 'ValidatorPubKey'
+	if size := len(p.ValidatorPubKey); size != 48 {
+		err = ssz.ErrBytesLengthFn("Proof.ValidatorPubKey", size, 48)
+		return
+	}
+	hh.PutBytes(p.ValidatorPubKey)
+
+	// Field (3) 'Owner'
+	hh.PutBytes(p.Owner[:])
+
+	// Field (4) 'Nonce'
+	hh.PutUint64(p.Nonce)
+
+	// Field (5) 'RequestID'
+	hh.PutBytes(p.RequestID[:])
+
+	hh.Merkleize(indx)
+	return
+}
+
+// GetTree ssz hashes the Proof object
+func (p *Proof) GetTree() (*ssz.Node, error) {
+	return ssz.ProofTree(p)
+}
+
+// MarshalSSZ ssz marshals the SignedProof object
+func (s *SignedProof) MarshalSSZ() ([]byte, error) {
+	return ssz.MarshalSSZ(s)
+}
+
+// MarshalSSZTo ssz marshals the SignedProof object to a target array
+func (s *SignedProof) MarshalSSZTo(buf []byte) (dst []byte, err error) {
+	dst = buf
+	offset := int(160)
+
+	// Field (0) 'Proof'
+	if s.Proof == nil {
+		s.Proof = new(Proof)
+	}
+	if dst, err = s.Proof.MarshalSSZTo(dst); err != nil {
+		return
+	}
+
+	// Offset (1) 'Signature'
+	dst = ssz.WriteOffset(dst, offset)
+	offset += len(s.Signature)
+
+	// Field (1) 'Signature'
+	if size := len(s.Signature); size > 2048 {
+		err = ssz.ErrBytesLengthFn("SignedProof.Signature", size, 2048)
+		return
+	}
+	dst = append(dst, s.Signature...)
+
+	return
+}
+
+// UnmarshalSSZ ssz unmarshals the SignedProof object
+func (s *SignedProof) UnmarshalSSZ(buf []byte) error {
+	var err error
+	size := uint64(len(buf))
+	if size < 160 {
+		return ssz.ErrSize
+	}
+
+	tail := buf
+	var o1 uint64
+
+	// Field (0) 'Proof'
+	if s.Proof == nil {
+		s.Proof = new(Proof)
+	}
+	if err = s.Proof.UnmarshalSSZ(buf[0:156]); err != nil {
+		return err
+	}
+
+	// Offset (1) 'Signature'
+	if o1 = ssz.ReadOffset(buf[156:160]); o1 > size {
+		return ssz.ErrOffset
+	}
+
+	if o1 < 160 {
+		return ssz.ErrInvalidVariableOffset
+	}
+
+	// Field (1) 'Signature'
+	{
+		buf = tail[o1:]
+		if len(buf) > 2048 {
+			return ssz.ErrBytesLength
+		}
+		if cap(s.Signature) == 0 {
+			s.Signature = make([]byte, 0, len(buf))
+		}
+		s.Signature = append(s.Signature, buf...)
+	}
+	return err
+}
+
+// SizeSSZ returns the ssz encoded size in bytes for the SignedProof object
+func (s *SignedProof) SizeSSZ() (size int) {
+	size = 160
+
+	// Field (1) 'Signature'
+	size += len(s.Signature)
+
+	return
+}
+
+// HashTreeRoot ssz hashes the SignedProof object
+func (s *SignedProof) HashTreeRoot() ([32]byte, error) {
+	return ssz.HashWithDefaultHasher(s)
+}
+
+// HashTreeRootWith ssz hashes the SignedProof object with a hasher
+func (s *SignedProof) HashTreeRootWith(hh ssz.HashWalker) (err error) {
+	indx := hh.Index()
+
+	// Field (0) 'Proof'
+	if s.Proof == nil {
+		s.Proof = new(Proof)
+	}
+	if err = s.Proof.HashTreeRootWith(hh); err != nil {
+		return
+	}
+
+	// Field (1) 'Signature'
+	{
+		elemIndx := hh.Index()
+		byteLen := uint64(len(s.Signature))
+		if byteLen > 2048 {
+			err = ssz.ErrIncorrectListSize
+			return
+		}
+		hh.Append(s.Signature)
+		hh.MerkleizeWithMixin(elemIndx, byteLen, (2048+31)/32)
+	}
+
+	hh.Merkleize(indx)
+	return
+}
+
+// GetTree ssz hashes the SignedProof object
+func (s *SignedProof) GetTree() (*ssz.Node, error) {
+	return ssz.ProofTree(s)
+}
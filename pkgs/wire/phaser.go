@@ -0,0 +1,171 @@
+package wire
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/drand/kyber/share/dkg"
+)
+
+// DefaultPhaseTimeout is the max duration of a DKG phase when no timeout is configured
+const DefaultPhaseTimeout = time.Second * 5
+
+// PhaserType selects how the DKG protocol moves between phases
+type PhaserType uint8
+
+const (
+	// EventPhaser moves to the next phase as soon as all expected bundles of the phase arrive,
+	// the phase timeout is a fallback for missing bundles
+	EventPhaser PhaserType = iota
+	// TimePhaser moves to the next phase after the phase timeout
+	TimePhaser
+)
+
+func (t PhaserType) String() string {
+	switch t {
+	case EventPhaser:
+		return "event"
+	case TimePhaser:
+		return "time"
+	default:
+		return "unknown"
+	}
+}
+
+// ParsePhaserType returns the phaser type by its name, event or time
+func ParsePhaserType(name string) (PhaserType, error) {
+	switch name {
+	case EventPhaser.String():
+		return EventPhaser, nil
+	case TimePhaser.String():
+		return TimePhaser, nil
+	default:
+		return 0, fmt.Errorf("unknown phaser %s, should be event or time", name)
+	}
+}
+
+// eventPhaser wraps the board of the protocol, it follows the bundles coming from the board
+// and signals the next phase when all expected bundles of the phase arrived
+type eventPhaser struct {
+	dkg.Board
+	out     chan dkg.Phase
+	deals   chan dkg.DealBundle
+	resps   chan dkg.ResponseBundle
+	justifs chan dkg.JustificationBundle
+	dealers int
+	timeout time.Duration
+	phaseF  func(dkg.Phase)
+	stop    <-chan struct{}
+	// dealt are the dealers whose deals arrived
+	dealt map[uint32]struct{}
+	// complained are the dealers share holders complained about
+	complained map[uint32]struct{}
+	// justified are the dealers whose justifications arrived
+	justified map[uint32]struct{}
+}
+
+func newEventPhaser(board dkg.Board, dealers int, timeout time.Duration, phaseF func(dkg.Phase), stop <-chan struct{}) *eventPhaser {
+	return &eventPhaser{
+		Board:      board,
+		out:        make(chan dkg.Phase, 4),
+		deals:      make(chan dkg.DealBundle),
+		resps:      make(chan dkg.ResponseBundle),
+		justifs:    make(chan dkg.JustificationBundle),
+		dealers:    dealers,
+		timeout:    timeout,
+		phaseF:     phaseF,
+		stop:       stop,
+		dealt:      make(map[uint32]struct{}),
+		complained: make(map[uint32]struct{}),
+		justified:  make(map[uint32]struct{}),
+	}
+}
+
+func (e *eventPhaser) NextPhase() chan dkg.Phase {
+	return e.out
+}
+
+func (e *eventPhaser) IncomingDeal() <-chan dkg.DealBundle {
+	return e.deals
+}
+
+func (e *eventPhaser) IncomingResponse() <-chan dkg.ResponseBundle {
+	return e.resps
+}
+
+func (e *eventPhaser) IncomingJustification() <-chan dkg.JustificationBundle {
+	return e.justifs
+}
+
+// Start forwards the bundles of the board to the protocol and signals the phases,
+// it returns after the finish phase. When stopped, the remaining phases are signalled right away.
+func (e *eventPhaser) Start() {
+	e.phaseF(dkg.DealPhase)
+	e.out <- dkg.DealPhase
+	for _, phase := range []dkg.Phase{dkg.DealPhase, dkg.ResponsePhase, dkg.JustifPhase} {
+		timer := time.NewTimer(e.timeout)
+		timeout := timer.C
+	wait:
+		for !e.complete(phase) {
+			// a bundle is forwarded before it's counted, so the protocol has all bundles of a phase when moving to the next one
+			select {
+			case b := <-e.Board.IncomingDeal():
+				if !forward(e.deals, b, timeout, e.stop) {
+					break wait
+				}
+				e.dealt[b.DealerIndex] = struct{}{}
+			case b := <-e.Board.IncomingResponse():
+				if !forward(e.resps, b, timeout, e.stop) {
+					break wait
+				}
+				for _, r := range b.Responses {
+					if r.Status == dkg.Complaint {
+						e.complained[r.DealerIndex] = struct{}{}
+					}
+				}
+			case b := <-e.Board.IncomingJustification():
+				if !forward(e.justifs, b, timeout, e.stop) {
+					break wait
+				}
+				e.justified[b.DealerIndex] = struct{}{}
+			case <-timeout:
+				break wait
+			case <-e.stop:
+				break wait
+			}
+		}
+		timer.Stop()
+		e.phaseF(phase + 1)
+		e.out <- phase + 1
+	}
+}
+
+// complete checks all expected bundles of the phase arrived
+func (e *eventPhaser) complete(phase dkg.Phase) bool {
+	switch phase {
+	case dkg.DealPhase:
+		return len(e.dealt) >= e.dealers
+	case dkg.ResponsePhase:
+		// share holders only send complaints about invalid deals, no response is expected
+		return true
+	default:
+		for dealer := range e.complained {
+			if _, ok := e.justified[dealer]; !ok {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// forward sends the bundle to the protocol, false if the protocol didn't read it before the timeout or the stop
+func forward[T any](c chan<- T, bundle T, timeout <-chan time.Time, stop <-chan struct{}) bool {
+	select {
+	case c <- bundle:
+		return true
+	case <-timeout:
+		return false
+	case <-stop:
+		return false
+	}
+}